@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+	"write_base/config"
+	"write_base/internal/repository"
+	"write_base/internal/usecase"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// reconcile recomputes every article's unique view, clap, comment and reaction counters
+// from their source collections and reports the articles that drifted.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report drift without correcting it")
+	timeout := flag.Duration("timeout", 10*time.Minute, "give up after this long")
+	flag.Parse()
+
+	cfg, err := config.LoadEnv()
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongodbURI))
+	if err != nil {
+		log.Fatalf("MongoDB connection failed: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	stats := usecase.NewStatsReconcileUsecase(repository.NewArticleStatsRepository(client.Database(cfg.MongodbName)))
+	report, err := stats.Reconcile(ctx, *dryRun)
+	if err != nil {
+		log.Fatalf("Reconcile failed: %v", err)
+	}
+
+	for _, d := range report.Drifted {
+		fmt.Printf("%s\tunique views %d -> %d\tclaps %d -> %d\tcomments %d -> %d\treactions %d -> %d\n", d.ArticleID,
+			d.Stored.UniqueViewCount, d.Actual.UniqueViewCount,
+			d.Stored.ClapCount, d.Actual.ClapCount,
+			d.Stored.CommentCount, d.Actual.CommentCount,
+			d.Stored.ReactionCount, d.Actual.ReactionCount)
+	}
+	if report.DryRun {
+		fmt.Printf("%d of %d articles drifted (dry run, nothing changed)\n", len(report.Drifted), report.Checked)
+		return
+	}
+	fmt.Printf("%d of %d articles drifted, %d fixed\n", len(report.Drifted), report.Checked, report.Fixed)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+	"write_base/config"
+	"write_base/internal/domain"
+	"write_base/internal/infrastructure/search"
+	"write_base/internal/repository"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// reindex rebuilds the embedded search index from the published articles in
+// MongoDB. The index file is locked while the server has it open, so run this
+// with the server stopped.
+func main() {
+	batch := flag.Int("batch", 500, "articles indexed per batch")
+	timeout := flag.Duration("timeout", 30*time.Minute, "give up after this long")
+	flag.Parse()
+
+	cfg, err := config.LoadEnv()
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+	if cfg.SearchBackend != "bleve" {
+		fmt.Printf("SEARCH_BACKEND is %q, which keeps no index; nothing to rebuild\n", cfg.SearchBackend)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongodbURI))
+	if err != nil {
+		log.Fatalf("MongoDB connection failed: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	articles := repository.NewArticleRepository(client.Database(cfg.MongodbName), "articles")
+	index, err := search.NewBleveIndex(cfg.SearchIndexPath, articles)
+	if err != nil {
+		log.Fatalf("Opening search index failed: %v", err)
+	}
+	defer index.Close()
+
+	if err := index.Reset(ctx); err != nil {
+		log.Fatalf("Clearing search index failed: %v", err)
+	}
+	indexed := 0
+	err = articles.ForEachPublished(ctx, *batch, func(b []domain.Article) error {
+		if err := index.Index(ctx, b...); err != nil {
+			return err
+		}
+		indexed += len(b)
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Rebuild stopped after %d articles: %v", indexed, err)
+	}
+	fmt.Printf("%d articles indexed into %s\n", indexed, cfg.SearchIndexPath)
+}
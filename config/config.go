@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -26,6 +28,29 @@ type Config struct {
 	ClientID    string
 	ClientSecret string
 	RedirectURL string
+	AICacheBackend    string
+	AICacheTTL        time.Duration
+	AICacheMaxEntries int64
+	AICacheTrimInterval time.Duration
+	ModerationWordListPath string
+	ModerationMaxLinks     int
+	ModerationAIClassifier bool
+	TrendingInterval       time.Duration
+	TrendingWindow         time.Duration
+	TrendingHalfLife       time.Duration
+	TrendingViewWeight     float64
+	TrendingClapWeight     float64
+	TrendingCommentWeight  float64
+	TrendingReactionWeight float64
+	SearchBackend          string
+	SearchIndexPath        string
+	SuggestRefreshInterval time.Duration
+	NotificationBroker     string
+	NotificationHeartbeat  time.Duration
+	ReactionTypes          string
+	StatsReconcileInterval time.Duration
+	ViewWindow             time.Duration
+	TrustedProxies         []string
 }
 
 func LoadEnv() (*Config, error) {
@@ -46,6 +71,116 @@ func LoadEnv() (*Config, error) {
 		ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
 		RedirectURL:  os.Getenv("GOOGLE_REDIRECT_URL"),
 			   GeminiAPIKey: os.Getenv("GEMINI_API_KEY"),
+		AICacheBackend:    os.Getenv("AI_CACHE_BACKEND"),
+		AICacheTTL:        24 * time.Hour,
+		AICacheMaxEntries: 1000,
+		AICacheTrimInterval: time.Minute,
+		ModerationWordListPath: os.Getenv("MODERATION_WORDLIST_PATH"),
+		ModerationMaxLinks:     3,
+		ModerationAIClassifier: os.Getenv("MODERATION_AI_CLASSIFIER") == "true",
+		TrendingInterval:       15 * time.Minute,
+		TrendingWindow:         7 * 24 * time.Hour,
+		TrendingHalfLife:       24 * time.Hour,
+		TrendingViewWeight:     1,
+		TrendingClapWeight:     2,
+		TrendingCommentWeight:  5,
+		TrendingReactionWeight: 3,
+		SearchBackend:          os.Getenv("SEARCH_BACKEND"),
+		SearchIndexPath:        os.Getenv("SEARCH_INDEX_PATH"),
+		SuggestRefreshInterval: 5 * time.Minute,
+		NotificationBroker:     os.Getenv("NOTIFICATION_BROKER"),
+		NotificationHeartbeat:  25 * time.Second,
+		ReactionTypes:          os.Getenv("REACTION_TYPES"),
+		StatsReconcileInterval: 6 * time.Hour,
+		ViewWindow:             30 * time.Minute,
+	   }
+
+	   // Optional AI cache settings; fall back to the defaults above when unset
+	   if cfg.AICacheBackend == "" {
+			   cfg.AICacheBackend = "memory"
+	   }
+	   if v := os.Getenv("AI_CACHE_TTL"); v != "" {
+			   ttl, err := time.ParseDuration(v)
+			   if err != nil {
+					   return nil, fmt.Errorf("invalid AI_CACHE_TTL: %w", err)
+			   }
+			   cfg.AICacheTTL = ttl
+	   }
+	   if v := os.Getenv("AI_CACHE_MAX_ENTRIES"); v != "" {
+			   n, err := strconv.ParseInt(v, 10, 64)
+			   if err != nil {
+					   return nil, fmt.Errorf("invalid AI_CACHE_MAX_ENTRIES: %w", err)
+			   }
+			   cfg.AICacheMaxEntries = n
+	   }
+	   if v := os.Getenv("MODERATION_MAX_LINKS"); v != "" {
+			   n, err := strconv.Atoi(v)
+			   if err != nil {
+					   return nil, fmt.Errorf("invalid MODERATION_MAX_LINKS: %w", err)
+			   }
+			   cfg.ModerationMaxLinks = n
+	   }
+
+	   // Optional trending score settings
+	   for key, dst := range map[string]*time.Duration{
+			   "TRENDING_INTERVAL":  &cfg.TrendingInterval,
+			   "TRENDING_WINDOW":    &cfg.TrendingWindow,
+			   "TRENDING_HALF_LIFE": &cfg.TrendingHalfLife,
+			   "SUGGEST_REFRESH_INTERVAL": &cfg.SuggestRefreshInterval,
+			   "NOTIFICATION_HEARTBEAT": &cfg.NotificationHeartbeat,
+			   "STATS_RECONCILE_INTERVAL": &cfg.StatsReconcileInterval,
+			   "VIEW_WINDOW": &cfg.ViewWindow,
+			   "AI_CACHE_TRIM_INTERVAL": &cfg.AICacheTrimInterval,
+	   } {
+			   if v := os.Getenv(key); v != "" {
+					   d, err := time.ParseDuration(v)
+					   if err != nil || d <= 0 {
+							   return nil, fmt.Errorf("invalid %s: %q", key, v)
+					   }
+					   *dst = d
+			   }
+	   }
+	   for key, dst := range map[string]*float64{
+			   "TRENDING_VIEW_WEIGHT":     &cfg.TrendingViewWeight,
+			   "TRENDING_CLAP_WEIGHT":     &cfg.TrendingClapWeight,
+			   "TRENDING_COMMENT_WEIGHT":  &cfg.TrendingCommentWeight,
+			   "TRENDING_REACTION_WEIGHT": &cfg.TrendingReactionWeight,
+	   } {
+			   if v := os.Getenv(key); v != "" {
+					   w, err := strconv.ParseFloat(v, 64)
+					   if err != nil || w < 0 {
+							   return nil, fmt.Errorf("invalid %s: %q", key, v)
+					   }
+					   *dst = w
+			   }
+	   }
+
+	   // Proxies whose X-Forwarded-For is believed; none unless listed
+	   for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+			   if proxy = strings.TrimSpace(proxy); proxy != "" {
+					   cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+			   }
+	   }
+
+	   // Search backend: MongoDB text search unless the embedded index is chosen
+	   switch cfg.SearchBackend {
+	   case "":
+			   cfg.SearchBackend = "mongo"
+	   case "mongo", "bleve":
+	   default:
+			   return nil, fmt.Errorf("invalid SEARCH_BACKEND: %q", cfg.SearchBackend)
+	   }
+	   if cfg.SearchIndexPath == "" {
+			   cfg.SearchIndexPath = "data/search.bleve"
+	   }
+
+	   // Notification broker: in-process unless instances share a change stream
+	   switch cfg.NotificationBroker {
+	   case "":
+			   cfg.NotificationBroker = "memory"
+	   case "memory", "changestream":
+	   default:
+			   return nil, fmt.Errorf("invalid NOTIFICATION_BROKER: %q", cfg.NotificationBroker)
 	   }
 
 	   var missing []string
@@ -61,3 +61,134 @@ func TestLoadEnv_MissingVariables(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadEnv_AICacheSettings(t *testing.T) {
+	base := map[string]string{
+		"MONGODB_URI":    "mongodb://localhost:27017",
+		"MONGODB_NAME":   "write_base",
+		"JWT_SECRET":     "secret",
+		"SERVER_PORT":    "8080",
+		"GEMINI_API_KEY": "key",
+	}
+	withEnv(base, func() {
+		withEnv(map[string]string{"AI_CACHE_BACKEND": "mongo", "AI_CACHE_TTL": "2h", "AI_CACHE_MAX_ENTRIES": "50", "AI_CACHE_TRIM_INTERVAL": "30s"}, func() {
+			cfg, err := LoadEnv()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.AICacheBackend != "mongo" || cfg.AICacheTTL.Hours() != 2 || cfg.AICacheMaxEntries != 50 || cfg.AICacheTrimInterval.Seconds() != 30 {
+				t.Fatalf("unexpected ai cache config: %+v", cfg)
+			}
+		})
+		withEnv(map[string]string{"AI_CACHE_TRIM_INTERVAL": "0s"}, func() {
+			if _, err := LoadEnv(); err == nil {
+				t.Fatalf("expected error for invalid AI_CACHE_TRIM_INTERVAL")
+			}
+		})
+		withEnv(map[string]string{"AI_CACHE_TTL": "soon"}, func() {
+			if _, err := LoadEnv(); err == nil {
+				t.Fatalf("expected error for invalid AI_CACHE_TTL")
+			}
+		})
+	})
+}
+
+func TestLoadEnv_TrendingSettings(t *testing.T) {
+	base := map[string]string{
+		"MONGODB_URI":    "mongodb://localhost:27017",
+		"MONGODB_NAME":   "write_base",
+		"JWT_SECRET":     "secret",
+		"SERVER_PORT":    "8080",
+		"GEMINI_API_KEY": "key",
+	}
+	withEnv(base, func() {
+		withEnv(map[string]string{"TRENDING_WINDOW": "48h", "TRENDING_HALF_LIFE": "6h", "TRENDING_COMMENT_WEIGHT": "10"}, func() {
+			cfg, err := LoadEnv()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.TrendingWindow.Hours() != 48 || cfg.TrendingHalfLife.Hours() != 6 || cfg.TrendingCommentWeight != 10 || cfg.TrendingViewWeight != 1 {
+				t.Fatalf("unexpected trending config: %+v", cfg)
+			}
+		})
+		withEnv(map[string]string{"SUGGEST_REFRESH_INTERVAL": "30s"}, func() {
+			cfg, err := LoadEnv()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.SuggestRefreshInterval.Seconds() != 30 {
+				t.Fatalf("unexpected suggest refresh interval: %v", cfg.SuggestRefreshInterval)
+			}
+		})
+		withEnv(map[string]string{"TRENDING_CLAP_WEIGHT": "-1"}, func() {
+			if _, err := LoadEnv(); err == nil {
+				t.Fatalf("expected error for negative TRENDING_CLAP_WEIGHT")
+			}
+		})
+	})
+}
+
+func TestLoadEnv_SearchSettings(t *testing.T) {
+	base := map[string]string{
+		"MONGODB_URI":    "mongodb://localhost:27017",
+		"MONGODB_NAME":   "write_base",
+		"JWT_SECRET":     "secret",
+		"SERVER_PORT":    "8080",
+		"GEMINI_API_KEY": "key",
+	}
+	withEnv(base, func() {
+		withEnv(map[string]string{"SEARCH_BACKEND": "", "SEARCH_INDEX_PATH": ""}, func() {
+			cfg, err := LoadEnv()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.SearchBackend != "mongo" || cfg.SearchIndexPath != "data/search.bleve" {
+				t.Fatalf("unexpected search defaults: %q %q", cfg.SearchBackend, cfg.SearchIndexPath)
+			}
+		})
+		withEnv(map[string]string{"SEARCH_BACKEND": "bleve", "SEARCH_INDEX_PATH": "/var/lib/wb/index"}, func() {
+			cfg, err := LoadEnv()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.SearchBackend != "bleve" || cfg.SearchIndexPath != "/var/lib/wb/index" {
+				t.Fatalf("unexpected search config: %q %q", cfg.SearchBackend, cfg.SearchIndexPath)
+			}
+		})
+		withEnv(map[string]string{"SEARCH_BACKEND": "elastic"}, func() {
+			if _, err := LoadEnv(); err == nil {
+				t.Fatalf("expected error for unknown SEARCH_BACKEND")
+			}
+		})
+	})
+}
+
+func TestLoadEnv_TrustedProxies(t *testing.T) {
+	base := map[string]string{
+		"MONGODB_URI":    "mongodb://localhost:27017",
+		"MONGODB_NAME":   "write_base",
+		"JWT_SECRET":     "secret",
+		"SERVER_PORT":    "8080",
+		"GEMINI_API_KEY": "key",
+	}
+	withEnv(base, func() {
+		withEnv(map[string]string{"TRUSTED_PROXIES": ""}, func() {
+			cfg, err := LoadEnv()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.TrustedProxies != nil {
+				t.Fatalf("expected no trusted proxies, got %v", cfg.TrustedProxies)
+			}
+		})
+		withEnv(map[string]string{"TRUSTED_PROXIES": "10.0.0.1, 172.16.0.0/12,"}, func() {
+			cfg, err := LoadEnv()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(cfg.TrustedProxies) != 2 || cfg.TrustedProxies[0] != "10.0.0.1" || cfg.TrustedProxies[1] != "172.16.0.0/12" {
+				t.Fatalf("unexpected trusted proxies: %v", cfg.TrustedProxies)
+			}
+		})
+	})
+}
@@ -0,0 +1,150 @@
+package ai
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"write_base/internal/domain"
+)
+
+// CacheKey builds the cache key for a deterministic AI request. The input is
+// normalized (trimmed, lower-cased, whitespace collapsed) so trivially different
+// requests share an entry, and the prompt version is included so that editing a
+// template invalidates everything rendered from the old one.
+func CacheKey(provider, model, prompt string, promptVersion int, input string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	sum := sha256.Sum256([]byte(strings.Join([]string{provider, model, prompt, strconv.Itoa(promptVersion), normalized}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+//===============================================================================//
+//                             In-memory cache                                   //
+//===============================================================================//
+
+type memoryEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+// MemoryCache is a per-process LRU cache with a fixed TTL per entry.
+type MemoryCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	order      *list.List // front = most recently used
+	entries    map[string]*list.Element
+	hits       int64
+	misses     int64
+	now        func() time.Time
+}
+
+var _ domain.IAICache = (*MemoryCache)(nil)
+
+func NewMemoryCache(ttl time.Duration, maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+func (m *MemoryCache) Get(ctx context.Context, key string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if ok && m.now().After(el.Value.(*memoryEntry).expiresAt) {
+		m.order.Remove(el)
+		delete(m.entries, key)
+		ok = false
+	}
+	if !ok {
+		m.misses++
+		return "", false, nil
+	}
+	m.hits++
+	m.order.MoveToFront(el)
+	return el.Value.(*memoryEntry).value, true, nil
+}
+
+func (m *MemoryCache) Set(ctx context.Context, key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt := m.now().Add(m.ttl)
+	if el, ok := m.entries[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.order.MoveToFront(el)
+		return nil
+	}
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+
+	// Evict least recently used entries once over the size limit
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+func (m *MemoryCache) Stats(ctx context.Context) (*domain.AICacheStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &domain.AICacheStats{
+		Backend: "memory",
+		Entries: int64(m.order.Len()),
+		Hits:    m.hits,
+		Misses:  m.misses,
+	}, nil
+}
+
+//===============================================================================//
+//                              Caching client                                   //
+//===============================================================================//
+
+// CachedClient wraps a domain.IAI and serves repeated slug requests from cache.
+// Free-form content generation is passed straight through since its output is
+// expected to vary between calls.
+type CachedClient struct {
+	next     domain.IAI
+	cache    domain.IAICache
+	provider string
+	model    string
+}
+
+var _ domain.IAI = (*CachedClient)(nil)
+
+func NewCachedClient(next domain.IAI, cache domain.IAICache, provider, model string) *CachedClient {
+	return &CachedClient{next: next, cache: cache, provider: provider, model: model}
+}
+
+func (c *CachedClient) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return c.next.GenerateContent(ctx, prompt)
+}
+
+func (c *CachedClient) GenerateSlug(ctx context.Context, title string) (string, error) {
+	// The slug prompt is not templated yet, so it is always version 0
+	key := CacheKey(c.provider, c.model, "slug", 0, title)
+	if slug, ok, err := c.cache.Get(ctx, key); err == nil && ok {
+		return slug, nil
+	}
+
+	slug, err := c.next.GenerateSlug(ctx, title)
+	if err != nil {
+		return "", err
+	}
+	// A failed cache write must not fail the request
+	_ = c.cache.Set(ctx, key, slug)
+	return slug, nil
+}
@@ -0,0 +1,99 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheKey_NormalizesInput(t *testing.T) {
+	a := CacheKey("gemini", "m1", "slug", 0, "  Hello   World ")
+	b := CacheKey("gemini", "m1", "slug", 0, "hello world")
+	require.Equal(t, a, b)
+
+	require.NotEqual(t, a, CacheKey("gemini", "m2", "slug", 0, "hello world"))
+	require.NotEqual(t, a, CacheKey("gemini", "m1", "slug", 1, "hello world"))
+	require.NotEqual(t, a, CacheKey("openai", "m1", "slug", 0, "hello world"))
+}
+
+func TestMemoryCache_HitMissAndExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewMemoryCache(time.Minute, 10)
+	c.now = func() time.Time { return now }
+
+	_, ok, _ := c.Get(ctx, "k")
+	require.False(t, ok)
+
+	require.NoError(t, c.Set(ctx, "k", "v"))
+	v, ok, _ := c.Get(ctx, "k")
+	require.True(t, ok)
+	require.Equal(t, "v", v)
+
+	now = now.Add(2 * time.Minute)
+	_, ok, _ = c.Get(ctx, "k")
+	require.False(t, ok)
+
+	stats, _ := c.Stats(ctx)
+	require.Equal(t, int64(1), stats.Hits)
+	require.Equal(t, int64(2), stats.Misses)
+	require.Equal(t, int64(0), stats.Entries)
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(time.Hour, 2)
+
+	_ = c.Set(ctx, "a", "1")
+	_ = c.Set(ctx, "b", "2")
+	_, _, _ = c.Get(ctx, "a") // a is now most recently used
+	_ = c.Set(ctx, "c", "3")
+
+	_, ok, _ := c.Get(ctx, "b")
+	require.False(t, ok)
+	_, ok, _ = c.Get(ctx, "a")
+	require.True(t, ok)
+	_, ok, _ = c.Get(ctx, "c")
+	require.True(t, ok)
+}
+
+type fakeAI struct {
+	slugCalls int
+	err       error
+}
+
+func (f *fakeAI) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return "content", nil
+}
+func (f *fakeAI) GenerateSlug(ctx context.Context, title string) (string, error) {
+	f.slugCalls++
+	return "my-title", f.err
+}
+
+func TestCachedClient_GenerateSlugUsesCache(t *testing.T) {
+	ctx := context.Background()
+	next := &fakeAI{}
+	c := NewCachedClient(next, NewMemoryCache(time.Hour, 10), "gemini", GeminiModel)
+
+	for _, title := range []string{"My Title", "my  title"} {
+		slug, err := c.GenerateSlug(ctx, title)
+		require.NoError(t, err)
+		require.Equal(t, "my-title", slug)
+	}
+	require.Equal(t, 1, next.slugCalls)
+}
+
+func TestCachedClient_ErrorsAreNotCached(t *testing.T) {
+	ctx := context.Background()
+	next := &fakeAI{err: errors.New("boom")}
+	c := NewCachedClient(next, NewMemoryCache(time.Hour, 10), "gemini", GeminiModel)
+
+	_, err := c.GenerateSlug(ctx, "title")
+	require.Error(t, err)
+	_, err = c.GenerateSlug(ctx, "title")
+	require.Error(t, err)
+	require.Equal(t, 2, next.slugCalls)
+}
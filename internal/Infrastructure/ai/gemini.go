@@ -11,6 +11,9 @@ import (
 	"google.golang.org/api/option"
 )
 
+// GeminiModel is the model used by GeminiClient; it is part of AI cache keys.
+const GeminiModel = "gemini-2.0-flash"
+
 type GeminiClient struct {
 	client *genai.GenerativeModel
 }
@@ -24,7 +27,7 @@ func NewGeminiClient(apiKey string) *GeminiClient {
 	if err != nil {
 		log.Fatalf("Failed to create Gemini client: %v", err)
 	}
-	model := client.GenerativeModel("gemini-2.0-flash")
+	model := client.GenerativeModel(GeminiModel)
 
 	// Configure the model
 	model.SetTemperature(0.7)
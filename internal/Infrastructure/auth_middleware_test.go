@@ -74,3 +74,28 @@ func TestAuthMiddleware_Success(t *testing.T) {
 		t.Fatalf("want 200 got %d", w.Code)
 	}
 }
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := NewMiddleware(&fakeTokenService{validate: func(tok string) (*domain.AuthClaims, error) {
+		if tok != "good" {
+			return nil, domain.ErrInvalidToken
+		}
+		return &domain.AuthClaims{UserID: "u1", Role: string(domain.RoleUser)}, nil
+	}})
+	r := gin.New()
+	r.Use(m.OptionalAuthmiddleware())
+	r.GET("/x", func(c *gin.Context) { c.String(200, c.GetString("user_id")) })
+
+	for header, want := range map[string]string{"": "", "Bearer good": "u1", "Bearer expired": "", "Token good": ""} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/x", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || w.Body.String() != want {
+			t.Fatalf("%q: want 200 %q got %d %q", header, want, w.Code, w.Body.String())
+		}
+	}
+}
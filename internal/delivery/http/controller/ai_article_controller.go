@@ -107,4 +107,102 @@ func (h *Handler) GenerateContent(ctx *gin.Context) {
 	articleDTO.ToDTO(edited)
 
 	ctx.JSON(http.StatusOK, GenerateContentResponse{Article: articleDTO})
-}
\ No newline at end of file
+}
+
+//========================== Suggest Tags ========================================
+type TagMatchResponse struct {
+	Suggested string      `json:"suggested"`
+	Tag       TagResponse `json:"tag"`
+	MatchType string      `json:"match_type"`
+}
+
+type TagResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type SuggestTagsResponse struct {
+	// Approved tags can be attached right away
+	Approved []TagMatchResponse `json:"approved"`
+	// Proposed tags do not exist yet and need CreateTag plus admin approval
+	Proposed []string `json:"proposed"`
+}
+
+func (h *Handler) SuggestTags(ctx *gin.Context) {
+	userIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	userID := userIDVal.(string)
+
+	articleID := ctx.Param("id")
+	if articleID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrArticleInvalidID.Error()})
+		return
+	}
+
+	suggestions, err := h.Usecase.SuggestTagsForArticle(ctx.Request.Context(), articleID, userID)
+	if err != nil {
+		switch err {
+		case domain.ErrArticleInvalidID:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case domain.ErrArticleNotFound:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case domain.ErrUnauthorized:
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	resp := SuggestTagsResponse{Approved: []TagMatchResponse{}, Proposed: suggestions.Proposed}
+	for _, m := range suggestions.Approved {
+		resp.Approved = append(resp.Approved, TagMatchResponse{
+			Suggested: m.Suggested,
+			Tag:       TagResponse{ID: m.Tag.ID, Name: m.Tag.Name},
+			MatchType: string(m.MatchType),
+		})
+	}
+	if resp.Proposed == nil {
+		resp.Proposed = []string{}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+//========================== Generate SEO ========================================
+func (h *Handler) GenerateSEO(ctx *gin.Context) {
+	userIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	userID := userIDVal.(string)
+
+	articleID := ctx.Param("id")
+	if articleID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrArticleInvalidID.Error()})
+		return
+	}
+
+	seo, err := h.Usecase.GenerateSEOForArticle(ctx.Request.Context(), articleID, userID)
+	if err != nil {
+		switch err {
+		case domain.ErrArticleInvalidID, domain.ErrArticleContentEmpty:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case domain.ErrContentPolicyViolation:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "content violates policy"})
+		case domain.ErrArticleNotFound:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case domain.ErrUnauthorized:
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": toArticleSEODTO(*seo)})
+}
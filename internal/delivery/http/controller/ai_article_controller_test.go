@@ -0,0 +1,110 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestTags_Unauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := controller.NewArticleHandler(&mocks.ArticleUsecaseMock{})
+	r := gin.New()
+	r.POST("/articles/:id/ai/suggest-tags", h.SuggestTags)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/articles/a1/ai/suggest-tags", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSuggestTags_OK(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &mocks.ArticleUsecaseMock{SuggestTagsForArticleFn: func(ctx context.Context, articleID, userID string) (*domain.TagSuggestions, error) {
+		return &domain.TagSuggestions{
+			Approved: []domain.TagMatch{{Suggested: "Go", Tag: domain.Tag{ID: "t1", Name: "go"}, MatchType: domain.TagMatchCaseInsensitive}},
+			Proposed: []string{"channels"},
+		}, nil
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.POST("/articles/:id/ai/suggest-tags", func(c *gin.Context) { c.Set("user_id", "u1"); h.SuggestTags(c) })
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/articles/a1/ai/suggest-tags", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Data controller.SuggestTagsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "t1", body.Data.Approved[0].Tag.ID)
+	require.Equal(t, "case_insensitive", body.Data.Approved[0].MatchType)
+	require.Equal(t, []string{"channels"}, body.Data.Proposed)
+}
+
+func TestSuggestTags_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &mocks.ArticleUsecaseMock{SuggestTagsForArticleFn: func(ctx context.Context, articleID, userID string) (*domain.TagSuggestions, error) {
+		return nil, domain.ErrArticleNotFound
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.POST("/articles/:id/ai/suggest-tags", func(c *gin.Context) { c.Set("user_id", "u1"); h.SuggestTags(c) })
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/articles/a1/ai/suggest-tags", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGenerateSEO_OK(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &mocks.ArticleUsecaseMock{GenerateSEOForArticleFn: func(ctx context.Context, articleID, userID string) (*domain.ArticleSEO, error) {
+		return &domain.ArticleSEO{MetaTitle: "Go generics", MetaDescription: "A tour", Keywords: []string{"go"}}, nil
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.POST("/articles/:id/ai/seo", func(c *gin.Context) { c.Set("user_id", "u1"); h.GenerateSEO(c) })
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/articles/a1/ai/seo", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Data controller.ArticleSEODTO `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "Go generics", body.Data.MetaTitle)
+	require.Equal(t, []string{"go"}, body.Data.Keywords)
+}
+
+func TestGenerateSEO_PolicyViolation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &mocks.ArticleUsecaseMock{GenerateSEOForArticleFn: func(ctx context.Context, articleID, userID string) (*domain.ArticleSEO, error) {
+		return nil, domain.ErrContentPolicyViolation
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.POST("/articles/:id/ai/seo", func(c *gin.Context) { c.Set("user_id", "u1"); h.GenerateSEO(c) })
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/articles/a1/ai/seo", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestArticleResponse_IncludesSocialCard(t *testing.T) {
+	var resp controller.ArticleResponse
+	resp.ToDTO(&domain.Article{ID: "a1", Title: "Go generics", Excerpt: "A tour", SEO: domain.ArticleSEO{OGImage: "https://img/1.png"}})
+	require.Equal(t, "Go generics", resp.SocialCard.OGTitle)
+	require.Equal(t, "https://img/1.png", resp.SocialCard.OGImage)
+	require.Equal(t, "summary_large_image", resp.SocialCard.TwitterCard)
+	require.Equal(t, []string{}, resp.SEO.Keywords)
+}
@@ -56,4 +56,20 @@ func (c *AIController) GenerateContent(ctx *gin.Context) {
    ctx.JSON(http.StatusOK, dto.GenerateContentResponseDTO{
        Content: resp.Content,
    })
-}
\ No newline at end of file
+}
+
+// GET /admin/ai/cache/stats
+func (c *AIController) CacheStats(ctx *gin.Context) {
+   stats, err := c.Usecase.CacheStats(ctx.Request.Context())
+   if err != nil {
+      ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+      return
+   }
+
+   ctx.JSON(http.StatusOK, gin.H{"data": dto.AICacheStatsResponseDTO{
+      Backend: stats.Backend,
+      Entries: stats.Entries,
+      Hits:    stats.Hits,
+      Misses:  stats.Misses,
+   }})
+}
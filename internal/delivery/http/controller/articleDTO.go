@@ -2,6 +2,7 @@ package controller
 
 import (
 	"time"
+	dtodlv "write_base/internal/delivery/http/controller/dto"
 	"write_base/internal/domain"
 )
 
@@ -12,6 +13,7 @@ type ArticleRequest struct {
 	Excerpt       string            `json:"excerpt" validate:"min=1,max=250"`
 	Language      string            `json:"language" validate:"required,len=2"`
 	Tags          []string          `json:"tags" validate:"min=1,max=5"`
+	SEO           *ArticleSEODTO    `json:"seo,omitempty"`
 }
 
 type ArticleUpdateRequest struct {
@@ -22,6 +24,7 @@ type ArticleUpdateRequest struct {
 	Excerpt       string            `json:"excerpt" validate:"min=1,max=250"`
 	Language      string            `json:"language" validate:"required,len=2"`
 	Tags          []string          `json:"tags" validate:"min=1,max=5"`
+	SEO           *ArticleSEODTO    `json:"seo,omitempty"`
 }
 
 type ArticleResponse struct {
@@ -33,6 +36,8 @@ type ArticleResponse struct {
 	Excerpt       string            `json:"excerpt"`
 	Language      string            `json:"language"`
 	Tags          []string          `json:"tags,omitempty"`
+	SEO           ArticleSEODTO     `json:"seo"`
+	SocialCard    SocialCardDTO     `json:"social_card"`
 	Status        string            `json:"status"`
 	Stats         ArticleStatsDTO   `json:"stats"`
 	Timestamps    ArticleTimesDTO   `json:"timestamps"`
@@ -47,9 +52,68 @@ type ArticleListResponse struct {
 	Status   string `json:"status"`
 }
 
+type SearchSnippetResponse struct {
+	Field string `json:"field"`
+	// Text is HTML-escaped with matches wrapped in <mark> tags
+	Text string `json:"text"`
+}
+
+type SearchResultResponse struct {
+	ArticleListResponse
+	Score    float64                 `json:"score"`
+	Snippets []SearchSnippetResponse `json:"snippets"`
+}
+
+type RelatedArticleResponse struct {
+	ArticleListResponse
+	Score      float64  `json:"score"`
+	SharedTags []string `json:"shared_tags"`
+	CoReaders  int      `json:"co_readers"`
+	Reasons    []string `json:"reasons"`
+}
+
+type FeedItemResponse struct {
+	ArticleListResponse
+	Reasons []string `json:"reasons"`
+}
+
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type ArticleFacetsResponse struct {
+	Tags          []FacetCountResponse `json:"tags"`
+	Authors       []FacetCountResponse `json:"authors"`
+	Languages     []FacetCountResponse `json:"languages"`
+	PublishMonths []FacetCountResponse `json:"publish_months"`
+	ReadingTimes  []FacetCountResponse `json:"reading_times"`
+}
+
 type ArticleStatsDTO struct {
-	ViewsCount int `json:"view_count"`
-	ClapCount  int `json:"clap_count"`
+	ViewsCount      int `json:"view_count"`
+	UniqueViewCount int `json:"unique_view_count"`
+	ClapCount       int `json:"clap_count"`
+	CommentCount    int `json:"comment_count"`
+	ReactionCount   int `json:"reaction_count"`
+}
+
+type ArticleSEODTO struct {
+	MetaTitle       string   `json:"meta_title"`
+	MetaDescription string   `json:"meta_description"`
+	Keywords        []string `json:"keywords"`
+	CanonicalURL    string   `json:"canonical_url"`
+	OGImage         string   `json:"og_image"`
+}
+
+// SocialCardDTO is ready to be rendered as og:* and twitter:* meta tags.
+type SocialCardDTO struct {
+	OGType        string `json:"og_type"`
+	OGTitle       string `json:"og_title"`
+	OGDescription string `json:"og_description"`
+	OGURL         string `json:"og_url,omitempty"`
+	OGImage       string `json:"og_image,omitempty"`
+	TwitterCard   string `json:"twitter_card"`
 }
 
 type ArticleTimesDTO struct {
@@ -81,6 +145,8 @@ type HeadingContentDTO struct {
 type ParagraphContentDTO struct {
 	Text  string `json:"text"`
 	Style string `json:"style"` //e.g., "normal", "bold", "italic"
+	// Mentions are resolved by the server; values sent by clients are ignored
+	Mentions []dtodlv.MentionResponse `json:"mentions,omitempty"`
 }
 type ImageContentDTO struct {
 	URL     string `json:"url"`
@@ -103,10 +169,28 @@ type DividerContentDTO struct {
 }
 
 type PaginationRequest struct {
-	Page     int `form:"page" binding:"min=1"`
-	PageSize int `form:"page_size" binding:"min=1,max=100"`
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
     SortField string `form:"sort_field"`
     SortOrder string `form:"sort_order"`
+    Cursor    string `form:"cursor"`
+}
+
+// ToDomain converts the query params. defaultSort is the listing's natural
+// order; it is made explicit so next_cursor can be derived from the page.
+func (p PaginationRequest) ToDomain(defaultSort string) domain.Pagination {
+	pag := domain.Pagination{
+		Page:      p.Page,
+		PageSize:  p.PageSize,
+		SortField: p.SortField,
+		SortOrder: p.SortOrder,
+		Cursor:    p.Cursor,
+	}
+	if pag.SortField == "" && defaultSort != "" {
+		pag.SortField, pag.SortOrder = defaultSort, "desc"
+	}
+	pag.ValidatePagination()
+	return pag
 }
 
 
@@ -124,6 +208,8 @@ func (aur *ArticleUpdateRequest) ToDomain() *domain.Article {
 		Excerpt:       aur.Excerpt,
 		Language:      aur.Language,
 		Tags:          aur.Tags,
+		SEO:           aur.SEO.ToDomain(),
+		KeepSEO:       aur.SEO == nil,
 		ContentBlocks: mapContentBlocks(aur.ContentBlocks),
 	}
 }
@@ -135,10 +221,49 @@ func (ar *ArticleRequest) ToDomain() *domain.Article {
 		Excerpt:       ar.Excerpt,
 		Language:      ar.Language,
 		Tags:          ar.Tags,
+		SEO:           ar.SEO.ToDomain(),
 		ContentBlocks: mapContentBlocks(ar.ContentBlocks),
 	}
 }
 
+func (s *ArticleSEODTO) ToDomain() domain.ArticleSEO {
+	if s == nil {
+		return domain.ArticleSEO{}
+	}
+	return domain.ArticleSEO{
+		MetaTitle:       s.MetaTitle,
+		MetaDescription: s.MetaDescription,
+		Keywords:        s.Keywords,
+		CanonicalURL:    s.CanonicalURL,
+		OGImage:         s.OGImage,
+	}
+}
+
+func toArticleSEODTO(seo domain.ArticleSEO) ArticleSEODTO {
+	keywords := seo.Keywords
+	if keywords == nil {
+		keywords = []string{}
+	}
+	return ArticleSEODTO{
+		MetaTitle:       seo.MetaTitle,
+		MetaDescription: seo.MetaDescription,
+		Keywords:        keywords,
+		CanonicalURL:    seo.CanonicalURL,
+		OGImage:         seo.OGImage,
+	}
+}
+
+func toSocialCardDTO(card domain.SocialCard) SocialCardDTO {
+	return SocialCardDTO{
+		OGType:        card.Type,
+		OGTitle:       card.Title,
+		OGDescription: card.Description,
+		OGURL:         card.URL,
+		OGImage:       card.Image,
+		TwitterCard:   card.TwitterCard,
+	}
+}
+
 func (ar *ArticleResponse) ToDTO(article *domain.Article) {
 	ar.ID = article.ID
 	ar.Title = article.Title
@@ -148,8 +273,16 @@ func (ar *ArticleResponse) ToDTO(article *domain.Article) {
 	ar.Excerpt = article.Excerpt
 	ar.Language = article.Language
 	ar.Tags = article.Tags
+	ar.SEO = toArticleSEODTO(article.SEO)
+	ar.SocialCard = toSocialCardDTO(domain.BuildSocialCard(article))
 	ar.Status = string(article.Status)
-	ar.Stats = ArticleStatsDTO{ViewsCount: article.Stats.ViewCount, ClapCount: article.Stats.ClapCount}
+	ar.Stats = ArticleStatsDTO{
+		ViewsCount:      article.Stats.ViewCount,
+		UniqueViewCount: article.Stats.UniqueViewCount,
+		ClapCount:       article.Stats.ClapCount,
+		CommentCount:    article.Stats.CommentCount,
+		ReactionCount:   article.Stats.ReactionCount,
+	}
 	ar.Timestamps = ArticleTimesDTO{
 		CreatedAt:   article.Timestamps.CreatedAt,
 		UpdatedAt:   article.Timestamps.UpdatedAt,
@@ -167,6 +300,43 @@ func (alr *ArticleListResponse) ToListDTO(article domain.Article) {
 	alr.Status = string(article.Status)
 }
 
+func (sr *SearchResultResponse) FromDomain(hit domain.SearchHit) {
+	sr.ToListDTO(hit.Article)
+	sr.Score = hit.Score
+	sr.Snippets = make([]SearchSnippetResponse, 0, len(hit.Snippets))
+	for _, s := range hit.Snippets {
+		sr.Snippets = append(sr.Snippets, SearchSnippetResponse{Field: s.Field, Text: s.Text})
+	}
+}
+
+func (rr *RelatedArticleResponse) FromDomain(related domain.RelatedArticle) {
+	rr.ToListDTO(related.Article)
+	rr.Score = related.Score
+	rr.SharedTags = append([]string{}, related.SharedTags...)
+	rr.CoReaders = related.CoReaders
+	rr.Reasons = append([]string{}, related.Reasons...)
+}
+
+func (fr *FeedItemResponse) FromDomain(item domain.FeedItem) {
+	fr.ToListDTO(item.Article)
+	fr.Reasons = append([]string{}, item.Reasons...)
+}
+
+func (fr *ArticleFacetsResponse) FromDomain(facets domain.ArticleFacets) {
+	counts := func(in []domain.FacetCount) []FacetCountResponse {
+		out := make([]FacetCountResponse, 0, len(in))
+		for _, c := range in {
+			out = append(out, FacetCountResponse{Value: c.Value, Count: c.Count})
+		}
+		return out
+	}
+	fr.Tags = counts(facets.Tags)
+	fr.Authors = counts(facets.Authors)
+	fr.Languages = counts(facets.Languages)
+	fr.PublishMonths = counts(facets.PublishMonths)
+	fr.ReadingTimes = counts(facets.ReadingTimes)
+}
+
 func mapContentBlocks(dtos []ContentBlockDTO) []domain.ContentBlock {
 	var blocks []domain.ContentBlock
 
@@ -297,8 +467,9 @@ func toParagraphContentDTO(content *domain.ParagraphContent) *ParagraphContentDT
 		return nil
 	}
 	return &ParagraphContentDTO{
-		Text:  content.Text,
-		Style: content.Style,
+		Text:     content.Text,
+		Style:    content.Style,
+		Mentions: dtodlv.FromDomainMentions(content.Mentions),
 	}
 }
 
@@ -38,7 +38,7 @@ func (h *Handler) CreateArticle(ctx *gin.Context) {
         switch err {
 		case domain.ErrInvalidTagName:
 			code = http.StatusBadRequest
-        case domain.ErrInvalidArticlePayload:
+        case domain.ErrInvalidArticlePayload, domain.ErrInvalidSEOMetadata:
             code = http.StatusBadRequest
         case domain.ErrUnauthorized:
             code = http.StatusUnauthorized
@@ -81,7 +81,7 @@ func (h *Handler) UpdateArticle(ctx *gin.Context) {
         switch err {
 		case domain.ErrInvalidTagName:
 			code = http.StatusBadRequest
-        case domain.ErrInvalidArticlePayload:
+        case domain.ErrInvalidArticlePayload, domain.ErrInvalidSEOMetadata:
             code = http.StatusBadRequest
         case domain.ErrUnauthorized:
             code = http.StatusUnauthorized
@@ -174,6 +174,8 @@ func (h *Handler) PublishArticle(ctx *gin.Context) {
 			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		case domain.ErrArticlePublished:
 			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case domain.ErrContentBlocked:
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		default:
 			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -394,9 +396,12 @@ func (h *Handler) GetArticleBySlug(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrArticleInvalidSlug})
 		return
 	}
-	//clientIP := ctx.ClientIP()
-	clientIP := "192.1.1.1"
-    article,err := h.Usecase.GetArticleBySlug(ctx,slug,clientIP)
+	// Signed-in readers are counted by user, anonymous ones by IP and user agent
+	reader := domain.ViewReader{ClientIP: ctx.ClientIP(), UserAgent: ctx.Request.UserAgent()}
+	if userID, ok := ctx.Get("user_id"); ok {
+		reader.UserID, _ = userID.(string)
+	}
+    article,err := h.Usecase.GetArticleBySlug(ctx,slug,reader)
     if err!=nil {
         code := http.StatusInternalServerError
         switch err {
@@ -417,6 +422,30 @@ func (h *Handler) GetArticleBySlug(ctx *gin.Context) {
 //===========================================================================//
 //                           Article Lists                                   //
 //===========================================================================//
+// paginatedResponse builds a listing response. Page mode keeps the totals;
+// cursor mode skips counting, so clients follow next_cursor until it is empty.
+func paginatedResponse(data interface{}, total int, pag domain.Pagination, articles []domain.Article) gin.H {
+	resp := gin.H{
+		"data":        data,
+		"page_size":   pag.PageSize,
+		"next_cursor": pag.NextCursor(articles),
+	}
+	if pag.Cursor == "" {
+		resp["total"] = total
+		resp["page"] = pag.Page
+		resp["total_pages"] = (total + pag.PageSize - 1) / pag.PageSize
+	}
+	return resp
+}
+
+// facetsResponse renders facet counts. They are best-effort: a failed count
+// leaves the facets out rather than failing results that were found.
+func facetsResponse(facets *domain.ArticleFacets) ArticleFacetsResponse {
+	var fr ArticleFacetsResponse
+	fr.FromDomain(*facets)
+	return fr
+}
+
 //======================= List user articles ==================================
 func (h *Handler) ListArticlesByAuthor(ctx *gin.Context) {
 	userIDVal, exists := ctx.Get("user_id")
@@ -436,17 +465,15 @@ func (h *Handler) ListArticlesByAuthor(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrArticleInvalidID})
 		return
 	}
-	pagination := domain.Pagination{
-		Page:     pagReq.Page,
-		PageSize: pagReq.PageSize,
-	}
-	
-	// Validate pagination parameters
-	pagination.ValidatePagination()
+	pagination := pagReq.ToDomain(domain.SortByCreatedAt)
 	
 	// List articles for the current user
 	articles, total, err := h.Usecase.ListArticlesByAuthor(ctx, userID, articleID, pagination)
 	if err != nil {
+		if err == domain.ErrInvalidCursor {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if err == domain.ErrArticleNotFound {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -454,14 +481,7 @@ func (h *Handler) ListArticlesByAuthor(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	response := gin.H{
-		"data":       articles,
-		"total":      total,
-		"page":       pagination.Page,
-		"page_size":  pagination.PageSize,
-		"total_pages": (total + pagination.PageSize - 1) / pagination.PageSize,
-	}
-	ctx.JSON(http.StatusOK, response)
+	ctx.JSON(http.StatusOK, paginatedResponse(articles, total, pagination, articles))
 }
 //======================= List Trending articles ==================================
 // Trending articles (last 7 days)
@@ -479,16 +499,14 @@ func (h *Handler) GetTrendingArticles(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	pagination := domain.Pagination{
-		Page:     pagReq.Page,
-		PageSize: pagReq.PageSize,
-	}
-	
-	// Validate pagination parameters
-	pagination.ValidatePagination()
+	pagination := pagReq.ToDomain(domain.SortByTrending)
 
 	articles, total, err := h.Usecase.GetTrendingArticles(ctx, userID, pagination)
 	if err != nil {
+		if err == domain.ErrInvalidCursor {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if err == domain.ErrArticleNotFound {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -497,14 +515,7 @@ func (h *Handler) GetTrendingArticles(ctx *gin.Context) {
 		return
 	}
 
-	response := gin.H{
-		"data":       articles,
-		"total":      total,
-		"page":       pagination.Page,
-		"page_size":  pagination.PageSize,
-		"total_pages": (total + pagination.PageSize - 1) / pagination.PageSize,
-	}
-	ctx.JSON(http.StatusOK, response)
+	ctx.JSON(http.StatusOK, paginatedResponse(articles, total, pagination, articles))
 }
 
 //======================= List New articles ==================================
@@ -522,16 +533,14 @@ func (h *Handler) GetNewArticles(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	pagination := domain.Pagination{
-		Page:     pagReq.Page,
-		PageSize: pagReq.PageSize,
-	}
-	
-	// Validate pagination parameters
-	pagination.ValidatePagination()
+	pagination := pagReq.ToDomain(domain.SortByPublishedAt)
 
 	articles, total, err := h.Usecase.GetNewArticles(ctx, userID, pagination)
 	if err != nil {
+		if err == domain.ErrInvalidCursor {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if err == domain.ErrArticleNotFound {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -540,14 +549,7 @@ func (h *Handler) GetNewArticles(ctx *gin.Context) {
 		return
 	}
 
-	response := gin.H{
-		"data":       articles,
-		"total":      total,
-		"page":       pagination.Page,
-		"page_size":  pagination.PageSize,
-		"total_pages": (total + pagination.PageSize - 1) / pagination.PageSize,
-	}
-	ctx.JSON(http.StatusOK, response)
+	ctx.JSON(http.StatusOK, paginatedResponse(articles, total, pagination, articles))
 }
 
 //======================= List Popular articles ==================================
@@ -565,16 +567,14 @@ func (h *Handler) GetPopularArticles(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	pagination := domain.Pagination{
-		Page:     pagReq.Page,
-		PageSize: pagReq.PageSize,
-	}
-	
-	// Validate pagination parameters
-	pagination.ValidatePagination()
+	pagination := pagReq.ToDomain(domain.SortByViewCount)
 
 	articles, total, err := h.Usecase.GetPopularArticles(ctx, userID, pagination)
 	if err != nil {
+		if err == domain.ErrInvalidCursor {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if err == domain.ErrArticleNotFound {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
@@ -583,14 +583,7 @@ func (h *Handler) GetPopularArticles(ctx *gin.Context) {
 		return
 	}
 
-	response := gin.H{
-		"data":       articles,
-		"total":      total,
-		"page":       pagination.Page,
-		"page_size":  pagination.PageSize,
-		"total_pages": (total + pagination.PageSize - 1) / pagination.PageSize,
-	}
-	ctx.JSON(http.StatusOK, response)
+	ctx.JSON(http.StatusOK, paginatedResponse(articles, total, pagination, articles))
 }
 //======================= List Author articles ==================================
 func (h *Handler) FilterAuthorArticles(c *gin.Context) {
@@ -679,13 +672,16 @@ func (h *Handler) FilterArticles(ctx *gin.Context) {
     if req.Pagination.PageSize <= 0 {
         req.Pagination.PageSize = 20
     }
+    if req.Pagination.SortField == "" {
+        req.Pagination.SortField, req.Pagination.SortOrder = domain.SortByCreatedAt, "desc"
+    }
 
     articles, total, err := h.Usecase.FilterArticles(ctx.Request.Context(), req.Filter, req.Pagination)
     if err != nil {
         switch err {
         case domain.ErrArticleNotFound:
             ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-        case domain.ErrInvalidArticlePayload:
+        case domain.ErrInvalidArticlePayload, domain.ErrInvalidCursor:
             ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
         default:
             ctx.JSON(http.StatusInternalServerError, gin.H{"error": domain.ErrInternalServer.Error()})
@@ -700,12 +696,14 @@ func (h *Handler) FilterArticles(ctx *gin.Context) {
         resp = append(resp, dto)
     }
 
-    ctx.JSON(http.StatusOK, gin.H{
-        "data":      resp,
-        "total":     total,
-        "page":      req.Pagination.Page,
-        "page_size": req.Pagination.PageSize,
-    })
+    body := paginatedResponse(resp, total, req.Pagination, articles)
+    // Facets describe the whole result set, so later cursor pages leave them out
+    if req.Pagination.Cursor == "" {
+        if facets, err := h.Usecase.GetFilterFacets(ctx.Request.Context(), req.Filter); err == nil {
+            body["facets"] = facetsResponse(facets)
+        }
+    }
+    ctx.JSON(http.StatusOK, body)
 }
 //=========================== Search ==============================================
 func (h *Handler) SearchArticles(ctx *gin.Context) {
@@ -729,11 +727,19 @@ func (h *Handler) SearchArticles(ctx *gin.Context) {
         return
     }
 
-    pagination := domain.Pagination{Page: pagReq.Page, PageSize: pagReq.PageSize}
-    pagination.ValidatePagination()
+    pagination := pagReq.ToDomain("")
+    search := domain.SearchQuery{
+        Text:     query,
+        AuthorID: strings.TrimSpace(ctx.Query("author_id")),
+        Tags:     ctx.QueryArray("tag"),
+    }
 
-    articles, total, err := h.Usecase.SearchArticles(ctx.Request.Context(), userID, query, pagination)
+    hits, total, err := h.Usecase.SearchArticles(ctx.Request.Context(), userID, search, pagination)
     if err != nil {
+        if err == domain.ErrInvalidCursor || err == domain.ErrInvalidSearchQuery || err == domain.ErrUnsupportedSearchSort {
+            ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
         if err == domain.ErrArticleNotFound {
             ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
             return
@@ -746,13 +752,21 @@ func (h *Handler) SearchArticles(ctx *gin.Context) {
         return
     }
 
-    ctx.JSON(http.StatusOK, gin.H{
-        "data":        articles,
-        "total":       total,
-        "page":        pagination.Page,
-        "page_size":   pagination.PageSize,
-        "total_pages": (total + pagination.PageSize - 1) / pagination.PageSize,
-    })
+    resp := make([]SearchResultResponse, 0, len(hits))
+    articles := make([]domain.Article, 0, len(hits))
+    for _, hit := range hits {
+        var dto SearchResultResponse
+        dto.FromDomain(hit)
+        resp = append(resp, dto)
+        articles = append(articles, hit.Article)
+    }
+    body := paginatedResponse(resp, total, pagination, articles)
+    if pagination.Cursor == "" {
+        if facets, err := h.Usecase.GetSearchFacets(ctx.Request.Context(), userID, search); err == nil {
+            body["facets"] = facetsResponse(facets)
+        }
+    }
+    ctx.JSON(http.StatusOK, body)
 }
 //======================== List By Tags =======================================
 func (h *Handler) ListArticlesByTags(c *gin.Context) {
@@ -777,11 +791,7 @@ func (h *Handler) ListArticlesByTags(c *gin.Context) {
 		return
 	}
 	
-	pagination := domain.Pagination{
-		Page:     pagReq.Page,
-		PageSize: pagReq.PageSize,
-	}
-	pagination.ValidatePagination()
+	pagination := pagReq.ToDomain(domain.SortByCreatedAt)
 	
 	articles, total, err := h.Usecase.ListArticlesByTags(c.Request.Context(), userID, tags, pagination)
 	if err != nil {
@@ -793,6 +803,8 @@ func (h *Handler) ListArticlesByTags(c *gin.Context) {
 			code = http.StatusUnauthorized
 		case domain.ErrUnapprovedTags:
 			code = http.StatusBadRequest
+		case domain.ErrInvalidCursor:
+			code = http.StatusBadRequest
 		case domain.ErrArticleNotFound:
 			code = http.StatusNotFound
 		}
@@ -800,14 +812,7 @@ func (h *Handler) ListArticlesByTags(c *gin.Context) {
 		return
 	}
 	
-	response := gin.H{
-		"data":        articles,
-		"total":       total,
-		"page":        pagination.Page,
-		"page_size":   pagination.PageSize,
-		"total_pages": (total + pagination.PageSize - 1) / pagination.PageSize,
-	}
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, paginatedResponse(articles, total, pagination, articles))
 }
 // ===========================================================================//
 //                  Trash Management (Author only)                            //
@@ -990,6 +995,33 @@ func (h *Handler) AdminUnpublishArticle(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, article)
 }
 
+func (h *Handler) AdminRebuildSearchIndex(ctx *gin.Context) {
+	userIDVal, exists := ctx.Get("user_id")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		return
+	}
+	userID := userIDVal.(string)
+	roleVal, exists := ctx.Get("role")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "role not found in context"})
+		return
+	}
+	userRole := roleVal.(string)
+
+	indexed, err := h.Usecase.AdminRebuildSearchIndex(ctx, userID, userRole)
+	if err != nil {
+		switch err {
+		case domain.ErrUnauthorized:
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "indexed": indexed})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"indexed": indexed})
+}
+
 //=================================== CLAPPING ===================================
 
 // Add new handler method
@@ -1008,18 +1040,52 @@ func (h *Handler) AddClap(c *gin.Context) {
 	
 	stats, err := h.Usecase.AddClap(c.Request.Context(), userID, articleID)
 	if err != nil {
-		code := http.StatusInternalServerError
-		switch err {
-		case domain.ErrUnauthorized:
-			code = http.StatusUnauthorized
-		case domain.ErrClapLimitExceeded:
-			code = http.StatusTooManyRequests
-		case domain.ErrArticleNotFound:
-			code = http.StatusNotFound
-		}
-		c.JSON(code, gin.H{"error": err.Error()})
+		c.JSON(clapStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	
 	c.JSON(http.StatusOK, stats)
 }
+
+// clapStatus maps clap errors to HTTP codes.
+func clapStatus(err error) int {
+	switch err {
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrClapLimitExceeded:
+		return http.StatusTooManyRequests
+	case domain.ErrArticleNotFound:
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// RemoveClaps retracts all of the caller's claps on the article
+func (h *Handler) RemoveClaps(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	stats, err := h.Usecase.RemoveClaps(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		c.JSON(clapStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetMyClaps returns how many times the caller clapped the article and how many claps they have left
+func (h *Handler) GetMyClaps(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	state, err := h.Usecase.GetUserClaps(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		c.JSON(clapStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": state.Count, "max": state.Max, "remaining": state.Remaining()})
+}
@@ -0,0 +1,56 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveClaps_Success(t *testing.T) {
+	uc := &mocks.ArticleUsecaseMock{RemoveClapsFn: func(ctx context.Context, uid, aid string) (domain.ArticleStats, error) {
+		require.Equal(t, "u1", uid)
+		require.Equal(t, "a1", aid)
+		return domain.ArticleStats{ClapCount: 2}, nil
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := setupRouterWithAuth(h)
+	r.DELETE("/articles/:id/clap", h.RemoveClaps)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/articles/a1/clap", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRemoveClaps_ArticleNotFound(t *testing.T) {
+	uc := &mocks.ArticleUsecaseMock{RemoveClapsFn: func(ctx context.Context, uid, aid string) (domain.ArticleStats, error) {
+		return domain.ArticleStats{}, domain.ErrArticleNotFound
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := setupRouterWithAuth(h)
+	r.DELETE("/articles/:id/clap", h.RemoveClaps)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/articles/a1/clap", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetMyClaps(t *testing.T) {
+	uc := &mocks.ArticleUsecaseMock{GetUserClapsFn: func(ctx context.Context, uid, aid string) (domain.UserClapState, error) {
+		return domain.UserClapState{Count: 7, Max: 10}, nil
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := setupRouterWithAuth(h)
+	r.GET("/articles/:id/clap/me", h.GetMyClaps)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/articles/a1/clap/me", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"count":7,"max":10,"remaining":3}`, w.Body.String())
+}
@@ -0,0 +1,77 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newListRouter(uc *mocks.ArticleUsecaseMock) *gin.Engine {
+	h := controller.NewArticleHandler(uc)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("user_id", "u1"); c.Next() })
+	r.GET("/articles/new", h.GetNewArticles)
+	return r
+}
+
+func TestGetNewArticles_PageModeIncludesNextCursor(t *testing.T) {
+	var got domain.Pagination
+	uc := &mocks.ArticleUsecaseMock{GetNewArticlesFn: func(ctx context.Context, userID string, pag domain.Pagination) ([]domain.Article, int, error) {
+		got = pag
+		return []domain.Article{{ID: "a1", Stats: domain.ArticleStats{ViewCount: 3}}}, 5, nil
+	}}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/articles/new?page_size=1", nil)
+	newListRouter(uc).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, domain.SortByPublishedAt, got.SortField)
+	require.Equal(t, 1, got.Page)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, float64(5), body["total"])
+	require.Equal(t, float64(5), body["total_pages"])
+	// published_at is unset on the mocked article, so there is no cursor to continue from
+	require.Equal(t, "", body["next_cursor"])
+}
+
+func TestGetNewArticles_CursorMode(t *testing.T) {
+	cursor := domain.ArticleCursor{SortField: domain.SortByViewCount, SortOrder: "desc", Value: "10", ID: "a0"}.Encode()
+	var got domain.Pagination
+	uc := &mocks.ArticleUsecaseMock{GetNewArticlesFn: func(ctx context.Context, userID string, pag domain.Pagination) ([]domain.Article, int, error) {
+		got = pag
+		return []domain.Article{{ID: "a1", Stats: domain.ArticleStats{ViewCount: 7}}}, 0, nil
+	}}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/articles/new?page_size=1&cursor="+cursor, nil)
+	newListRouter(uc).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, cursor, got.Cursor)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.NotContains(t, body, "total")
+	next, err := domain.DecodeArticleCursor(body["next_cursor"].(string))
+	require.NoError(t, err)
+	require.Equal(t, domain.ArticleCursor{SortField: domain.SortByViewCount, SortOrder: "desc", Value: "7", ID: "a1"}, *next)
+}
+
+func TestGetNewArticles_InvalidCursor(t *testing.T) {
+	uc := &mocks.ArticleUsecaseMock{GetNewArticlesFn: func(ctx context.Context, userID string, pag domain.Pagination) ([]domain.Article, int, error) {
+		return nil, 0, domain.ErrInvalidCursor
+	}}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/articles/new?cursor=bogus", nil)
+	newListRouter(uc).ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
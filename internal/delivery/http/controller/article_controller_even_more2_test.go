@@ -59,8 +59,8 @@ func TestGetTrending_New_Popular_Success(t *testing.T) {
 
 func TestSearchArticles_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	uc := &mocks.ArticleUsecaseMock{SearchArticlesFn: func(ctx context.Context, uid, q string, p domain.Pagination) ([]domain.Article, int, error) {
-		return []domain.Article{{ID: "a1"}}, 1, nil
+	uc := &mocks.ArticleUsecaseMock{SearchArticlesFn: func(ctx context.Context, uid string, q domain.SearchQuery, p domain.Pagination) ([]domain.SearchHit, int, error) {
+		return []domain.SearchHit{{Article: domain.Article{ID: "a1"}}}, 1, nil
 	}}
 	h := controller.NewArticleHandler(uc)
 	r := gin.New()
@@ -107,7 +107,9 @@ func TestEmptyTrash_And_DeleteFromTrash_Success(t *testing.T) {
 
 func TestGetArticleBySlug_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	uc := &mocks.ArticleUsecaseMock{GetArticleBySlugFn: func(ctx context.Context, slug, ip string) (*domain.Article, error) {
+	uc := &mocks.ArticleUsecaseMock{GetArticleBySlugFn: func(ctx context.Context, slug string, reader domain.ViewReader) (*domain.Article, error) {
+		require.Equal(t, "Mozilla/5.0", reader.UserAgent)
+		require.NotEmpty(t, reader.ClientIP)
 		return &domain.Article{ID: "a1", Slug: slug}, nil
 	}}
 	h := controller.NewArticleHandler(uc)
@@ -115,6 +117,8 @@ func TestGetArticleBySlug_Success(t *testing.T) {
 	r.GET("/:slug", h.GetArticleBySlug)
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/hello-world", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	req.RemoteAddr = "203.0.113.7:5000"
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
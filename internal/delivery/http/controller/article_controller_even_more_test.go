@@ -16,8 +16,15 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// withAuth sets what the auth middleware sets; the older admin handlers still
+// read user_role.
 func withAuth() gin.HandlerFunc {
-	return func(c *gin.Context) { c.Set("user_id", "u1"); c.Set("user_role", string(domain.RoleAdmin)); c.Next() }
+	return func(c *gin.Context) {
+		c.Set("user_id", "u1")
+		c.Set("role", string(domain.RoleAdmin))
+		c.Set("user_role", string(domain.RoleAdmin))
+		c.Next()
+	}
 }
 
 func TestUpdateArticle_Unauthorized(t *testing.T) {
@@ -36,6 +43,40 @@ func TestUpdateArticle_Unauthorized(t *testing.T) {
 	require.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
+func TestUpdateArticle_OmittedSEOIsKept(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var got *domain.Article
+	uc := &mocks.ArticleUsecaseMock{UpdateArticleFn: func(ctx context.Context, uid string, a *domain.Article) error { got = a; return nil }}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.Use(withAuth())
+	r.PUT("/articles/:id", h.UpdateArticle)
+	blocks := []map[string]any{{"type": "paragraph", "order": 0, "content": map[string]any{"paragraph": map[string]any{"text": "hi"}}}}
+
+	for _, tc := range []struct {
+		name string
+		seo  any
+		keep bool
+	}{
+		{"omitted", nil, true},
+		{"empty", map[string]any{}, false},
+		{"set", map[string]any{"meta_title": "Go"}, false},
+	} {
+		body := map[string]any{"title": "x", "content_blocks": blocks}
+		if tc.seo != nil {
+			body["seo"] = tc.seo
+		}
+		b, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/articles/a1", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, tc.name)
+		require.Equal(t, tc.keep, got.KeepSEO, tc.name)
+	}
+	require.Equal(t, "Go", got.SEO.MetaTitle)
+}
+
 func TestUpdateArticle_BadPayload(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	uc := &mocks.ArticleUsecaseMock{}
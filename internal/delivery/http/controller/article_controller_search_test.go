@@ -0,0 +1,151 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchArticles_FiltersAndSnippets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var got domain.SearchQuery
+	uc := &mocks.ArticleUsecaseMock{SearchArticlesFn: func(ctx context.Context, uid string, q domain.SearchQuery, p domain.Pagination) ([]domain.SearchHit, int, error) {
+		got = q
+		return []domain.SearchHit{{
+			Article:  domain.Article{ID: "a1", Title: "Tips"},
+			Score:    2.5,
+			Snippets: []domain.SearchSnippet{{Field: "paragraph", Text: "use <mark>go</mark>"}},
+		}}, 1, nil
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.Use(withAuth())
+	r.GET("/search", h.SearchArticles)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, `/search?q=go+-java&author_id=au1&tag=go&tag=tips`, nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, domain.SearchQuery{Text: "go -java", AuthorID: "au1", Tags: []string{"go", "tips"}}, got)
+
+	var body struct {
+		Data []controller.SearchResultResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "a1", body.Data[0].ID)
+	require.Equal(t, 2.5, body.Data[0].Score)
+	require.Equal(t, "use <mark>go</mark>", body.Data[0].Snippets[0].Text)
+}
+
+func TestSearchArticles_OnlyExclusions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &mocks.ArticleUsecaseMock{SearchArticlesFn: func(ctx context.Context, uid string, q domain.SearchQuery, p domain.Pagination) ([]domain.SearchHit, int, error) {
+		return nil, 0, domain.ErrInvalidSearchQuery
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.Use(withAuth())
+	r.GET("/search", h.SearchArticles)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/search?q=-java", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdminRebuildSearchIndex(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &mocks.ArticleUsecaseMock{AdminRebuildSearchIndexFn: func(ctx context.Context, uid, role string) (int, error) {
+		if role != string(domain.RoleAdmin) {
+			return 0, domain.ErrUnauthorized
+		}
+		return 42, nil
+	}}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.Use(withAuth())
+	r.POST("/admin/search/reindex", h.AdminRebuildSearchIndex)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/admin/search/reindex", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"indexed":42}`, w.Body.String())
+
+	uc.AdminRebuildSearchIndexFn = func(ctx context.Context, uid, role string) (int, error) { return 0, domain.ErrUnauthorized }
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSearchArticles_IncludesFacetsOnFirstPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	facetCalls := 0
+	uc := &mocks.ArticleUsecaseMock{
+		SearchArticlesFn: func(ctx context.Context, uid string, q domain.SearchQuery, p domain.Pagination) ([]domain.SearchHit, int, error) {
+			return []domain.SearchHit{{Article: domain.Article{ID: "a1"}}}, 1, nil
+		},
+		GetSearchFacetsFn: func(ctx context.Context, uid string, q domain.SearchQuery) (*domain.ArticleFacets, error) {
+			facetCalls++
+			return &domain.ArticleFacets{
+				Tags:         []domain.FacetCount{{Value: "go", Count: 1}},
+				ReadingTimes: []domain.FacetCount{{Value: "0-3", Count: 1}},
+			}, nil
+		},
+	}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.Use(withAuth())
+	r.GET("/search", h.SearchArticles)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/search?q=go", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Facets *controller.ArticleFacetsResponse `json:"facets"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.NotNil(t, body.Facets)
+	require.Equal(t, []controller.FacetCountResponse{{Value: "go", Count: 1}}, body.Facets.Tags)
+	require.Equal(t, []controller.FacetCountResponse{{Value: "0-3", Count: 1}}, body.Facets.ReadingTimes)
+	require.Empty(t, body.Facets.Authors)
+
+	cursor := domain.ArticleCursor{SortField: domain.SortByPublishedAt, SortOrder: "desc", Value: "2025-01-01T00:00:00Z", ID: "a0"}.Encode()
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/search?q=go&cursor="+cursor, nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), `"facets"`)
+	require.Equal(t, 1, facetCalls)
+}
+
+func TestFilterArticles_FacetFailureKeepsResults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &mocks.ArticleUsecaseMock{
+		FilterArticlesFn: func(ctx context.Context, f domain.ArticleFilter, p domain.Pagination) ([]domain.Article, int, error) {
+			return []domain.Article{{ID: "a1"}}, 1, nil
+		},
+		GetFilterFacetsFn: func(ctx context.Context, f domain.ArticleFilter) (*domain.ArticleFacets, error) {
+			return nil, domain.ErrInternalServer
+		},
+	}
+	h := controller.NewArticleHandler(uc)
+	r := gin.New()
+	r.Use(withAuth())
+	r.POST("/articles/filter", h.FilterArticles)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/articles/filter", strings.NewReader(`{"filter":{"language":"en"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"a1"`)
+	require.NotContains(t, w.Body.String(), `"facets"`)
+}
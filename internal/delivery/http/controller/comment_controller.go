@@ -16,6 +16,23 @@ func NewCommentController(usecase domain.ICommentUsecase) *CommentController {
 	return &CommentController{usecase: usecase}
 }
 
+// commentStatus maps comment errors to HTTP codes.
+func commentStatus(err error) int {
+	switch err {
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrCommentPermission:
+		return http.StatusForbidden
+	case domain.ErrCommentNotFound, domain.ErrArticleNotFound:
+		return http.StatusNotFound
+	case domain.ErrArticleNotPublished, domain.ErrCommentParent, domain.ErrCommentPinReply:
+		return http.StatusBadRequest
+	case domain.ErrContentBlocked:
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
+
 func (cc *CommentController) Create(c *gin.Context) {
 	   var req dtodlv.CommentRequest
 	   if err := c.ShouldBindJSON(&req); err != nil {
@@ -24,12 +41,12 @@ func (cc *CommentController) Create(c *gin.Context) {
 	   }
 	   comment := &domain.Comment{
 			   PostID:   req.PostID,
-			   UserID:   req.UserID,
+			   UserID:   c.GetString("user_id"),
 			   ParentID: req.ParentID,
 			   Content:  req.Content,
 	   }
 	   if err := cc.usecase.CreateComment(c, comment); err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			   c.JSON(commentStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
 	   c.JSON(http.StatusCreated, gin.H{"message": "Comment created"})
@@ -46,8 +63,8 @@ func (cc *CommentController) Update(c *gin.Context) {
 			   ID:      id,
 			   Content: req.Content,
 	   }
-	   if err := cc.usecase.UpdateComment(c, comment); err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	   if err := cc.usecase.UpdateComment(c, c.GetString("user_id"), comment); err != nil {
+			   c.JSON(commentStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
 	   c.JSON(http.StatusOK, gin.H{"message": "Comment updated"})
@@ -55,8 +72,8 @@ func (cc *CommentController) Update(c *gin.Context) {
 
 func (cc *CommentController) Delete(c *gin.Context) {
 	   id := c.Param("id")
-	   if err := cc.usecase.DeleteComment(c, id); err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	   if err := cc.usecase.DeleteComment(c, c.GetString("user_id"), id); err != nil {
+			   c.JSON(commentStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
 	   c.JSON(http.StatusOK, gin.H{"message": "Comment deleted"})
@@ -101,3 +118,78 @@ func (cc *CommentController) GetReplies(c *gin.Context) {
 	   }
 	   c.JSON(http.StatusOK, replies)
 }
+
+// GetTree returns a page of an article's top-level comments with nested reply previews
+func (cc *CommentController) GetTree(c *gin.Context) {
+	   cc.serveTree(c, func(q domain.CommentThreadQuery) ([]domain.CommentNode, string, error) {
+			   return cc.usecase.GetCommentTree(c.Request.Context(), c.Param("post_id"), q)
+	   })
+}
+
+// GetThread loads more replies of a collapsed branch, in the same shape as GetTree
+func (cc *CommentController) GetThread(c *gin.Context) {
+	   cc.serveTree(c, func(q domain.CommentThreadQuery) ([]domain.CommentNode, string, error) {
+			   return cc.usecase.GetReplyTree(c.Request.Context(), c.Param("id"), q)
+	   })
+}
+
+func (cc *CommentController) serveTree(c *gin.Context, load func(domain.CommentThreadQuery) ([]domain.CommentNode, string, error)) {
+	   var q dtodlv.CommentTreeQuery
+	   if err := c.ShouldBindQuery(&q); err != nil {
+			   c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			   return
+	   }
+	   nodes, next, err := load(q.ToDomain())
+	   if err != nil {
+			   code := commentStatus(err)
+			   if err == domain.ErrInvalidCursor {
+					   code = http.StatusBadRequest
+			   }
+			   c.JSON(code, gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, gin.H{"data": dtodlv.FromDomainCommentNodes(nodes), "next_cursor": next})
+}
+
+// GetHistory returns the earlier versions of an edited comment
+func (cc *CommentController) GetHistory(c *gin.Context) {
+	   history, err := cc.usecase.GetCommentHistory(c.Request.Context(), c.Param("id"))
+	   if err != nil {
+			   c.JSON(commentStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, gin.H{"data": dtodlv.FromDomainCommentRevisions(history)})
+}
+
+// Pin, Unpin, Hide and Unhide are for the author of the comment's article
+func (cc *CommentController) Pin(c *gin.Context) {
+	   cc.authorAction(c, "Comment pinned", func(userID, id string) error {
+			   return cc.usecase.PinComment(c.Request.Context(), userID, id, true)
+	   })
+}
+
+func (cc *CommentController) Unpin(c *gin.Context) {
+	   cc.authorAction(c, "Comment unpinned", func(userID, id string) error {
+			   return cc.usecase.PinComment(c.Request.Context(), userID, id, false)
+	   })
+}
+
+func (cc *CommentController) Hide(c *gin.Context) {
+	   cc.authorAction(c, "Comment hidden", func(userID, id string) error {
+			   return cc.usecase.HideComment(c.Request.Context(), userID, id, true)
+	   })
+}
+
+func (cc *CommentController) Unhide(c *gin.Context) {
+	   cc.authorAction(c, "Comment unhidden", func(userID, id string) error {
+			   return cc.usecase.HideComment(c.Request.Context(), userID, id, false)
+	   })
+}
+
+func (cc *CommentController) authorAction(c *gin.Context, message string, act func(userID, id string) error) {
+	   if err := act(c.GetString("user_id"), c.Param("id")); err != nil {
+			   c.JSON(commentStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, gin.H{"message": message})
+}
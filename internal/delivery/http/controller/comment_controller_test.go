@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -24,11 +25,23 @@ type fakeCommentUC struct {
 	getByIDErr error
 	listRes    []*domain.Comment
 	listErr    error
+	created    *domain.Comment
+	tree       []domain.CommentNode
+	treeQuery  domain.CommentThreadQuery
+	treeErr    error
+	history    []domain.CommentRevision
+	actionErr  error
+	actions    []string
 }
 
-func (f *fakeCommentUC) CreateComment(_ context.Context, _ *domain.Comment) error { return f.createErr }
-func (f *fakeCommentUC) UpdateComment(_ context.Context, _ *domain.Comment) error { return f.updateErr }
-func (f *fakeCommentUC) DeleteComment(_ context.Context, _ string) error          { return f.deleteErr }
+func (f *fakeCommentUC) CreateComment(_ context.Context, c *domain.Comment) error {
+	f.created = c
+	return f.createErr
+}
+func (f *fakeCommentUC) UpdateComment(_ context.Context, _ string, _ *domain.Comment) error {
+	return f.updateErr
+}
+func (f *fakeCommentUC) DeleteComment(_ context.Context, _, _ string) error { return f.deleteErr }
 func (f *fakeCommentUC) GetCommentByID(_ context.Context, _ string) (*domain.Comment, error) {
 	return f.getByIDRes, f.getByIDErr
 }
@@ -42,6 +55,27 @@ func (f *fakeCommentUC) GetReplies(_ context.Context, _ string) ([]*domain.Comme
 	return f.listRes, f.listErr
 }
 
+func (f *fakeCommentUC) GetCommentTree(_ context.Context, _ string, q domain.CommentThreadQuery) ([]domain.CommentNode, string, error) {
+	f.treeQuery = q
+	return f.tree, "next", f.treeErr
+}
+func (f *fakeCommentUC) GetReplyTree(_ context.Context, _ string, q domain.CommentThreadQuery) ([]domain.CommentNode, string, error) {
+	f.treeQuery = q
+	return f.tree, "", f.treeErr
+}
+
+func (f *fakeCommentUC) GetCommentHistory(_ context.Context, _ string) ([]domain.CommentRevision, error) {
+	return f.history, f.actionErr
+}
+func (f *fakeCommentUC) PinComment(_ context.Context, userID, id string, pinned bool) error {
+	f.actions = append(f.actions, fmt.Sprintf("pin %s %s %v", userID, id, pinned))
+	return f.actionErr
+}
+func (f *fakeCommentUC) HideComment(_ context.Context, userID, id string, hidden bool) error {
+	f.actions = append(f.actions, fmt.Sprintf("hide %s %s %v", userID, id, hidden))
+	return f.actionErr
+}
+
 func setupCommentRouter(uc domain.ICommentUsecase) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -62,7 +96,7 @@ func TestCommentController_HappyPaths(t *testing.T) {
 	r := setupCommentRouter(uc)
 
 	// Create
-	body, _ := json.Marshal(dtodlv.CommentRequest{PostID: "p1", UserID: "u1", Content: "hi"})
+	body, _ := json.Marshal(dtodlv.CommentRequest{PostID: "p1", Content: "hi"})
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodPost, "/comments", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -107,3 +141,122 @@ func TestCommentController_HappyPaths(t *testing.T) {
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestCommentController_AuthorFromToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &fakeCommentUC{}
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("user_id", "token-user") })
+	h := NewCommentController(uc)
+	r.POST("/comments", h.Create)
+	r.DELETE("/comments/:id", h.Delete)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/comments", bytes.NewReader([]byte(`{"post_id":"p1","user_id":"someone-else","content":"hi"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, "token-user", uc.created.UserID)
+
+	uc.deleteErr = domain.ErrCommentPermission
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/comments/c1", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestCommentController_Tree(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &fakeCommentUC{tree: []domain.CommentNode{{
+		Comment:        domain.Comment{ID: "c1", PostID: "p1"},
+		ReplyCount:     2,
+		Replies:        []domain.CommentNode{{Comment: domain.Comment{ID: "c2"}, Likes: 4}},
+		HasMoreReplies: true,
+	}}}
+	r := gin.New()
+	h := NewCommentController(uc)
+	r.GET("/comments/post/:post_id/tree", h.GetTree)
+	r.GET("/comments/:id/thread", h.GetThread)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/comments/post/p1/tree?sort=most_liked&page_size=5&depth=2&replies=0", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, domain.CommentThreadQuery{Sort: domain.CommentSortMostLiked, PageSize: 5, Depth: 2, Replies: 0}, uc.treeQuery)
+	var body struct {
+		Data       []dtodlv.CommentNodeResponse `json:"data"`
+		NextCursor string                       `json:"next_cursor"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "next", body.NextCursor)
+	require.Len(t, body.Data, 1)
+	require.Equal(t, 2, body.Data[0].ReplyCount)
+	require.True(t, body.Data[0].HasMoreReplies)
+	require.Equal(t, 4, body.Data[0].Replies[0].Likes)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/comments/c1/thread", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, domain.DefaultReplyPreview, uc.treeQuery.Replies)
+
+	for _, q := range []string{"sort=top", "page_size=51", "depth=7", "replies=11"} {
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodGet, "/comments/post/p1/tree?"+q, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+
+	uc.treeErr = domain.ErrInvalidCursor
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/comments/post/p1/tree?cursor=bad", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	uc.treeErr = domain.ErrCommentNotFound
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/comments/missing/thread", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCommentController_AuthorActions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &fakeCommentUC{history: []domain.CommentRevision{{Content: "first", CreatedAt: 1}}}
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("user_id", "writer") })
+	h := NewCommentController(uc)
+	r.GET("/comments/:id/history", h.GetHistory)
+	r.PUT("/comments/:id/pin", h.Pin)
+	r.DELETE("/comments/:id/pin", h.Unpin)
+	r.PUT("/comments/:id/hide", h.Hide)
+	r.DELETE("/comments/:id/hide", h.Unhide)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/comments/c1/history", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"data":[{"content":"first","created_at":1}]}`, w.Body.String())
+
+	for _, m := range []string{http.MethodPut, http.MethodDelete} {
+		for _, path := range []string{"/comments/c1/pin", "/comments/c1/hide"} {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest(m, path, nil)
+			r.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, m+" "+path)
+		}
+	}
+	require.Equal(t, []string{"pin writer c1 true", "hide writer c1 true", "pin writer c1 false", "hide writer c1 false"}, uc.actions)
+
+	for err, code := range map[error]int{
+		domain.ErrCommentPermission: http.StatusForbidden,
+		domain.ErrCommentPinReply:   http.StatusBadRequest,
+		domain.ErrCommentNotFound:   http.StatusNotFound,
+	} {
+		uc.actionErr = err
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodPut, "/comments/c1/pin", nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, code, w.Code, err.Error())
+	}
+}
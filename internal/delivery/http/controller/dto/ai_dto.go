@@ -16,3 +16,10 @@ type GenerateContentRequestDTO struct {
 type GenerateContentResponseDTO struct {
 	Content string `json:"content"`
 }
+
+type AICacheStatsResponseDTO struct {
+	Backend string `json:"backend"`
+	Entries int64  `json:"entries"`
+	Hits    int64  `json:"hits"`
+	Misses  int64  `json:"misses"`
+}
@@ -0,0 +1,86 @@
+package dto
+
+import "write_base/internal/domain"
+
+// CommentTreeQuery shapes a comment tree page. An omitted replies count uses
+// the default and 0 collapses every branch to its reply count.
+type CommentTreeQuery struct {
+	Sort     string `form:"sort" binding:"omitempty,oneof=newest oldest most_liked"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=50"`
+	Cursor   string `form:"cursor"`
+	Depth    int    `form:"depth" binding:"omitempty,min=1,max=6"`
+	Replies  *int   `form:"replies" binding:"omitempty,min=0,max=10"`
+}
+
+func (q CommentTreeQuery) ToDomain() domain.CommentThreadQuery {
+	replies := domain.DefaultReplyPreview
+	if q.Replies != nil {
+		replies = *q.Replies
+	}
+	return domain.CommentThreadQuery{
+		Sort:     domain.CommentSort(q.Sort),
+		PageSize: q.PageSize,
+		Cursor:   q.Cursor,
+		Depth:    q.Depth,
+		Replies:  replies,
+	}
+}
+
+type CommentNodeResponse struct {
+	ID             string                `json:"id"`
+	PostID         string                `json:"post_id"`
+	UserID         string                `json:"user_id"`
+	ParentID       *string               `json:"parent_id,omitempty"`
+	Content        string                `json:"content"`
+	Mentions       []MentionResponse     `json:"mentions,omitempty"`
+	CreatedAt      int64                 `json:"created_at"`
+	UpdatedAt      int64                 `json:"updated_at"`
+	Edited         bool                  `json:"edited"`
+	EditedAt       int64                 `json:"edited_at,omitempty"`
+	Deleted        bool                  `json:"deleted"`
+	Hidden         bool                  `json:"hidden"`
+	Pinned         bool                  `json:"pinned"`
+	Likes          int                   `json:"likes"`
+	ReplyCount     int                   `json:"reply_count"`
+	Replies        []CommentNodeResponse `json:"replies"`
+	HasMoreReplies bool                  `json:"has_more_replies"`
+}
+
+func FromDomainCommentNodes(nodes []domain.CommentNode) []CommentNodeResponse {
+	out := make([]CommentNodeResponse, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, CommentNodeResponse{
+			ID:             n.ID,
+			PostID:         n.PostID,
+			UserID:         n.UserID,
+			ParentID:       n.ParentID,
+			Content:        n.Content,
+			Mentions:       FromDomainMentions(n.Mentions),
+			CreatedAt:      n.CreatedAt,
+			UpdatedAt:      n.UpdatedAt,
+			Edited:         n.EditedAt != 0,
+			EditedAt:       n.EditedAt,
+			Deleted:        n.Deleted,
+			Hidden:         n.Hidden,
+			Pinned:         n.Pinned,
+			Likes:          n.Likes,
+			ReplyCount:     n.ReplyCount,
+			Replies:        FromDomainCommentNodes(n.Replies),
+			HasMoreReplies: n.HasMoreReplies,
+		})
+	}
+	return out
+}
+
+type CommentRevisionResponse struct {
+	Content   string `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func FromDomainCommentRevisions(history []domain.CommentRevision) []CommentRevisionResponse {
+	out := make([]CommentRevisionResponse, 0, len(history))
+	for _, r := range history {
+		out = append(out, CommentRevisionResponse{Content: r.Content, CreatedAt: r.CreatedAt})
+	}
+	return out
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "write_base/internal/domain"
+
+// MentionResponse is an @username with the profile it links to.
+type MentionResponse struct {
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	ProfileURL string `json:"profile_url"`
+}
+
+func FromDomainMentions(mentions []domain.Mention) []MentionResponse {
+	if len(mentions) == 0 {
+		return nil
+	}
+	out := make([]MentionResponse, 0, len(mentions))
+	for _, m := range mentions {
+		out = append(out, MentionResponse{UserID: m.UserID, Username: m.Username, ProfileURL: m.ProfilePath()})
+	}
+	return out
+}
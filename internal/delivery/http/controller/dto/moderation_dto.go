@@ -0,0 +1,17 @@
+package dto
+
+type ModerationCheckRequestDTO struct {
+	Kind string `json:"kind" binding:"required,oneof=ai_output article comment profile_bio"`
+	Text string `json:"text" binding:"required"`
+}
+
+type ModerationReasonDTO struct {
+	Check  string `json:"check"`
+	Action string `json:"action"`
+	Detail string `json:"detail"`
+}
+
+type ModerationVerdictDTO struct {
+	Action  string                `json:"action"`
+	Reasons []ModerationReasonDTO `json:"reasons"`
+}
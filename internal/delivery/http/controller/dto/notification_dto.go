@@ -0,0 +1,57 @@
+package dto
+
+import "write_base/internal/domain"
+
+type NotificationQuery struct {
+	Unread   bool   `form:"unread"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+type NotificationResponse struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	ActorID   string `json:"actor_id,omitempty"`
+	ArticleID string `json:"article_id,omitempty"`
+	CommentID string `json:"comment_id,omitempty"`
+	ReportID  string `json:"report_id,omitempty"`
+	Message   string `json:"message"`
+	Read      bool   `json:"read"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func FromDomainNotification(n domain.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID,
+		Type:      string(n.Type),
+		ActorID:   n.ActorID,
+		ArticleID: n.ArticleID,
+		CommentID: n.CommentID,
+		ReportID:  n.ReportID,
+		Message:   n.Message,
+		Read:      n.Read,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func FromDomainNotifications(items []domain.Notification) []NotificationResponse {
+	out := make([]NotificationResponse, 0, len(items))
+	for _, n := range items {
+		out = append(out, FromDomainNotification(n))
+	}
+	return out
+}
+
+// NotificationPreferencesRequest turns types on or off; omitted types keep
+// their setting.
+type NotificationPreferencesRequest struct {
+	Preferences map[string]bool `json:"preferences" binding:"required"`
+}
+
+func (r NotificationPreferencesRequest) ToDomain() domain.NotificationPreferences {
+	prefs := domain.NotificationPreferences{}
+	for t, on := range r.Preferences {
+		prefs[domain.NotificationType(t)] = on
+	}
+	return prefs
+}
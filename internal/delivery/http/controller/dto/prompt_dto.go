@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type PromptTemplateResponseDTO struct {
+	Name        string    `json:"name"`
+	Version     int       `json:"version"`
+	Body        string    `json:"body"`
+	Description string    `json:"description"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+type UpdatePromptTemplateRequestDTO struct {
+	Body        string `json:"body" binding:"required"`
+	Description string `json:"description"`
+}
+
+type PreviewPromptTemplateRequestDTO struct {
+	Body      string                 `json:"body"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type PreviewPromptTemplateResponseDTO struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+	Prompt  string `json:"prompt"`
+}
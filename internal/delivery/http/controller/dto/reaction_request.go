@@ -1,8 +1,44 @@
 package dto
 
+import "write_base/internal/domain"
+
 type ReactionRequest struct {
 	PostID    string  `json:"post_id"`
-	UserID    string  `json:"user_id"`
 	CommentID *string `json:"comment_id,omitempty"`
 	Type      string  `json:"type"`
 }
+
+// ReactionSummaryQuery names an article, or a comment when comment_id is set.
+type ReactionSummaryQuery struct {
+	PostID    string `form:"post_id"`
+	CommentID string `form:"comment_id"`
+}
+
+type ReactionOptionResponse struct {
+	Type  string `json:"type"`
+	Emoji string `json:"emoji"`
+}
+
+func FromDomainReactionOptions(options []domain.ReactionOption) []ReactionOptionResponse {
+	out := make([]ReactionOptionResponse, 0, len(options))
+	for _, o := range options {
+		out = append(out, ReactionOptionResponse{Type: string(o.Type), Emoji: o.Emoji})
+	}
+	return out
+}
+
+type ReactionSummaryResponse struct {
+	Counts map[string]int `json:"counts"`
+	Mine   []string       `json:"mine"`
+}
+
+func FromDomainReactionSummary(s *domain.ReactionSummary) ReactionSummaryResponse {
+	out := ReactionSummaryResponse{Counts: make(map[string]int, len(s.Counts)), Mine: []string{}}
+	for t, n := range s.Counts {
+		out.Counts[string(t)] = n
+	}
+	for _, t := range s.Mine {
+		out.Mine = append(out.Mine, string(t))
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "write_base/internal/domain"
+
+// SuggestQuery holds the per-kind limits; an omitted limit uses the default
+// and 0 leaves that kind out.
+type SuggestQuery struct {
+	ArticleLimit *int `form:"article_limit" binding:"omitempty,min=0,max=10"`
+	TagLimit     *int `form:"tag_limit" binding:"omitempty,min=0,max=10"`
+	UserLimit    *int `form:"user_limit" binding:"omitempty,min=0,max=10"`
+}
+
+func (q SuggestQuery) ToDomain() domain.SuggestLimits {
+	limit := func(v *int) int {
+		if v == nil {
+			return domain.DefaultSuggestLimit
+		}
+		return *v
+	}
+	return domain.SuggestLimits{
+		Articles: limit(q.ArticleLimit),
+		Tags:     limit(q.TagLimit),
+		Users:    limit(q.UserLimit),
+	}
+}
+
+type SuggestionResponse struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	Slug string `json:"slug,omitempty"`
+}
+
+type SuggestionsResponse struct {
+	Articles []SuggestionResponse `json:"articles"`
+	Tags     []SuggestionResponse `json:"tags"`
+	Users    []SuggestionResponse `json:"users"`
+}
+
+func FromDomainSuggestions(s *domain.Suggestions) SuggestionsResponse {
+	convert := func(in []domain.Suggestion) []SuggestionResponse {
+		out := make([]SuggestionResponse, 0, len(in))
+		for _, v := range in {
+			out = append(out, SuggestionResponse{ID: v.ID, Text: v.Text, Slug: v.Slug})
+		}
+		return out
+	}
+	return SuggestionsResponse{
+		Articles: convert(s.Articles),
+		Tags:     convert(s.Tags),
+		Users:    convert(s.Users),
+	}
+}
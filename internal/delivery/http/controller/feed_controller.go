@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"net/http"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedController struct {
+	usecase domain.IFeedUsecase
+}
+
+func NewFeedController(usecase domain.IFeedUsecase) *FeedController {
+	return &FeedController{usecase: usecase}
+}
+
+type feedQuery struct {
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+// GetFeed serves the signed-in reader's home feed. It only pages by cursor.
+func (fc *FeedController) GetFeed(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var q feedQuery
+	if err := ctx.ShouldBindQuery(&q); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	pag := domain.Pagination{PageSize: q.PageSize}
+	pag.ValidatePagination()
+
+	items, next, err := fc.usecase.GetFeed(ctx.Request.Context(), userID, pag.PageSize, q.Cursor)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidCursor:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case domain.ErrUnauthorized:
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	resp := make([]FeedItemResponse, 0, len(items))
+	for _, item := range items {
+		var dto FeedItemResponse
+		dto.FromDomain(item)
+		resp = append(resp, dto)
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"data":        resp,
+		"page_size":   pag.PageSize,
+		"next_cursor": next,
+	})
+}
@@ -0,0 +1,83 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type feedUCStub struct {
+	gotUser, gotCursor string
+	gotSize            int
+	items              []domain.FeedItem
+	next               string
+	err                error
+}
+
+func (s *feedUCStub) GetFeed(ctx context.Context, userID string, pageSize int, cursor string) ([]domain.FeedItem, string, error) {
+	s.gotUser, s.gotSize, s.gotCursor = userID, pageSize, cursor
+	return s.items, s.next, s.err
+}
+
+func feedRouter(uc domain.IFeedUsecase, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if userID != "" {
+		r.Use(func(c *gin.Context) { c.Set("user_id", userID); c.Next() })
+	}
+	r.GET("/feed", controller.NewFeedController(uc).GetFeed)
+	return r
+}
+
+func TestGetFeed_Response(t *testing.T) {
+	uc := &feedUCStub{
+		items: []domain.FeedItem{{Article: domain.Article{ID: "a1", Title: "Hi"}, Reasons: []string{domain.FeedReasonFollowing}}},
+		next:  "next-page",
+	}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/feed?cursor=abc", nil)
+	feedRouter(uc, "u1").ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "u1", uc.gotUser)
+	require.Equal(t, 10, uc.gotSize)
+	require.Equal(t, "abc", uc.gotCursor)
+
+	var body struct {
+		Data []struct {
+			ID      string   `json:"id"`
+			Reasons []string `json:"reasons"`
+		} `json:"data"`
+		NextCursor string `json:"next_cursor"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "next-page", body.NextCursor)
+	require.Len(t, body.Data, 1)
+	require.Equal(t, []string{"following"}, body.Data[0].Reasons)
+}
+
+func TestGetFeed_ErrorMapping(t *testing.T) {
+	cases := []struct {
+		user string
+		url  string
+		err  error
+		code int
+	}{
+		{"", "/feed", nil, http.StatusUnauthorized},
+		{"u1", "/feed?page_size=101", nil, http.StatusBadRequest},
+		{"u1", "/feed", domain.ErrInvalidCursor, http.StatusBadRequest},
+		{"u1", "/feed", domain.ErrInternalServer, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+		feedRouter(&feedUCStub{err: tc.err}, tc.user).ServeHTTP(w, req)
+		require.Equal(t, tc.code, w.Code, tc.url)
+	}
+}
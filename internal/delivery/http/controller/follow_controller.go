@@ -16,14 +16,25 @@ func NewFollowController(usecase domain.IFollowUsecase) *FollowController {
 	return &FollowController{usecase: usecase}
 }
 
+// followStatus maps follow errors to HTTP codes.
+func followStatus(err error) int {
+	switch err {
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrCannotFollowSelf:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
 func (fc *FollowController) FollowUser(c *gin.Context) {
 	   var req dtodlv.FollowRequest
 	   if err := c.ShouldBindJSON(&req); err != nil {
 			   c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 			   return
 	   }
-	   if err := fc.usecase.FollowUser(c, req.FollowerID, req.FolloweeID); err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	   if err := fc.usecase.FollowUser(c, c.GetString("user_id"), req.FolloweeID); err != nil {
+			   c.JSON(followStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
 	   c.JSON(http.StatusCreated, gin.H{"message": "Followed user"})
@@ -35,8 +46,8 @@ func (fc *FollowController) UnfollowUser(c *gin.Context) {
 			   c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 			   return
 	   }
-	   if err := fc.usecase.UnfollowUser(c, req.FollowerID, req.FolloweeID); err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	   if err := fc.usecase.UnfollowUser(c, c.GetString("user_id"), req.FolloweeID); err != nil {
+			   c.JSON(followStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
 	   c.JSON(http.StatusOK, gin.H{"message": "Unfollowed user"})
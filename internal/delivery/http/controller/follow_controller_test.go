@@ -48,7 +48,7 @@ func TestFollowController_HappyPaths(t *testing.T) {
 	r := setupFollowRouter(uc)
 
 	// Follow
-	body, _ := json.Marshal(dtodlv.FollowRequest{FollowerID: "u1", FolloweeID: "u2"})
+	body, _ := json.Marshal(dtodlv.FollowRequest{FolloweeID: "u2"})
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodPost, "/follow", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+	dtodlv "write_base/internal/delivery/http/controller/dto"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationController struct {
+	service domain.IModerationService
+}
+
+func NewModerationController(service domain.IModerationService) *ModerationController {
+	return &ModerationController{service: service}
+}
+
+// POST /admin/moderation/check
+// Runs the moderation pipeline on arbitrary text so admins can tune word lists.
+func (mc *ModerationController) Check(c *gin.Context) {
+	var req dtodlv.ModerationCheckRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	verdict, err := mc.service.Moderate(c.Request.Context(), domain.ContentKind(req.Kind), req.Text)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dtodlv.ModerationVerdictDTO{Action: string(verdict.Action), Reasons: []dtodlv.ModerationReasonDTO{}}
+	for _, r := range verdict.Reasons {
+		resp.Reasons = append(resp.Reasons, dtodlv.ModerationReasonDTO{Check: r.Check, Action: string(r.Action), Detail: r.Detail})
+	}
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
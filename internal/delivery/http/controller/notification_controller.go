@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	dtodlv "write_base/internal/delivery/http/controller/dto"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamRetry is how long a browser waits before reconnecting a dropped stream.
+const streamRetry = 3 * time.Second
+
+type NotificationController struct {
+	usecase domain.INotificationUsecase
+	// heartbeat is how often an idle stream sends a comment line, keeping
+	// proxies from closing it and letting the server notice dead clients
+	heartbeat time.Duration
+}
+
+func NewNotificationController(usecase domain.INotificationUsecase, heartbeat time.Duration) *NotificationController {
+	return &NotificationController{usecase: usecase, heartbeat: heartbeat}
+}
+
+// notificationStatus maps notification errors to HTTP codes.
+func notificationStatus(err error) int {
+	switch err {
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrNotificationNotFound:
+		return http.StatusNotFound
+	case domain.ErrInvalidCursor, domain.ErrInvalidNotificationType:
+		return http.StatusBadRequest
+	case domain.ErrNotificationStreamUnavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// List returns a page of the user's notifications, newest first, with the unread count
+func (nc *NotificationController) List(c *gin.Context) {
+	   var q dtodlv.NotificationQuery
+	   if err := c.ShouldBindQuery(&q); err != nil {
+			   c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			   return
+	   }
+	   userID := c.GetString("user_id")
+	   items, next, err := nc.usecase.List(c.Request.Context(), userID, q.Unread, q.PageSize, q.Cursor)
+	   if err != nil {
+			   c.JSON(notificationStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   unread, err := nc.usecase.UnreadCount(c.Request.Context(), userID)
+	   if err != nil {
+			   c.JSON(notificationStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, gin.H{"data": dtodlv.FromDomainNotifications(items), "unread_count": unread, "next_cursor": next})
+}
+
+func (nc *NotificationController) UnreadCount(c *gin.Context) {
+	   unread, err := nc.usecase.UnreadCount(c.Request.Context(), c.GetString("user_id"))
+	   if err != nil {
+			   c.JSON(notificationStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, gin.H{"unread_count": unread})
+}
+
+func (nc *NotificationController) MarkRead(c *gin.Context) {
+	   if err := nc.usecase.MarkRead(c.Request.Context(), c.GetString("user_id"), c.Param("id")); err != nil {
+			   c.JSON(notificationStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+func (nc *NotificationController) MarkAllRead(c *gin.Context) {
+	   updated, err := nc.usecase.MarkAllRead(c.Request.Context(), c.GetString("user_id"))
+	   if err != nil {
+			   c.JSON(notificationStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+func (nc *NotificationController) GetPreferences(c *gin.Context) {
+	   prefs, err := nc.usecase.GetPreferences(c.Request.Context(), c.GetString("user_id"))
+	   if err != nil {
+			   c.JSON(notificationStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, gin.H{"preferences": prefs})
+}
+
+func (nc *NotificationController) UpdatePreferences(c *gin.Context) {
+	   var req dtodlv.NotificationPreferencesRequest
+	   if err := c.ShouldBindJSON(&req); err != nil {
+			   c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			   return
+	   }
+	   prefs, err := nc.usecase.UpdatePreferences(c.Request.Context(), c.GetString("user_id"), req.ToDomain())
+	   if err != nil {
+			   c.JSON(notificationStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, gin.H{"preferences": prefs})
+}
+
+// Stream sends new notifications as server-sent events. Each event's ID is the
+// notification ID; a client reconnecting with Last-Event-ID (or last_event_id,
+// for the first connection) first receives what it missed.
+func (nc *NotificationController) Stream(c *gin.Context) {
+	   lastEventID := c.GetHeader("Last-Event-ID")
+	   if lastEventID == "" {
+			   lastEventID = c.Query("last_event_id")
+	   }
+	   ctx := c.Request.Context()
+	   events, err := nc.usecase.Stream(ctx, c.GetString("user_id"), lastEventID)
+	   if err != nil {
+			   c.JSON(notificationStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+
+	   header := c.Writer.Header()
+	   header.Set("Content-Type", "text/event-stream")
+	   header.Set("Cache-Control", "no-cache")
+	   header.Set("Connection", "keep-alive")
+	   header.Set("X-Accel-Buffering", "no")
+	   c.Status(http.StatusOK)
+	   fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+	   c.Writer.Flush()
+
+	   ticker := time.NewTicker(nc.heartbeat)
+	   defer ticker.Stop()
+	   for {
+			   select {
+			   case <-ctx.Done():
+					   return
+			   case <-ticker.C:
+					   fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			   case n, ok := <-events:
+					   if !ok {
+							   return
+					   }
+					   data, _ := json.Marshal(dtodlv.FromDomainNotification(n))
+					   fmt.Fprintf(c.Writer, "id: %s\nevent: notification\ndata: %s\n\n", n.ID, data)
+			   }
+			   c.Writer.Flush()
+	   }
+}
@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func setupNotificationRouter(uc domain.INotificationUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("user_id", "u1") })
+	h := NewNotificationController(uc, time.Minute)
+	r.GET("/notifications", h.List)
+	r.GET("/notifications/unread-count", h.UnreadCount)
+	r.PUT("/notifications/read-all", h.MarkAllRead)
+	r.PUT("/notifications/:id/read", h.MarkRead)
+	r.GET("/notifications/preferences", h.GetPreferences)
+	r.PUT("/notifications/preferences", h.UpdatePreferences)
+	r.GET("/notifications/stream", h.Stream)
+	return r
+}
+
+func TestNotificationController_List(t *testing.T) {
+	var gotUnread bool
+	var gotSize int
+	uc := &mocks.NotificationUsecaseMock{
+		ListFn: func(ctx context.Context, userID string, unreadOnly bool, pageSize int, cursor string) ([]domain.Notification, string, error) {
+			require.Equal(t, "u1", userID)
+			gotUnread, gotSize = unreadOnly, pageSize
+			if cursor == "bad" {
+				return nil, "", domain.ErrInvalidCursor
+			}
+			return []domain.Notification{{ID: "n1", Type: domain.NotificationNewFollower, ActorID: "u2", Message: "bob started following you"}}, "next", nil
+		},
+		UnreadCountFn: func(ctx context.Context, userID string) (int, error) { return 3, nil },
+	}
+	r := setupNotificationRouter(uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/notifications?unread=true&page_size=5", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, gotUnread)
+	require.Equal(t, 5, gotSize)
+	var body struct {
+		Data []struct {
+			ID      string `json:"id"`
+			Type    string `json:"type"`
+			ActorID string `json:"actor_id"`
+		} `json:"data"`
+		UnreadCount int    `json:"unread_count"`
+		NextCursor  string `json:"next_cursor"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Data, 1)
+	require.Equal(t, "new_follower", body.Data[0].Type)
+	require.Equal(t, 3, body.UnreadCount)
+	require.Equal(t, "next", body.NextCursor)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/notifications?cursor=bad", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/notifications?page_size=1000", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNotificationController_MarkRead(t *testing.T) {
+	uc := &mocks.NotificationUsecaseMock{
+		MarkReadFn: func(ctx context.Context, userID, id string) error {
+			if id == "missing" {
+				return domain.ErrNotificationNotFound
+			}
+			return nil
+		},
+		MarkAllReadFn: func(ctx context.Context, userID string) (int, error) { return 4, nil },
+	}
+	r := setupNotificationRouter(uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/notifications/n1/read", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPut, "/notifications/missing/read", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPut, "/notifications/read-all", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"updated":4}`, w.Body.String())
+}
+
+func TestNotificationController_Preferences(t *testing.T) {
+	uc := &mocks.NotificationUsecaseMock{
+		UpdatePreferencesFn: func(ctx context.Context, userID string, changes domain.NotificationPreferences) (domain.NotificationPreferences, error) {
+			for typ := range changes {
+				if !typ.Valid() {
+					return nil, domain.ErrInvalidNotificationType
+				}
+			}
+			return changes, nil
+		},
+	}
+	r := setupNotificationRouter(uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/notifications/preferences", bytes.NewReader([]byte(`{"preferences":{"mention":false}}`)))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"preferences":{"mention":false}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPut, "/notifications/preferences", bytes.NewReader([]byte(`{"preferences":{"nope":false}}`)))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNotificationController_Stream(t *testing.T) {
+	var gotLast string
+	uc := &mocks.NotificationUsecaseMock{
+		StreamFn: func(ctx context.Context, userID, lastEventID string) (<-chan domain.Notification, error) {
+			gotLast = lastEventID
+			if lastEventID == "bad" {
+				return nil, domain.ErrInvalidCursor
+			}
+			ch := make(chan domain.Notification, 1)
+			ch <- domain.Notification{ID: "n2", Type: domain.NotificationMention, Message: "bob mentioned you"}
+			close(ch)
+			return ch, nil
+		},
+	}
+	r := setupNotificationRouter(uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/notifications/stream", nil)
+	req.Header.Set("Last-Event-ID", "n1")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "n1", gotLast)
+	require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	require.True(t, strings.HasPrefix(body, "retry: 3000\n\n"), body)
+	require.Contains(t, body, "id: n2\nevent: notification\ndata: {")
+	require.Contains(t, body, `"message":"bob mentioned you"`)
+
+	// The query parameter stands in for the header on a first connection
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/notifications/stream?last_event_id=bad", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNotificationController_StreamHeartbeat(t *testing.T) {
+	uc := &mocks.NotificationUsecaseMock{
+		StreamFn: func(ctx context.Context, userID, lastEventID string) (<-chan domain.Notification, error) {
+			return make(chan domain.Notification), nil
+		},
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/notifications/stream", NewNotificationController(uc, 5*time.Millisecond).Stream)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/notifications/stream", nil)
+	r.ServeHTTP(w, req)
+	require.Contains(t, w.Body.String(), ": heartbeat\n\n")
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"net/http"
+	dtodlv "write_base/internal/delivery/http/controller/dto"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromptController struct {
+	usecase domain.IPromptTemplateUsecase
+}
+
+func NewPromptController(usecase domain.IPromptTemplateUsecase) *PromptController {
+	return &PromptController{usecase: usecase}
+}
+
+func toPromptTemplateResponse(tpl domain.PromptTemplate) dtodlv.PromptTemplateResponseDTO {
+	return dtodlv.PromptTemplateResponseDTO{
+		Name:        tpl.Name,
+		Version:     tpl.Version,
+		Body:        tpl.Body,
+		Description: tpl.Description,
+		UpdatedBy:   tpl.UpdatedBy,
+		CreatedAt:   tpl.CreatedAt,
+	}
+}
+
+func promptErrorStatus(err error) int {
+	switch err {
+	case domain.ErrPromptTemplateNotFound:
+		return http.StatusNotFound
+	case domain.ErrInvalidPromptTemplate, domain.ErrPromptRenderFailed:
+		return http.StatusBadRequest
+	case domain.ErrConflict:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// GET /admin/prompts
+func (pc *PromptController) ListTemplates(c *gin.Context) {
+	templates, err := pc.usecase.ListTemplates(c.Request.Context())
+	if err != nil {
+		c.JSON(promptErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]dtodlv.PromptTemplateResponseDTO, 0, len(templates))
+	for _, tpl := range templates {
+		resp = append(resp, toPromptTemplateResponse(tpl))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+// GET /admin/prompts/:name
+func (pc *PromptController) GetTemplate(c *gin.Context) {
+	active, versions, err := pc.usecase.GetTemplate(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		c.JSON(promptErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	history := make([]dtodlv.PromptTemplateResponseDTO, 0, len(versions))
+	for _, tpl := range versions {
+		history = append(history, toPromptTemplateResponse(tpl))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":     toPromptTemplateResponse(*active),
+		"versions": history,
+	})
+}
+
+// PUT /admin/prompts/:name
+func (pc *PromptController) UpdateTemplate(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": domain.ErrUnauthorized.Error()})
+		return
+	}
+	var req dtodlv.UpdatePromptTemplateRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	tpl, err := pc.usecase.UpdateTemplate(c.Request.Context(), userID, c.Param("name"), req.Body, req.Description)
+	if err != nil {
+		c.JSON(promptErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": toPromptTemplateResponse(*tpl)})
+}
+
+// POST /admin/prompts/:name/preview
+func (pc *PromptController) PreviewTemplate(c *gin.Context) {
+	var req dtodlv.PreviewPromptTemplateRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	rendered, err := pc.usecase.PreviewTemplate(c.Request.Context(), c.Param("name"), req.Body, req.Variables)
+	if err != nil {
+		c.JSON(promptErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dtodlv.PreviewPromptTemplateResponseDTO{
+		Name:    rendered.Name,
+		Version: rendered.Version,
+		Prompt:  rendered.Text,
+	})
+}
@@ -16,6 +16,21 @@ func NewReactionController(usecase domain.IReactionUsecase) *ReactionController
 	return &ReactionController{usecase: usecase}
 }
 
+// reactionStatus maps reaction errors to HTTP codes.
+func reactionStatus(err error) int {
+	switch err {
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrForbidden:
+		return http.StatusForbidden
+	case domain.ErrReactionNotFound, domain.ErrArticleNotFound, domain.ErrCommentNotFound:
+		return http.StatusNotFound
+	case domain.ErrInvalidReactionType, domain.ErrArticleNotPublished:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
 func (rc *ReactionController) AddReaction(c *gin.Context) {
 	   var req dtodlv.ReactionRequest
 	   if err := c.ShouldBindJSON(&req); err != nil {
@@ -24,21 +39,67 @@ func (rc *ReactionController) AddReaction(c *gin.Context) {
 	   }
 	   reaction := &domain.Reaction{
 			   PostID:    req.PostID,
-			   UserID:    req.UserID,
+			   UserID:    c.GetString("user_id"),
 			   CommentID: req.CommentID,
 			   Type:      domain.ReactionType(req.Type),
 	   }
 	   if err := rc.usecase.AddReaction(c, reaction); err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			   c.JSON(reactionStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusCreated, gin.H{"message": "Reaction added", "id": reaction.ID})
+}
+
+// ToggleReaction adds the caller's reaction of that type, or removes it if it is already there
+func (rc *ReactionController) ToggleReaction(c *gin.Context) {
+	   var req dtodlv.ReactionRequest
+	   if err := c.ShouldBindJSON(&req); err != nil {
+			   c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+			   return
+	   }
+	   reaction := &domain.Reaction{
+			   PostID:    req.PostID,
+			   UserID:    c.GetString("user_id"),
+			   CommentID: req.CommentID,
+			   Type:      domain.ReactionType(req.Type),
+	   }
+	   added, err := rc.usecase.ToggleReaction(c, reaction)
+	   if err != nil {
+			   c.JSON(reactionStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
-	   c.JSON(http.StatusCreated, gin.H{"message": "Reaction added"})
+	   c.JSON(http.StatusOK, gin.H{"added": added, "type": reaction.Type})
+}
+
+// GetSummary counts an article's or comment's reactions by type in one call
+func (rc *ReactionController) GetSummary(c *gin.Context) {
+	   var q dtodlv.ReactionSummaryQuery
+	   if err := c.ShouldBindQuery(&q); err != nil {
+			   c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			   return
+	   }
+	   if q.PostID == "" && q.CommentID == "" {
+			   c.JSON(http.StatusBadRequest, gin.H{"error": "post_id or comment_id is required"})
+			   return
+	   }
+	   target := domain.ReactionTarget{PostID: q.PostID, CommentID: q.CommentID}
+	   summary, err := rc.usecase.GetReactionSummary(c, c.GetString("user_id"), target)
+	   if err != nil {
+			   c.JSON(reactionStatus(err), gin.H{"error": err.Error()})
+			   return
+	   }
+	   c.JSON(http.StatusOK, dtodlv.FromDomainReactionSummary(summary))
+}
+
+// GetTypes lists the reactions users can pick from
+func (rc *ReactionController) GetTypes(c *gin.Context) {
+	   c.JSON(http.StatusOK, gin.H{"data": dtodlv.FromDomainReactionOptions(rc.usecase.ReactionTypes())})
 }
 
 func (rc *ReactionController) RemoveReaction(c *gin.Context) {
 	   id := c.Param("id")
-	   if err := rc.usecase.RemoveReaction(c, id); err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	   if err := rc.usecase.RemoveReaction(c, c.GetString("user_id"), id); err != nil {
+			   c.JSON(reactionStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
 	   c.JSON(http.StatusOK, gin.H{"message": "Reaction removed"})
@@ -22,7 +22,7 @@ type fakeReactionUC struct {
 }
 
 func (f *fakeReactionUC) AddReaction(_ context.Context, _ *domain.Reaction) error { return f.err }
-func (f *fakeReactionUC) RemoveReaction(_ context.Context, _ string) error        { return f.err }
+func (f *fakeReactionUC) RemoveReaction(_ context.Context, _, _ string) error     { return f.err }
 func (f *fakeReactionUC) GetReactionsByPost(_ context.Context, _ string) ([]*domain.Reaction, error) {
 	return f.list, f.err
 }
@@ -32,6 +32,18 @@ func (f *fakeReactionUC) GetReactionsByUser(_ context.Context, _ string) ([]*dom
 func (f *fakeReactionUC) CountReactions(_ context.Context, _ string, _ domain.ReactionType) (int, error) {
 	return 1, f.err
 }
+func (f *fakeReactionUC) ToggleReaction(_ context.Context, r *domain.Reaction) (bool, error) {
+	return r.Type == "like", f.err
+}
+func (f *fakeReactionUC) GetReactionSummary(_ context.Context, userID string, target domain.ReactionTarget) (*domain.ReactionSummary, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &domain.ReactionSummary{Counts: map[domain.ReactionType]int{"like": 2, "love": 0}, Mine: []domain.ReactionType{"like"}}, nil
+}
+func (f *fakeReactionUC) ReactionTypes() []domain.ReactionOption {
+	return []domain.ReactionOption{{Type: "like", Emoji: "👍"}}
+}
 
 func setupReactionRouter(uc domain.IReactionUsecase) *gin.Engine {
 	gin.SetMode(gin.TestMode)
@@ -42,6 +54,9 @@ func setupReactionRouter(uc domain.IReactionUsecase) *gin.Engine {
 	r.GET("/posts/:post_id/reactions", h.GetReactionsByPost)
 	r.GET("/users/:user_id/reactions", h.GetReactionsByUser)
 	r.GET("/posts/:post_id/reactions/:type/count", h.CountReactions)
+	r.POST("/reactions/toggle", h.ToggleReaction)
+	r.GET("/reactions/summary", h.GetSummary)
+	r.GET("/reactions/types", h.GetTypes)
 	return r
 }
 
@@ -50,7 +65,7 @@ func TestReactionController_HappyPaths(t *testing.T) {
 	r := setupReactionRouter(uc)
 
 	// Add
-	body, _ := json.Marshal(dtodlv.ReactionRequest{PostID: "p1", UserID: "u1", Type: "like"})
+	body, _ := json.Marshal(dtodlv.ReactionRequest{PostID: "p1", Type: "like"})
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodPost, "/reactions", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -81,3 +96,42 @@ func TestReactionController_HappyPaths(t *testing.T) {
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestReactionController_ToggleSummaryTypes(t *testing.T) {
+	r := setupReactionRouter(&fakeReactionUC{})
+
+	body, _ := json.Marshal(dtodlv.ReactionRequest{PostID: "p1", Type: "like"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/reactions/toggle", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"added":true,"type":"like"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/reactions/summary?post_id=p1", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"counts":{"like":2,"love":0},"mine":["like"]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/reactions/summary", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/reactions/types", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"data":[{"type":"like","emoji":"👍"}]}`, w.Body.String())
+}
+
+func TestReactionController_InvalidType(t *testing.T) {
+	r := setupReactionRouter(&fakeReactionUC{err: domain.ErrInvalidReactionType})
+	body, _ := json.Marshal(dtodlv.ReactionRequest{PostID: "p1", Type: "nope"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/reactions/toggle", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"net/http"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RelatedController struct {
+	usecase domain.IRelatedUsecase
+}
+
+func NewRelatedController(usecase domain.IRelatedUsecase) *RelatedController {
+	return &RelatedController{usecase: usecase}
+}
+
+type relatedQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+// GetRelatedArticles suggests what to read next. Signed-in readers do not get
+// their own articles back.
+func (rc *RelatedController) GetRelatedArticles(ctx *gin.Context) {
+	var q relatedQuery
+	if err := ctx.ShouldBindQuery(&q); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	related, err := rc.usecase.GetRelatedArticles(ctx.Request.Context(), ctx.Param("id"), ctx.GetString("user_id"), q.Limit)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidArticlePayload:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case domain.ErrArticleNotFound, domain.ErrUnauthorized:
+			// Unpublished articles are reported as missing to everyone but their author
+			ctx.JSON(http.StatusNotFound, gin.H{"error": domain.ErrArticleNotFound.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	resp := make([]RelatedArticleResponse, 0, len(related))
+	for _, r := range related {
+		var dto RelatedArticleResponse
+		dto.FromDomain(r)
+		resp = append(resp, dto)
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": resp})
+}
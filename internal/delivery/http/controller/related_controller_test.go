@@ -0,0 +1,80 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type relatedUCStub struct {
+	gotID, gotUser string
+	gotLimit       int
+	related        []domain.RelatedArticle
+	err            error
+}
+
+func (s *relatedUCStub) GetRelatedArticles(ctx context.Context, articleID, userID string, limit int) ([]domain.RelatedArticle, error) {
+	s.gotID, s.gotUser, s.gotLimit = articleID, userID, limit
+	return s.related, s.err
+}
+
+func relatedRouter(uc domain.IRelatedUsecase, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if userID != "" {
+		r.Use(func(c *gin.Context) { c.Set("user_id", userID); c.Next() })
+	}
+	r.GET("/articles/:id/related", controller.NewRelatedController(uc).GetRelatedArticles)
+	return r
+}
+
+func TestGetRelatedArticles_Response(t *testing.T) {
+	uc := &relatedUCStub{related: []domain.RelatedArticle{{
+		Article:    domain.Article{ID: "a2", Title: "Next", Status: domain.StatusPublished},
+		Score:      6,
+		SharedTags: []string{"go"},
+		Reasons:    []string{domain.RelatedReasonTags},
+	}}}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/articles/a1/related?limit=3", nil)
+	relatedRouter(uc, "u1").ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "a1", uc.gotID)
+	require.Equal(t, "u1", uc.gotUser)
+	require.Equal(t, 3, uc.gotLimit)
+
+	var body struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Data, 1)
+	require.Equal(t, "a2", body.Data[0]["id"])
+	require.Equal(t, []interface{}{"go"}, body.Data[0]["shared_tags"])
+	require.Equal(t, []interface{}{"tags"}, body.Data[0]["reasons"])
+}
+
+func TestGetRelatedArticles_ErrorMapping(t *testing.T) {
+	cases := []struct {
+		url  string
+		err  error
+		code int
+	}{
+		{"/articles/a1/related?limit=21", nil, http.StatusBadRequest},
+		{"/articles/a1/related", domain.ErrArticleNotFound, http.StatusNotFound},
+		{"/articles/a1/related", domain.ErrUnauthorized, http.StatusNotFound},
+		{"/articles/a1/related", domain.ErrInternalServer, http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, tc.url, nil)
+		relatedRouter(&relatedUCStub{err: tc.err}, "").ServeHTTP(w, req)
+		require.Equal(t, tc.code, w.Code, tc.url)
+	}
+}
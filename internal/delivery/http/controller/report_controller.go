@@ -16,6 +16,21 @@ func NewReportController(usecase domain.IReportUsecase) *ReportController {
 	return &ReportController{usecase: usecase}
 }
 
+// reportStatus maps report errors to HTTP codes.
+func reportStatus(err error) int {
+	switch err {
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrForbidden:
+		return http.StatusForbidden
+	case domain.ErrReportNotFound:
+		return http.StatusNotFound
+	case domain.ErrReportAlreadyResolved:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
 func (rc *ReportController) CreateReport(c *gin.Context) {
 	   var req dtodlv.ReportRequest
 	   if err := c.ShouldBindJSON(&req); err != nil {
@@ -23,13 +38,13 @@ func (rc *ReportController) CreateReport(c *gin.Context) {
 			   return
 	   }
 	   report := &domain.Report{
-			   ReporterID: req.ReporterID,
+			   ReporterID: c.GetString("user_id"),
 			   TargetID:   req.TargetID,
 			   TargetType: req.TargetType,
 			   Reason:     req.Reason,
 	   }
 	   if err := rc.usecase.CreateReport(c, report); err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			   c.JSON(reportStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
 	   c.JSON(http.StatusCreated, gin.H{"message": "Report created"})
@@ -37,9 +52,9 @@ func (rc *ReportController) CreateReport(c *gin.Context) {
 
 func (rc *ReportController) GetReports(c *gin.Context) {
 	   // For simplicity, no filter from query params
-	   reports, err := rc.usecase.GetReports(c, map[string]interface{}{})
+	   reports, err := rc.usecase.GetReports(c, domain.UserRole(c.GetString("role")), map[string]interface{}{})
 	   if err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			   c.JSON(reportStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
 	   c.JSON(http.StatusOK, reports)
@@ -52,8 +67,8 @@ func (rc *ReportController) UpdateReportStatus(c *gin.Context) {
 			   c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 			   return
 	   }
-	   if err := rc.usecase.UpdateReportStatus(c, id, domain.ReportStatus(req.Status)); err != nil {
-			   c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	   if err := rc.usecase.UpdateReportStatus(c, domain.UserRole(c.GetString("role")), id, domain.ReportStatus(req.Status)); err != nil {
+			   c.JSON(reportStatus(err), gin.H{"error": err.Error()})
 			   return
 	   }
 	   c.JSON(http.StatusOK, gin.H{"message": "Report status updated"})
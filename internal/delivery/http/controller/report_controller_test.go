@@ -22,10 +22,10 @@ type fakeReportUC struct {
 }
 
 func (f *fakeReportUC) CreateReport(_ context.Context, _ *domain.Report) error { return f.err }
-func (f *fakeReportUC) GetReports(_ context.Context, _ map[string]interface{}) ([]*domain.Report, error) {
+func (f *fakeReportUC) GetReports(_ context.Context, _ domain.UserRole, _ map[string]interface{}) ([]*domain.Report, error) {
 	return f.list, f.err
 }
-func (f *fakeReportUC) UpdateReportStatus(_ context.Context, _ string, _ domain.ReportStatus) error {
+func (f *fakeReportUC) UpdateReportStatus(_ context.Context, _ domain.UserRole, _ string, _ domain.ReportStatus) error {
 	return f.err
 }
 
@@ -44,7 +44,7 @@ func TestReportController_HappyPaths(t *testing.T) {
 	r := setupReportRouter(uc)
 
 	// Create
-	body, _ := json.Marshal(dtodlv.ReportRequest{ReporterID: "u1", TargetID: "p1", TargetType: "post", Reason: "spam"})
+	body, _ := json.Marshal(dtodlv.ReportRequest{TargetID: "p1", TargetType: "post", Reason: "spam"})
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodPost, "/reports", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	dtodlv "write_base/internal/delivery/http/controller/dto"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SuggestController struct {
+	usecase domain.ISuggestUsecase
+}
+
+func NewSuggestController(usecase domain.ISuggestUsecase) *SuggestController {
+	return &SuggestController{usecase: usecase}
+}
+
+// Suggest serves search-box autocomplete for article titles, tags and usernames.
+func (sc *SuggestController) Suggest(c *gin.Context) {
+	prefix := strings.TrimSpace(c.Query("q"))
+	if prefix == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "search query is required"})
+		return
+	}
+	var req dtodlv.SuggestQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	suggestions, err := sc.usecase.Suggest(c.Request.Context(), prefix, req.ToDomain())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": dtodlv.FromDomainSuggestions(suggestions)})
+}
@@ -0,0 +1,59 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func suggestRouter(uc domain.ISuggestUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/search/suggest", controller.NewSuggestController(uc).Suggest)
+	return r
+}
+
+func TestSuggest_PassesLimitsAndShapesResponse(t *testing.T) {
+	var gotPrefix string
+	var gotLimits domain.SuggestLimits
+	uc := &mocks.SuggestUsecaseMock{
+		SuggestFn: func(ctx context.Context, prefix string, limits domain.SuggestLimits) (*domain.Suggestions, error) {
+			gotPrefix, gotLimits = prefix, limits
+			return &domain.Suggestions{
+				Articles: []domain.Suggestion{{Kind: domain.SuggestArticle, ID: "a1", Text: "Go tips", Slug: "go-tips"}},
+			}, nil
+		},
+	}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/search/suggest?q=go&tag_limit=0&user_limit=10", nil)
+	suggestRouter(uc).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "go", gotPrefix)
+	require.Equal(t, domain.SuggestLimits{Articles: domain.DefaultSuggestLimit, Tags: 0, Users: 10}, gotLimits)
+
+	var body struct {
+		Data map[string][]map[string]string `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, []map[string]string{{"id": "a1", "text": "Go tips", "slug": "go-tips"}}, body.Data["articles"])
+	require.NotNil(t, body.Data["tags"])
+	require.Empty(t, body.Data["tags"])
+}
+
+func TestSuggest_BadRequests(t *testing.T) {
+	r := suggestRouter(&mocks.SuggestUsecaseMock{})
+	for _, url := range []string{"/search/suggest", "/search/suggest?q=%20", "/search/suggest?q=go&article_limit=11", "/search/suggest?q=go&user_limit=-1"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+}
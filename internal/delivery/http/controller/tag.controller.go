@@ -158,4 +158,77 @@ func (h *TagHandler) RejectTag(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{"data": tag})
-}
\ No newline at end of file
+}
+// tagAdminStatus maps synonym, hierarchy and merge errors to HTTP codes.
+func tagAdminStatus(err error) int {
+	switch err {
+	case domain.ErrTagNotFound:
+		return http.StatusNotFound
+	case domain.ErrInvalidTagName, domain.ErrTagMergeSelf, domain.ErrTagMergeTarget:
+		return http.StatusBadRequest
+	case domain.ErrSynonymInUse, domain.ErrTagHierarchyCycle:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// AddSynonym makes another spelling resolve to the tag (admin only)
+func (h *TagHandler) AddSynonym(c *gin.Context) {
+	var req struct {
+		Synonym string `json:"synonym" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tag, err := h.tagUsecase.AddSynonym(c.Request.Context(), c.Param("id"), req.Synonym)
+	if err != nil {
+		c.JSON(tagAdminStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tag})
+}
+
+// RemoveSynonym drops a spelling from the tag (admin only)
+func (h *TagHandler) RemoveSynonym(c *gin.Context) {
+	tag, err := h.tagUsecase.RemoveSynonym(c.Request.Context(), c.Param("id"), c.Param("synonym"))
+	if err != nil {
+		c.JSON(tagAdminStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tag})
+}
+
+// SetParent nests the tag under another one; an empty parent_id clears it (admin only)
+func (h *TagHandler) SetParent(c *gin.Context) {
+	var req struct {
+		ParentID string `json:"parent_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tag, err := h.tagUsecase.SetParent(c.Request.Context(), c.Param("id"), req.ParentID)
+	if err != nil {
+		c.JSON(tagAdminStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tag})
+}
+
+// MergeTags folds the tag into target_id and rewrites affected articles (admin only)
+func (h *TagHandler) MergeTags(c *gin.Context) {
+	var req struct {
+		TargetID string `json:"target_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	result, err := h.tagUsecase.MergeTags(c.Request.Context(), c.Param("id"), req.TargetID)
+	if err != nil {
+		c.JSON(tagAdminStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": result.Tag, "articles_updated": len(result.ArticleIDs)})
+}
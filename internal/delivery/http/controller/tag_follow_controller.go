@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagFollowController struct {
+	usecase domain.ITagFollowUsecase
+}
+
+func NewTagFollowController(usecase domain.ITagFollowUsecase) *TagFollowController {
+	return &TagFollowController{usecase: usecase}
+}
+
+type FollowedTagResponse struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Followers  int       `json:"followers"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+func tagFollowStatus(err error) int {
+	switch err {
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrTagNotFound, domain.ErrTagFollowNotFound:
+		return http.StatusNotFound
+	case domain.ErrTagNotApproved:
+		return http.StatusBadRequest
+	case domain.ErrAlreadyFollowingTag:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (tc *TagFollowController) FollowTag(c *gin.Context) {
+	if err := tc.usecase.FollowTag(c.Request.Context(), c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(tagFollowStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Followed tag"})
+}
+
+func (tc *TagFollowController) UnfollowTag(c *gin.Context) {
+	if err := tc.usecase.UnfollowTag(c.Request.Context(), c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(tagFollowStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Unfollowed tag"})
+}
+
+// ListMyTags lists the tags the signed-in user follows with their follower counts.
+func (tc *TagFollowController) ListMyTags(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	tags, err := tc.usecase.ListFollowedTags(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(tagFollowStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]FollowedTagResponse, 0, len(tags))
+	for _, t := range tags {
+		resp = append(resp, FollowedTagResponse{ID: t.TagID, Name: t.Name, Followers: t.Followers, FollowedAt: t.FollowedAt})
+	}
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+func (tc *TagFollowController) GetFollowerCount(c *gin.Context) {
+	tagID := c.Param("id")
+	n, err := tc.usecase.FollowerCount(c.Request.Context(), tagID)
+	if err != nil {
+		c.JSON(tagFollowStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"tag_id": tagID, "followers": n}})
+}
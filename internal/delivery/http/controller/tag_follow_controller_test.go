@@ -0,0 +1,88 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type tagFollowUCStub struct {
+	err  error
+	tags []domain.FollowedTag
+}
+
+func (s *tagFollowUCStub) FollowTag(ctx context.Context, userID, tagID string) error   { return s.err }
+func (s *tagFollowUCStub) UnfollowTag(ctx context.Context, userID, tagID string) error { return s.err }
+func (s *tagFollowUCStub) ListFollowedTags(ctx context.Context, userID string) ([]domain.FollowedTag, error) {
+	return s.tags, s.err
+}
+func (s *tagFollowUCStub) FollowerCount(ctx context.Context, tagID string) (int, error) {
+	return 7, s.err
+}
+func (s *tagFollowUCStub) FollowedTagNames(ctx context.Context, userID string) ([]string, error) {
+	return nil, s.err
+}
+func (s *tagFollowUCStub) FollowersOfTags(ctx context.Context, tagNames []string) ([]string, error) {
+	return nil, s.err
+}
+
+func tagFollowRouter(uc domain.ITagFollowUsecase, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if userID != "" {
+		r.Use(func(c *gin.Context) { c.Set("user_id", userID); c.Next() })
+	}
+	h := controller.NewTagFollowController(uc)
+	r.POST("/tags/:id/follow", h.FollowTag)
+	r.DELETE("/tags/:id/follow", h.UnfollowTag)
+	r.GET("/tags/:id/followers", h.GetFollowerCount)
+	r.GET("/me/tags", h.ListMyTags)
+	return r
+}
+
+func serve(r *gin.Engine, method, url string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, url, nil)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestTagFollowController_HappyPaths(t *testing.T) {
+	r := tagFollowRouter(&tagFollowUCStub{tags: []domain.FollowedTag{{TagID: "t1", Name: "go", Followers: 3}}}, "u1")
+	require.Equal(t, http.StatusCreated, serve(r, http.MethodPost, "/tags/t1/follow").Code)
+	require.Equal(t, http.StatusOK, serve(r, http.MethodDelete, "/tags/t1/follow").Code)
+
+	w := serve(r, http.MethodGet, "/me/tags")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Data []controller.FollowedTagResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, "go", list.Data[0].Name)
+	require.Equal(t, 3, list.Data[0].Followers)
+
+	w = serve(r, http.MethodGet, "/tags/t1/followers")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"data":{"tag_id":"t1","followers":7}}`, w.Body.String())
+}
+
+func TestTagFollowController_ErrorMapping(t *testing.T) {
+	cases := map[error]int{
+		domain.ErrTagNotFound:         http.StatusNotFound,
+		domain.ErrTagNotApproved:      http.StatusBadRequest,
+		domain.ErrAlreadyFollowingTag: http.StatusConflict,
+		domain.ErrInternalServer:      http.StatusInternalServerError,
+	}
+	for err, code := range cases {
+		r := tagFollowRouter(&tagFollowUCStub{err: err}, "u1")
+		require.Equal(t, code, serve(r, http.MethodPost, "/tags/t1/follow").Code, err.Error())
+	}
+	require.Equal(t, http.StatusUnauthorized, serve(tagFollowRouter(&tagFollowUCStub{}, ""), http.MethodGet, "/me/tags").Code)
+}
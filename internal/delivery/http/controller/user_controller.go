@@ -271,6 +271,10 @@ func (uc *UserController) UpdateMyProfile(c *gin.Context) {
 
 	err := uc.userUsercase.UpdateProfile(ctx, updateInfo)
 	if err != nil {
+		if err == domain.ErrContentBlocked {
+			c.IndentedJSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		}
 		c.IndentedJSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
 		return
 	}
@@ -104,6 +104,7 @@ type UserResponse struct {
 	ProfileImage string    `json:"profile_image,omitempty"`
 	Role         string    `json:"role"`
 	CreatedAt    time.Time `json:"created_at"`
+	FollowedTags []string  `json:"followed_tags,omitempty"`
 }
 
 func ToUserResponse(user *domain.User) *UserResponse {
@@ -118,6 +119,7 @@ func ToUserResponse(user *domain.User) *UserResponse {
 		ProfileImage: user.ProfileImage,
 		Role:         string(user.Role),
 		CreatedAt:    user.CreatedAt,
+		FollowedTags: user.FollowedTags,
 	}
 }
 
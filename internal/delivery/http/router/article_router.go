@@ -2,11 +2,12 @@ package router
 
 import (
 	"write_base/internal/delivery/http/controller"
+	"write_base/internal/infrastructure"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterArticleRouter(r *gin.Engine, h  *controller.Handler)  {
+func RegisterArticleRouter(r *gin.Engine, h  *controller.Handler, authMiddleware *infrastructure.Middleware)  {
 	userAuthGroup := r.Group("/")
 	{
 		userAuthGroup.POST("/articles/new",h.CreateArticle)
@@ -23,7 +24,7 @@ func RegisterArticleRouter(r *gin.Engine, h  *controller.Handler)  {
 		// Statistics
 		userAuthGroup.GET("/articles/:id/stats", h.GetArticleStats)
 		userAuthGroup.GET("/articles/stats/all", h.GetAllArticleStats)
-		userAuthGroup.GET(":slug",h.GetArticleBySlug)
+		userAuthGroup.GET(":slug", authMiddleware.OptionalAuthmiddleware(), h.GetArticleBySlug)
 		// List Articles
 		userAuthGroup.GET("/authors/:author_id/articles", h.ListArticlesByAuthor)
 		userAuthGroup.GET("/articles/trending", h.GetTrendingArticles)
@@ -42,10 +43,14 @@ func RegisterArticleRouter(r *gin.Engine, h  *controller.Handler)  {
 		userAuthGroup.DELETE("/me/trash", h.EmptyTrash)
 		userAuthGroup.DELETE("/articles/trash/:id", h.DeleteFromTrash)
 
-		userAuthGroup.POST("articles/:id/clap", h.AddClap)
+		userAuthGroup.POST("articles/:id/clap", authMiddleware.Authmiddleware(), h.AddClap)
+		userAuthGroup.DELETE("articles/:id/clap", authMiddleware.Authmiddleware(), h.RemoveClaps)
+		userAuthGroup.GET("articles/:id/clap/me", authMiddleware.Authmiddleware(), h.GetMyClaps)
 
 		userAuthGroup.POST("/generateslug", h.GenerateSlug)
 		userAuthGroup.POST("/articles/generatecontent", h.GenerateContent)
+		userAuthGroup.POST("/articles/:id/ai/suggest-tags", authMiddleware.Authmiddleware(), h.SuggestTags)
+		userAuthGroup.POST("/articles/:id/ai/seo", authMiddleware.Authmiddleware(), h.GenerateSEO)
 	}
 	adminGroup := r.Group("/admin")
 	{
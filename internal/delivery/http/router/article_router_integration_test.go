@@ -8,6 +8,7 @@ import (
 
 	"write_base/internal/delivery/http/controller"
 	"write_base/internal/domain"
+	"write_base/internal/infrastructure"
 	"write_base/internal/mocks"
 
 	"github.com/gin-gonic/gin"
@@ -24,10 +25,119 @@ func TestRegisterArticleRouter_WiresRoutes(t *testing.T) {
 	h := controller.NewArticleHandler(uc)
 	// Add auth context so controller passes auth checks
 	r.Use(func(c *gin.Context) { c.Set("user_id", "u1"); c.Set("user_role", string(domain.RoleAdmin)); c.Next() })
-	RegisterArticleRouter(r, h)
+	RegisterArticleRouter(r, h, infrastructure.NewMiddleware(roleTokens{}))
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest(http.MethodGet, "/articles/popular", nil)
 	r.ServeHTTP(w, req)
 	require.NotEqual(t, http.StatusNotFound, w.Code)
 }
+
+func TestRegisterArticleRouter_ClapRoutesRequireAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var users []string
+	uc := &mocks.ArticleUsecaseMock{
+		AddClapFn: func(ctx context.Context, userID, articleID string) (domain.ArticleStats, error) {
+			users = append(users, userID)
+			return domain.ArticleStats{}, nil
+		},
+		RemoveClapsFn: func(ctx context.Context, userID, articleID string) (domain.ArticleStats, error) {
+			users = append(users, userID)
+			return domain.ArticleStats{}, nil
+		},
+		GetUserClapsFn: func(ctx context.Context, userID, articleID string) (domain.UserClapState, error) {
+			users = append(users, userID)
+			return domain.UserClapState{Max: domain.MaxClapsPerUser}, nil
+		},
+	}
+	RegisterArticleRouter(r, controller.NewArticleHandler(uc), infrastructure.NewMiddleware(roleTokens{}))
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPost, "/articles/a1/clap"},
+		{http.MethodDelete, "/articles/a1/clap"},
+		{http.MethodGet, "/articles/a1/clap/me"},
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(route.method, route.path, nil)
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnauthorized, w.Code, route.method+" "+route.path)
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest(route.method, route.path, nil)
+		req.Header.Set("Authorization", "Bearer "+string(domain.RoleUser))
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, route.method+" "+route.path)
+	}
+	require.Equal(t, []string{"u1", "u1", "u1"}, users)
+}
+
+func TestRegisterArticleRouter_SlugIdentifiesSignedInReader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var readers []domain.ViewReader
+	uc := &mocks.ArticleUsecaseMock{GetArticleBySlugFn: func(ctx context.Context, slug string, reader domain.ViewReader) (*domain.Article, error) {
+		readers = append(readers, reader)
+		return &domain.Article{ID: "a1", Slug: slug}, nil
+	}}
+	RegisterArticleRouter(r, controller.NewArticleHandler(uc), infrastructure.NewMiddleware(roleTokens{}))
+
+	for _, auth := range []string{"", "Bearer " + string(domain.RoleUser)} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/some-article", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, auth)
+	}
+	require.Len(t, readers, 2)
+	require.Empty(t, readers[0].UserID)
+	require.Equal(t, "u1", readers[1].UserID)
+}
+
+func TestRegisterArticleRouter_SuggestTagsRequiresAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var users []string
+	uc := &mocks.ArticleUsecaseMock{SuggestTagsForArticleFn: func(ctx context.Context, articleID, userID string) (*domain.TagSuggestions, error) {
+		users = append(users, userID)
+		return &domain.TagSuggestions{}, nil
+	}}
+	RegisterArticleRouter(r, controller.NewArticleHandler(uc), infrastructure.NewMiddleware(roleTokens{}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/articles/a1/ai/suggest-tags", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/articles/a1/ai/suggest-tags", nil)
+	req.Header.Set("Authorization", "Bearer "+string(domain.RoleUser))
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"u1"}, users)
+}
+
+func TestRegisterArticleRouter_GenerateSEORequiresAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var users []string
+	uc := &mocks.ArticleUsecaseMock{GenerateSEOForArticleFn: func(ctx context.Context, articleID, userID string) (*domain.ArticleSEO, error) {
+		users = append(users, userID)
+		return &domain.ArticleSEO{}, nil
+	}}
+	RegisterArticleRouter(r, controller.NewArticleHandler(uc), infrastructure.NewMiddleware(roleTokens{}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/articles/a1/ai/seo", nil)
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/articles/a1/ai/seo", nil)
+	req.Header.Set("Authorization", "Bearer "+string(domain.RoleUser))
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"u1"}, users)
+}
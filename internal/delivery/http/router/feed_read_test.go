@@ -0,0 +1,93 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+	"write_base/internal/infrastructure"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// readerRecords keeps the per-reader records the way the readers collection
+// does: one entry per article and reader key.
+type readerRecords map[string]bool
+
+func (r readerRecords) TrackRead(ctx context.Context, articleID, readerKey string, window time.Duration) (bool, bool, error) {
+	id := articleID + "|" + readerKey
+	first := !r[id]
+	r[id] = true
+	return first, first, nil
+}
+func (r readerRecords) Create(ctx context.Context, view *domain.View) error { return nil }
+
+// A signed-in reader opening an article through GET /:slug must be recognised
+// by the feed as having read it.
+func TestArticleRead_DropsArticleFromFeed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Now()
+	published := func(id string) domain.Article {
+		return domain.Article{ID: id, Slug: id, AuthorID: "writer", Status: domain.StatusPublished, Timestamps: domain.ArticleTimes{PublishedAt: &now}}
+	}
+	records := readerRecords{}
+	articles := &mocks.ArticleRepositoryMock{GetBySlugFn: func(ctx context.Context, slug string) (*domain.Article, error) {
+		a := published(slug)
+		return &a, nil
+	}}
+	articleUC := &usecase.ArticleUsecase{Repo: articles, ViewUsecase: usecase.NewViewUsecase(records, articles, &mocks.UtilsMock{}, time.Minute)}
+	feed := &mocks.FeedRepositoryMock{
+		TopTrendingFn: func(ctx context.Context, since time.Time, limit int) ([]domain.Article, error) {
+			return []domain.Article{published("read-me"), published("unread")}, nil
+		},
+		ReadArticleIDsFn: func(ctx context.Context, userID string, ids []string) (map[string]bool, error) {
+			read := map[string]bool{}
+			for _, id := range ids {
+				read[id] = records[id+"|"+domain.ViewReader{UserID: userID}.Key()]
+			}
+			return read, nil
+		},
+	}
+	feedUC := usecase.NewFeedUsecase(feed, &mocks.FollowRepositoryMock{}, &mocks.TagFollowRepositoryMock{})
+
+	r := gin.New()
+	auth := infrastructure.NewMiddleware(roleTokens{})
+	RegisterArticleRouter(r, controller.NewArticleHandler(articleUC), auth)
+	RegisterFeedRoutes(r, controller.NewFeedController(feedUC), auth)
+
+	feedIDs := func() []string {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/feed", nil)
+		req.Header.Set("Authorization", "Bearer "+string(domain.RoleUser))
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		var ids []string
+		for _, item := range body.Data {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+	require.ElementsMatch(t, []string{"read-me", "unread"}, feedIDs())
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/read-me", nil)
+	req.Header.Set("Authorization", "Bearer "+string(domain.RoleUser))
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	require.Equal(t, []string{"unread"}, feedIDs())
+}
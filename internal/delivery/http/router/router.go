@@ -7,9 +7,21 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// NewEngine builds the HTTP engine. Client IPs are read from X-Forwarded-For
+// only when the request comes from one of trustedProxies; with none, the
+// connection's address is used.
+func NewEngine(trustedProxies []string) (*gin.Engine, error) {
+    r := gin.Default()
+    if err := r.SetTrustedProxies(trustedProxies); err != nil {
+        return nil, err
+    }
+    return r, nil
+}
+
 // Comment Routes
-func RegisterCommentRoutes(r *gin.Engine, commentController *controller.CommentController) {
+func RegisterCommentRoutes(r *gin.Engine, commentController *controller.CommentController, authMiddleware *infrastructure.Middleware) {
     comments := r.Group("/comments")
+    comments.Use(authMiddleware.Authmiddleware())
     {
         comments.POST("", commentController.Create)
         comments.PUT("/:id", commentController.Update)
@@ -18,14 +30,25 @@ func RegisterCommentRoutes(r *gin.Engine, commentController *controller.CommentC
         comments.GET("/post/:post_id", commentController.GetByPostID)
         comments.GET("/user/:user_id", commentController.GetByUserID)
         comments.GET("/replies/:parent_id", commentController.GetReplies)
+        comments.GET("/post/:post_id/tree", commentController.GetTree)
+        comments.GET("/:id/thread", commentController.GetThread)
+        comments.GET("/:id/history", commentController.GetHistory)
+        comments.PUT("/:id/pin", commentController.Pin)
+        comments.DELETE("/:id/pin", commentController.Unpin)
+        comments.PUT("/:id/hide", commentController.Hide)
+        comments.DELETE("/:id/hide", commentController.Unhide)
     }
 }
 
 // Reaction Routes
-func RegisterReactionRoutes(r *gin.Engine, reactionController *controller.ReactionController) {
+func RegisterReactionRoutes(r *gin.Engine, reactionController *controller.ReactionController, authMiddleware *infrastructure.Middleware) {
     reactions := r.Group("/reactions")
+    reactions.Use(authMiddleware.Authmiddleware())
     {
         reactions.POST("", reactionController.AddReaction)
+        reactions.POST("/toggle", reactionController.ToggleReaction)
+        reactions.GET("/types", reactionController.GetTypes)
+        reactions.GET("/summary", reactionController.GetSummary)
         reactions.DELETE(":id", reactionController.RemoveReaction)
         reactions.GET("/post/:post_id", reactionController.GetReactionsByPost)
         reactions.GET("/user/:user_id", reactionController.GetReactionsByUser)
@@ -34,8 +57,9 @@ func RegisterReactionRoutes(r *gin.Engine, reactionController *controller.Reacti
 }
 
 // Follow Routes
-func RegisterFollowRoutes(r *gin.Engine, followController *controller.FollowController) {
+func RegisterFollowRoutes(r *gin.Engine, followController *controller.FollowController, authMiddleware *infrastructure.Middleware) {
     follows := r.Group("/follows")
+    follows.Use(authMiddleware.Authmiddleware())
     {
         follows.POST("/follow", followController.FollowUser)
         follows.POST("/unfollow", followController.UnfollowUser)
@@ -45,9 +69,26 @@ func RegisterFollowRoutes(r *gin.Engine, followController *controller.FollowCont
     }
 }
 
+// Notification Routes
+func RegisterNotificationRoutes(r *gin.Engine, notificationController *controller.NotificationController, authMiddleware *infrastructure.Middleware) {
+    r.GET("/notifications/stream", authMiddleware.StreamAuthmiddleware(), notificationController.Stream)
+
+    notifications := r.Group("/notifications")
+    notifications.Use(authMiddleware.Authmiddleware())
+    {
+        notifications.GET("", notificationController.List)
+        notifications.GET("/unread-count", notificationController.UnreadCount)
+        notifications.PUT("/read-all", notificationController.MarkAllRead)
+        notifications.PUT("/:id/read", notificationController.MarkRead)
+        notifications.GET("/preferences", notificationController.GetPreferences)
+        notifications.PUT("/preferences", notificationController.UpdatePreferences)
+    }
+}
+
 // Report Routes
-func RegisterReportRoutes(r *gin.Engine, reportController *controller.ReportController) {
+func RegisterReportRoutes(r *gin.Engine, reportController *controller.ReportController, authMiddleware *infrastructure.Middleware) {
     reports := r.Group("/reports")
+    reports.Use(authMiddleware.Authmiddleware())
     {
         reports.POST("", reportController.CreateReport)
         reports.GET("", reportController.GetReports)
@@ -55,6 +96,21 @@ func RegisterReportRoutes(r *gin.Engine, reportController *controller.ReportCont
     }
 }
 
+// Search autocomplete (public, called on every keystroke)
+func RegisterSuggestRoutes(r *gin.Engine, suggestController *controller.SuggestController) {
+    r.GET("/search/suggest", suggestController.Suggest)
+}
+
+// Related articles ("read next"); signed-in readers get their own articles left out
+func RegisterRelatedRoutes(r *gin.Engine, relatedController *controller.RelatedController, authMiddleware *infrastructure.Middleware) {
+    r.GET("/articles/:id/related", authMiddleware.OptionalAuthmiddleware(), relatedController.GetRelatedArticles)
+}
+
+// Personalized home feed (signed-in readers)
+func RegisterFeedRoutes(r *gin.Engine, feedController *controller.FeedController, authMiddleware *infrastructure.Middleware) {
+    r.GET("/feed", authMiddleware.Authmiddleware(), feedController.GetFeed)
+}
+
 // AI Routes
 func RegisterAIRoutes(r *gin.Engine, aiController *controller.AIController) {
     ai := r.Group("/ai")
@@ -64,6 +120,41 @@ func RegisterAIRoutes(r *gin.Engine, aiController *controller.AIController) {
     }
 }
 
+// AI admin routes
+func RegisterAIAdminRoutes(r *gin.Engine, aiController *controller.AIController, authMiddleware *infrastructure.Middleware) {
+    admin := r.Group("/admin/ai")
+    admin.Use(authMiddleware.Authmiddleware(), infrastructure.RequireRole(domain.RoleAdmin, domain.RoleSuperAdmin))
+    {
+        admin.GET("/cache/stats", aiController.CacheStats)
+    }
+}
+
+// Search index rebuild (admin only)
+func RegisterSearchAdminRoutes(r *gin.Engine, h *controller.Handler, authMiddleware *infrastructure.Middleware) {
+    r.POST("/admin/search/reindex", authMiddleware.Authmiddleware(), infrastructure.RequireRole(domain.RoleAdmin, domain.RoleSuperAdmin), h.AdminRebuildSearchIndex)
+}
+
+// Moderation Routes (admin only)
+func RegisterModerationRoutes(r *gin.Engine, moderationController *controller.ModerationController, authMiddleware *infrastructure.Middleware) {
+    moderation := r.Group("/admin/moderation")
+    moderation.Use(authMiddleware.Authmiddleware(), infrastructure.RequireRole(domain.RoleAdmin, domain.RoleSuperAdmin))
+    {
+        moderation.POST("/check", moderationController.Check)
+    }
+}
+
+// Prompt Template Routes (admin only)
+func RegisterPromptRoutes(r *gin.Engine, promptController *controller.PromptController, authMiddleware *infrastructure.Middleware) {
+    prompts := r.Group("/admin/prompts")
+    prompts.Use(authMiddleware.Authmiddleware(), infrastructure.RequireRole(domain.RoleAdmin, domain.RoleSuperAdmin))
+    {
+        prompts.GET("", promptController.ListTemplates)
+        prompts.GET("/:name", promptController.GetTemplate)
+        prompts.PUT("/:name", promptController.UpdateTemplate)
+        prompts.POST("/:name/preview", promptController.PreviewTemplate)
+    }
+}
+
 
 
 func UserRouter(r *gin.Engine, userController *controller.UserController, authMiddleware *infrastructure.Middleware){
@@ -1,10 +1,17 @@
 package router
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+	"write_base/internal/infrastructure"
+	"write_base/internal/mocks"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRegisterOtherRouters_NoPanic(t *testing.T) {
@@ -18,9 +25,110 @@ func TestRegisterOtherRouters_NoPanic(t *testing.T) {
 	report := controller.NewReportController(nil)
 	ai := controller.NewAIController(nil)
 
-	RegisterCommentRoutes(r, comment)
-	RegisterReactionRoutes(r, reaction)
-	RegisterFollowRoutes(r, follow)
-	RegisterReportRoutes(r, report)
+	auth := infrastructure.NewMiddleware(nil)
+
+	RegisterCommentRoutes(r, comment, auth)
+	RegisterReactionRoutes(r, reaction, auth)
+	RegisterFollowRoutes(r, follow, auth)
+	RegisterReportRoutes(r, report, auth)
 	RegisterAIRoutes(r, ai)
 }
+
+// roleTokens accepts any bearer token and reads it as the caller's role.
+type roleTokens struct{ domain.ITokenService }
+
+func (roleTokens) ValidateAccessToken(token string) (*domain.AuthClaims, error) {
+	return &domain.AuthClaims{UserID: "u1", Role: token}, nil
+}
+
+func TestRegisterSearchAdminRoutes_RequiresAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	calls := 0
+	uc := &mocks.ArticleUsecaseMock{AdminRebuildSearchIndexFn: func(ctx context.Context, uid, role string) (int, error) {
+		calls++
+		return 3, nil
+	}}
+	RegisterSearchAdminRoutes(r, controller.NewArticleHandler(uc), infrastructure.NewMiddleware(roleTokens{}))
+
+	for _, tc := range []struct {
+		auth string
+		want int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer " + string(domain.RoleUser), http.StatusForbidden},
+		{"Bearer " + string(domain.RoleAdmin), http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/search/reindex", nil)
+		if tc.auth != "" {
+			req.Header.Set("Authorization", tc.auth)
+		}
+		r.ServeHTTP(w, req)
+		require.Equal(t, tc.want, w.Code, tc.auth)
+	}
+	require.Equal(t, 1, calls)
+}
+
+func TestNewEngine_ForwardedForOnlyFromTrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	readerKeys := func(trusted []string) []string {
+		r, err := NewEngine(trusted)
+		require.NoError(t, err)
+		var keys []string
+		uc := &mocks.ArticleUsecaseMock{GetArticleBySlugFn: func(ctx context.Context, slug string, reader domain.ViewReader) (*domain.Article, error) {
+			keys = append(keys, reader.Key())
+			return &domain.Article{ID: "a1", Slug: slug}, nil
+		}}
+		RegisterArticleRouter(r, controller.NewArticleHandler(uc), infrastructure.NewMiddleware(roleTokens{}))
+		for _, forwarded := range []string{"198.51.100.1", "198.51.100.2"} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/some-article", nil)
+			req.RemoteAddr = "10.0.0.1:4000"
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+			req.Header.Set("X-Forwarded-For", forwarded)
+			r.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+		}
+		return keys
+	}
+
+	keys := readerKeys(nil)
+	require.Len(t, keys, 2)
+	require.Equal(t, keys[0], keys[1], "a spoofed X-Forwarded-For must not make a new reader")
+	require.Equal(t, domain.ViewReader{ClientIP: "10.0.0.1", UserAgent: "Mozilla/5.0"}.Key(), keys[0])
+
+	keys = readerKeys([]string{"10.0.0.0/8"})
+	require.Len(t, keys, 2)
+	require.Equal(t, domain.ViewReader{ClientIP: "198.51.100.1", UserAgent: "Mozilla/5.0"}.Key(), keys[0])
+	require.NotEqual(t, keys[0], keys[1])
+
+	_, err := NewEngine([]string{"not-an-ip"})
+	require.Error(t, err)
+}
+
+// relatedUsecase records which reader asked for recommendations.
+type relatedUsecase struct{ users []string }
+
+func (u *relatedUsecase) GetRelatedArticles(ctx context.Context, articleID, userID string, limit int) ([]domain.RelatedArticle, error) {
+	u.users = append(u.users, userID)
+	return nil, nil
+}
+
+func TestRegisterRelatedRoutes_IdentifiesSignedInReader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	uc := &relatedUsecase{}
+	RegisterRelatedRoutes(r, controller.NewRelatedController(uc), infrastructure.NewMiddleware(roleTokens{}))
+
+	for _, auth := range []string{"", "Bearer " + string(domain.RoleUser)} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/articles/a1/related", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, auth)
+	}
+	require.Equal(t, []string{"", "u1"}, uc.users)
+}
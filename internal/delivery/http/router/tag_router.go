@@ -2,6 +2,8 @@ package router
 
 import (
 	"write_base/internal/delivery/http/controller"
+	"write_base/internal/domain"
+	"write_base/internal/infrastructure"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,4 +17,28 @@ func RegisterTagRouter(r *gin.Engine, tagHandler *controller.TagHandler) {
 		tags.PATCH("/:id/reject", tagHandler.RejectTag)
 		tags.DELETE("/:id", tagHandler.DeleteTag)
 	}
-}
\ No newline at end of file
+}
+
+// Tag synonyms, hierarchy and merges (admin only)
+func RegisterTagAdminRoutes(r *gin.Engine, tagHandler *controller.TagHandler, authMiddleware *infrastructure.Middleware) {
+	admin := r.Group("/admin/tags")
+	admin.Use(authMiddleware.Authmiddleware(), infrastructure.RequireRole(domain.RoleAdmin, domain.RoleSuperAdmin))
+	{
+		admin.POST("/:id/synonyms", tagHandler.AddSynonym)
+		admin.DELETE("/:id/synonyms/:synonym", tagHandler.RemoveSynonym)
+		admin.PUT("/:id/parent", tagHandler.SetParent)
+		admin.POST("/:id/merge", tagHandler.MergeTags)
+	}
+}
+
+// Tag subscriptions; following needs a signed-in user, counts are public
+func RegisterTagFollowRoutes(r *gin.Engine, tagFollowController *controller.TagFollowController, authMiddleware *infrastructure.Middleware) {
+	r.GET("/tags/:id/followers", tagFollowController.GetFollowerCount)
+	auth := r.Group("/")
+	auth.Use(authMiddleware.Authmiddleware())
+	{
+		auth.POST("/tags/:id/follow", tagFollowController.FollowTag)
+		auth.DELETE("/tags/:id/follow", tagFollowController.UnfollowTag)
+		auth.GET("/me/tags", tagFollowController.ListMyTags)
+	}
+}
@@ -30,4 +30,22 @@ type GenerateContentResponse struct {
 type IAIUsecase interface {
 	GetSuggestions(ctx context.Context, req *SuggestionRequest) (*SuggestionResponse, error)
 	GenerateContent(ctx context.Context, req *GenerateContentRequest) (*GenerateContentResponse, error)
+	CacheStats(ctx context.Context) (*AICacheStats, error)
+}
+
+// AICacheStats reports how often cached AI responses were reused.
+type AICacheStats struct {
+	Backend string
+	Entries int64
+	Hits    int64
+	Misses  int64
+}
+
+// IAICache stores responses of deterministic AI requests. Keys are built by the
+// caller from provider, model, prompt version and normalized input; entry TTL
+// and size limits are owned by the implementation. Get records a hit or miss.
+type IAICache interface {
+	Get(ctx context.Context, key string) (string, bool, error)
+	Set(ctx context.Context, key, value string) error
+	Stats(ctx context.Context) (*AICacheStats, error)
 }
\ No newline at end of file
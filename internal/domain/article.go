@@ -34,9 +34,13 @@ type Article struct {
 	Excerpt       string
 	Language      string
 	Tags          []string
+	SEO           ArticleSEO
 	Status        ArticleStatus
 	Stats         ArticleStats
 	Timestamps    ArticleTimes
+	// KeepSEO marks an update that left SEO out, so the stored metadata stays;
+	// an update that sends empty SEO clears it. It is never stored.
+	KeepSEO bool
 }
 
 type ArticleStatus string
@@ -84,6 +88,8 @@ type HeadingContent struct {
 type ParagraphContent struct {
 	Text  string
 	Style string // e.g., "normal", "italic", "bold"
+	// Mentions are resolved from Text on save
+	Mentions []Mention
 }
 type ImageContent struct {
 	URL     string
@@ -106,8 +112,17 @@ type DividerContent struct {
 }
 
 type ArticleStats struct {
+	// ViewCount counts reads once per reader per view window
 	ViewCount int
-	ClapCount int
+	// UniqueViewCount counts distinct readers
+	UniqueViewCount int
+	ClapCount       int
+	// CommentCount counts comments that were not deleted, hidden ones included
+	CommentCount int
+	// ReactionCount counts reactions on the article itself, not on its comments
+	ReactionCount int
+	// TrendingScore is recomputed periodically from recent engagement
+	TrendingScore float64
 }
 
 // Timestamps
@@ -139,12 +154,14 @@ type ArticleFilter struct {
 	PublishedBefore *time.Time
 }
 
-// Pagination controls result slicing
+// Pagination controls result slicing. When Cursor is set the listing continues
+// after the cursor position and Page is ignored.
 type Pagination struct {
 	Page      int
 	PageSize  int
 	SortField string
 	SortOrder string
+	Cursor    string
 }
 
 func (p *Pagination) ValidatePagination() {
@@ -178,7 +195,7 @@ type IArticleUsecase interface {
 	RestoreArticle(ctx context.Context, userID, articleID string) error
 
 	GetArticleByID(ctx context.Context, articleID, userID string) (*Article, error)
-	GetArticleBySlug(ctx context.Context, slug string, clientIP string) (*Article, error)
+	GetArticleBySlug(ctx context.Context, slug string, reader ViewReader) (*Article, error)
 	GetArticleStats(ctx context.Context, articleID, userID string) (*ArticleStats, error)
 	GetAllArticleStats(ctx context.Context, userID string) ([]ArticleStats, int, error)
 
@@ -195,8 +212,10 @@ type IArticleUsecase interface {
 	FilterAuthorArticles(ctx context.Context, callerID, authorID string, filter ArticleFilter, pag Pagination) ([]Article, int, error)
 
 	FilterArticles(ctx context.Context, filter ArticleFilter, pag Pagination) ([]Article, int, error)
+	GetFilterFacets(ctx context.Context, filter ArticleFilter) (*ArticleFacets, error)
 
-	SearchArticles(ctx context.Context, userID, query string, pag Pagination) ([]Article, int, error)
+	SearchArticles(ctx context.Context, userID string, query SearchQuery, pag Pagination) ([]SearchHit, int, error)
+	GetSearchFacets(ctx context.Context, userID string, query SearchQuery) (*ArticleFacets, error)
 
 	ListArticlesByTags(ctx context.Context, userID string, tags []string, pag Pagination) ([]Article, int, error)
 
@@ -207,11 +226,16 @@ type IArticleUsecase interface {
 	AdminHardDeleteArticle(ctx context.Context, userID, userRole, articleID string) error
 
 	AdminUnpublishArticle(ctx context.Context, userID, userRole, articleID string) (*Article, error)
+	AdminRebuildSearchIndex(ctx context.Context, userID, userRole string) (int, error)
 
 	AddClap(ctx context.Context, userID, articleID string) (ArticleStats, error)
+	RemoveClaps(ctx context.Context, userID, articleID string) (ArticleStats, error)
+	GetUserClaps(ctx context.Context, userID, articleID string) (UserClapState, error)
 
 	GenerateContentForArticle(ctx context.Context, article *Article, instructions string) (*Article, error)
 	GenerateSlugForTitle(ctx context.Context, title string) (string, error)
+	SuggestTagsForArticle(ctx context.Context, articleID, userID string) (*TagSuggestions, error)
+	GenerateSEOForArticle(ctx context.Context, articleID, userID string) (*ArticleSEO, error)
 }
 
 // ===========================================================================//
@@ -226,6 +250,7 @@ type IArticleRepository interface {
 	Restore(ctx context.Context, articleID string) error
 
 	GetByID(ctx context.Context, articleID string) (*Article, error)
+	GetByIDs(ctx context.Context, articleIDs []string) ([]Article, error)
 	GetBySlug(ctx context.Context, slug string) (*Article, error)
 	GetStats(ctx context.Context, articleID string) (*ArticleStats, error)
 	GetAllArticleStats(ctx context.Context, userID string) ([]ArticleStats, int, error)
@@ -236,25 +261,34 @@ type IArticleRepository interface {
 	Unarchive(ctx context.Context, articleID string) error
 
 	ListByAuthor(ctx context.Context, authorID string, pag Pagination) ([]Article, int, error)
-	FindTrending(ctx context.Context, windowDays int, pag Pagination) ([]Article, int, error)
+	FindTrending(ctx context.Context, window time.Duration, pag Pagination) ([]Article, int, error)
 	FindNewArticles(ctx context.Context, pag Pagination) ([]Article, int, error)
 	FindPopularArticles(ctx context.Context, pag Pagination) ([]Article, int, error)
 
 	FilterAuthorArticles(ctx context.Context, authorID string, filter ArticleFilter, pag Pagination) ([]Article, int, error)
 
 	Filter(ctx context.Context, filter ArticleFilter, pag Pagination) ([]Article, int, error)
-	Search(ctx context.Context, query string, pag Pagination) ([]Article, int, error)
+	FilterFacets(ctx context.Context, filter ArticleFilter) (*ArticleFacets, error)
+	Search(ctx context.Context, query SearchQuery, pag Pagination) ([]SearchHit, int, error)
+	SearchFacets(ctx context.Context, query SearchQuery) (*ArticleFacets, error)
 
 	ListByTags(ctx context.Context, tags []string, pag Pagination) ([]Article, int, error)
+	// ReplaceTag renames a tag on every article and returns the IDs it touched
+	ReplaceTag(ctx context.Context, from, to string) ([]string, error)
 
 	EmptyTrash(ctx context.Context, userID string) error
 	DeleteFromTrash(ctx context.Context, articleID, userID string) error
 
 	AdminListAllArticles(ctx context.Context, pag Pagination) ([]Article, int, error)
+	ForEachPublished(ctx context.Context, batchSize int, fn func([]Article) error) error
 	HardDelete(ctx context.Context, articleID string) error
 
 	IncrementView(ctx context.Context, articleID string) error
-	UpdateClapCount(ctx context.Context, articleID string, count int) error
+	// IncrementStat atomically adds delta to one counter and returns the updated stats
+	IncrementStat(ctx context.Context, articleID string, counter StatCounter, delta int) (*ArticleStats, error)
+	// MarkClapMilestone records that the article reached milestone claps and
+	// reports whether this was the first time
+	MarkClapMilestone(ctx context.Context, articleID string, milestone int) (bool, error)
 }
 
 // ===========================================================================//
@@ -25,13 +25,31 @@ type Clap struct {
 // 	UpdatedAt time.Time `json:"updated_at"`
 // }
 
+// UserClapState is how many times a user clapped an article, out of Max.
+type UserClapState struct {
+	Count int
+	Max   int
+}
+
+func (s UserClapState) Remaining() int {
+	if s.Count >= s.Max {
+		return 0
+	}
+	return s.Max - s.Count
+}
+
 type ClapRepository interface {
-	Create(ctx context.Context, clap *Clap) error
-	Update(ctx context.Context, clap *Clap) error
+	// Increment atomically adds one clap by the user, creating their record on
+	// the first clap; it returns ErrClapLimitExceeded once they gave max
+	Increment(ctx context.Context, userID, articleID string, max int) (*Clap, error)
+	// Remove deletes the user's claps on the article and returns how many there were
+	Remove(ctx context.Context, userID, articleID string) (int, error)
 	GetByUserAndArticle(ctx context.Context, userID, articleID string) (*Clap, error)
 	GetArticleClapCount(ctx context.Context, articleID string) (int, error)
 }
 
 type ClapUsecase interface {
-	AddClap(ctx context.Context, userID, articleID string) (int, error) // Returns total clap count
+	AddClap(ctx context.Context, userID, articleID string) (int, error) // Returns the user's clap count
+	RemoveClaps(ctx context.Context, userID, articleID string) (int, error) // Returns how many claps were removed
+	GetUserClaps(ctx context.Context, userID, articleID string) (UserClapState, error)
 }
\ No newline at end of file
@@ -8,28 +8,89 @@ type Comment struct {
 	UserID    string
 	ParentID  *string // nil if top-level comment
 	Content   string
+	Mentions  []Mention // resolved from Content on save
 	CreatedAt int64
 	UpdatedAt int64
+	EditedAt  int64 // 0 if the content was never edited
+	Deleted   bool
+	// Hidden and Pinned are set by the author of the article
+	Hidden bool
+	Pinned bool
+}
+
+// CommentRevision is an earlier version of an edited comment.
+type CommentRevision struct {
+	Content   string
+	CreatedAt int64
+}
+
+// Placeholders shown in place of removed comments so their replies keep their context.
+const (
+	DeletedCommentContent = "[deleted]"
+	HiddenCommentContent  = "[hidden]"
+)
+
+// Redact replaces the content of deleted and hidden comments with a placeholder.
+func (c *Comment) Redact() {
+	switch {
+	case c.Deleted:
+		c.Content = DeletedCommentContent
+		c.UserID = ""
+		c.Mentions = nil
+	case c.Hidden:
+		c.Content = HiddenCommentContent
+		c.Mentions = nil
+	}
 }
 
 // ICommentRepository defines the interface for comment repository operations.
 type ICommentRepository interface {
 	Create(ctx context.Context, comment *Comment) error
+	// Update replaces the content and appends the previous version to the edit history
 	Update(ctx context.Context, comment *Comment) error
+	// Delete is a soft delete: the comment keeps its place in the thread but
+	// loses its content and history
 	Delete(ctx context.Context, commentID string) error
 	GetByID(ctx context.Context, commentID string) (*Comment, error)
 	GetByPostID(ctx context.Context, postID string) ([]*Comment, error)
 	GetByUserID(ctx context.Context, userID string) ([]*Comment, error)
 	GetReplies(ctx context.Context, parentID string) ([]*Comment, error)
+
+	// ListThread returns one page of a thread level with like counts filled in
+	ListThread(ctx context.Context, query CommentListQuery) ([]CommentNode, error)
+	// ListReplies returns the first perParent replies of each parent, in sort order
+	ListReplies(ctx context.Context, parentIDs []string, sort CommentSort, perParent int) (map[string][]CommentNode, error)
+	CountReplies(ctx context.Context, parentIDs []string) (map[string]int, error)
+
+	GetHistory(ctx context.Context, commentID string) ([]CommentRevision, error)
+	SetHidden(ctx context.Context, commentID string, hidden bool) error
+	// SetPinned pins commentID and unpins any other comment of the post; an
+	// empty commentID only unpins
+	SetPinned(ctx context.Context, postID, commentID string) error
+	// GetPinned returns the pinned comment of a post, or nil
+	GetPinned(ctx context.Context, postID string) (*CommentNode, error)
 }
 
 // usecase interface for comment operations
 type ICommentUsecase interface {
 	CreateComment(ctx context.Context, comment *Comment) error
-	UpdateComment(ctx context.Context, comment *Comment) error
-	DeleteComment(ctx context.Context, commentID string) error
+	// UpdateComment and DeleteComment are limited to the comment's author
+	UpdateComment(ctx context.Context, userID string, comment *Comment) error
+	DeleteComment(ctx context.Context, userID, commentID string) error
 	GetCommentByID(ctx context.Context, commentID string) (*Comment, error)
 	GetCommentsByPostID(ctx context.Context, postID string) ([]*Comment, error)
 	GetCommentsByUserID(ctx context.Context, userID string) ([]*Comment, error)
 	GetReplies(ctx context.Context, parentID string) ([]*Comment, error)
+
+	// GetCommentTree pages through an article's top-level comments with nested
+	// reply previews; it returns the cursor of the next page
+	GetCommentTree(ctx context.Context, postID string, query CommentThreadQuery) ([]CommentNode, string, error)
+	// GetReplyTree loads more replies of a collapsed branch in the same shape
+	GetReplyTree(ctx context.Context, commentID string, query CommentThreadQuery) ([]CommentNode, string, error)
+
+	// GetCommentHistory returns the earlier versions of a comment, oldest first
+	GetCommentHistory(ctx context.Context, commentID string) ([]CommentRevision, error)
+	// PinComment and HideComment are limited to the author of the comment's article
+	PinComment(ctx context.Context, userID, commentID string, pinned bool) error
+	HideComment(ctx context.Context, userID, commentID string, hidden bool) error
 }
\ No newline at end of file
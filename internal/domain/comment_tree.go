@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// CommentSort orders the comments of one level of a thread.
+type CommentSort string
+
+const (
+	CommentSortNewest    CommentSort = "newest"
+	CommentSortOldest    CommentSort = "oldest"
+	CommentSortMostLiked CommentSort = "most_liked"
+)
+
+// Limits for comment trees. Depth counts levels including the top level, so a
+// depth of 1 returns top-level comments with their reply counts only.
+const (
+	DefaultCommentPageSize = 20
+	MaxCommentPageSize     = 50
+	DefaultCommentDepth    = 3
+	MaxCommentDepth        = 6
+	DefaultReplyPreview    = 3
+	MaxReplyPreview        = 10
+)
+
+func (s CommentSort) Valid() bool {
+	switch s {
+	case CommentSortNewest, CommentSortOldest, CommentSortMostLiked:
+		return true
+	}
+	return false
+}
+
+// CommentNode is a comment with its like count and a preview of its replies.
+// HasMoreReplies marks a collapsed branch: fetch the rest with the node's ID
+// as the parent.
+type CommentNode struct {
+	Comment
+	Likes          int
+	ReplyCount     int
+	Replies        []CommentNode
+	HasMoreReplies bool
+}
+
+// CommentThreadQuery shapes a page of a comment tree.
+type CommentThreadQuery struct {
+	Sort     CommentSort
+	PageSize int
+	Cursor   string
+	Depth    int
+	// Replies is how many replies are shown under each expanded comment
+	Replies int
+}
+
+// WithDefaults fills unset fields and clamps the rest to their maximums.
+func (q CommentThreadQuery) WithDefaults() CommentThreadQuery {
+	if q.Sort == "" {
+		q.Sort = CommentSortNewest
+	}
+	q.PageSize = clampComment(q.PageSize, DefaultCommentPageSize, MaxCommentPageSize)
+	q.Depth = clampComment(q.Depth, DefaultCommentDepth, MaxCommentDepth)
+	if q.Replies < 0 {
+		q.Replies = DefaultReplyPreview
+	}
+	if q.Replies > MaxReplyPreview {
+		q.Replies = MaxReplyPreview
+	}
+	return q
+}
+
+func clampComment(v, def, max int) int {
+	if v <= 0 {
+		return def
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// CommentListQuery selects one page of the direct children of ParentID, or of
+// the top-level comments of PostID when ParentID is empty.
+type CommentListQuery struct {
+	PostID   string
+	ParentID string
+	Sort     CommentSort
+	After    *CommentCursor
+	Limit    int
+}
+
+// CommentCursor points after the last comment of a page: its sort key (likes
+// or creation time) with the ID breaking ties.
+type CommentCursor struct {
+	Sort CommentSort `json:"s"`
+	Key  int64       `json:"k"`
+	ID   string      `json:"id"`
+}
+
+// CommentCursorFor builds the cursor that continues after node.
+func CommentCursorFor(sort CommentSort, node CommentNode) CommentCursor {
+	key := node.CreatedAt
+	if sort == CommentSortMostLiked {
+		key = int64(node.Likes)
+	}
+	return CommentCursor{Sort: sort, Key: key, ID: node.ID}
+}
+
+func (c CommentCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCommentCursor(s string) (*CommentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c CommentCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" || !c.Sort.Valid() {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Sort keys that article listings can be paged through with a cursor. Counter
+// cursors resume after the last (value, ID) seen, so an article whose counter
+// moves between pages can cross the cursor.
+const (
+	SortByCreatedAt   = "timestamps.created_at"
+	SortByPublishedAt = "timestamps.published_at"
+	SortByViewCount   = "stats.view_count"
+	SortByClapCount   = "stats.clap_count"
+	SortByTrending    = "stats.trending_score"
+)
+
+// ArticleCursor is the decoded form of an opaque listing cursor: the sort key
+// of the last article on the previous page, with its ID breaking ties.
+type ArticleCursor struct {
+	SortField string `json:"f"`
+	SortOrder string `json:"o"`
+	Value     string `json:"v"`
+	ID        string `json:"id"`
+}
+
+func (c ArticleCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeArticleCursor(s string) (*ArticleCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c ArticleCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if c.SortOrder != "asc" && c.SortOrder != "desc" {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := c.TypedValue(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// TypedValue converts the encoded sort key back to the type stored in the database.
+func (c ArticleCursor) TypedValue() (interface{}, error) {
+	switch c.SortField {
+	case SortByCreatedAt, SortByPublishedAt:
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return t, nil
+	case SortByViewCount, SortByClapCount:
+		n, err := strconv.Atoi(c.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return n, nil
+	case SortByTrending:
+		f, err := strconv.ParseFloat(c.Value, 64)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return f, nil
+	default:
+		return nil, ErrInvalidCursor
+	}
+}
+
+// articleSortValue returns the article's value for a cursor sort key.
+func articleSortValue(a Article, field string) (string, bool) {
+	switch field {
+	case SortByCreatedAt:
+		return a.Timestamps.CreatedAt.UTC().Format(time.RFC3339Nano), true
+	case SortByPublishedAt:
+		if a.Timestamps.PublishedAt == nil {
+			return "", false
+		}
+		return a.Timestamps.PublishedAt.UTC().Format(time.RFC3339Nano), true
+	case SortByViewCount:
+		return strconv.Itoa(a.Stats.ViewCount), true
+	case SortByClapCount:
+		return strconv.Itoa(a.Stats.ClapCount), true
+	case SortByTrending:
+		return strconv.FormatFloat(a.Stats.TrendingScore, 'g', -1, 64), true
+	}
+	return "", false
+}
+
+// EffectiveSort is the sort a listing runs with: a cursor carries its own sort,
+// otherwise SortField and SortOrder apply (ascending unless "desc").
+func (p Pagination) EffectiveSort() (field, order string, err error) {
+	if p.Cursor != "" {
+		c, err := DecodeArticleCursor(p.Cursor)
+		if err != nil {
+			return "", "", err
+		}
+		return c.SortField, c.SortOrder, nil
+	}
+	order = "asc"
+	if p.SortOrder == "desc" {
+		order = "desc"
+	}
+	return p.SortField, order, nil
+}
+
+// NextCursor returns the cursor for the page after articles, or "" when this
+// was the last page or the sort key cannot be used as a cursor.
+func (p Pagination) NextCursor(articles []Article) string {
+	if len(articles) == 0 || len(articles) < p.PageSize {
+		return ""
+	}
+	field, order, err := p.EffectiveSort()
+	if err != nil {
+		return ""
+	}
+	last := articles[len(articles)-1]
+	value, ok := articleSortValue(last, field)
+	if !ok {
+		return ""
+	}
+	return ArticleCursor{SortField: field, SortOrder: order, Value: value, ID: last.ID}.Encode()
+}
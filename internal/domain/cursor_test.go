@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextCursor_RoundTrip(t *testing.T) {
+	published := time.Date(2025, 3, 1, 10, 30, 0, 123000000, time.UTC)
+	p := Pagination{PageSize: 2, SortField: SortByPublishedAt, SortOrder: "desc"}
+	articles := []Article{{ID: "a1"}, {ID: "a2", Timestamps: ArticleTimes{PublishedAt: &published}}}
+
+	next := p.NextCursor(articles)
+	if next == "" {
+		t.Fatal("expected a cursor for a full page")
+	}
+	c, err := DecodeArticleCursor(next)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if c.SortField != SortByPublishedAt || c.SortOrder != "desc" || c.ID != "a2" {
+		t.Fatalf("unexpected cursor %+v", *c)
+	}
+	v, err := c.TypedValue()
+	if err != nil || !v.(time.Time).Equal(published) {
+		t.Fatalf("unexpected value %v (%v)", v, err)
+	}
+
+	// The cursor carries its own sort, so follow-up requests need not repeat it
+	field, order, err := Pagination{Cursor: next}.EffectiveSort()
+	if err != nil || field != SortByPublishedAt || order != "desc" {
+		t.Fatalf("unexpected effective sort %s %s (%v)", field, order, err)
+	}
+}
+
+func TestNextCursor_LastPageOrUnsupportedSort(t *testing.T) {
+	articles := []Article{{ID: "a1", Stats: ArticleStats{ViewCount: 4}}}
+	if c := (Pagination{PageSize: 2, SortField: SortByViewCount}).NextCursor(articles); c != "" {
+		t.Fatalf("short page should have no cursor, got %q", c)
+	}
+	if c := (Pagination{PageSize: 1, SortField: "title"}).NextCursor(articles); c != "" {
+		t.Fatalf("unsupported sort should have no cursor, got %q", c)
+	}
+	if c := (Pagination{PageSize: 1, SortField: SortByViewCount}).NextCursor(articles); c == "" {
+		t.Fatal("expected a cursor for view count sort")
+	}
+}
+
+func TestDecodeArticleCursor_Invalid(t *testing.T) {
+	bad := []string{
+		"not base64!",
+		ArticleCursor{SortField: "title", SortOrder: "asc", Value: "x", ID: "a1"}.Encode(),
+		ArticleCursor{SortField: SortByViewCount, SortOrder: "up", Value: "1", ID: "a1"}.Encode(),
+		ArticleCursor{SortField: SortByViewCount, SortOrder: "asc", Value: "many", ID: "a1"}.Encode(),
+		ArticleCursor{SortField: SortByViewCount, SortOrder: "asc", Value: "1"}.Encode(),
+	}
+	for _, s := range bad {
+		if _, err := DecodeArticleCursor(s); err != ErrInvalidCursor {
+			t.Fatalf("expected ErrInvalidCursor for %q, got %v", s, err)
+		}
+	}
+}
+
+func TestFeedCursor_RoundTripAndOrder(t *testing.T) {
+	rank := time.Date(2025, 6, 1, 8, 0, 0, 123, time.UTC)
+	c, err := DecodeFeedCursor(FeedCursor{Rank: rank, ID: "b"}.Encode())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.Rank.Equal(rank) || c.ID != "b" {
+		t.Fatalf("unexpected cursor %+v", c)
+	}
+	if !c.After(FeedItem{Article: Article{ID: "a"}, Rank: rank}) || c.After(FeedItem{Article: Article{ID: "c"}, Rank: rank}) {
+		t.Fatalf("ties should continue with lower IDs")
+	}
+	if !c.After(FeedItem{Article: Article{ID: "z"}, Rank: rank.Add(-time.Second)}) {
+		t.Fatalf("lower ranks come after the cursor")
+	}
+	for _, bad := range []string{"%%", FeedCursor{ID: "x"}.Encode(), ArticleCursor{SortField: SortByCreatedAt, SortOrder: "desc", ID: "x"}.Encode()} {
+		if _, err := DecodeFeedCursor(bad); err != ErrInvalidCursor {
+			t.Fatalf("expected ErrInvalidCursor for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestNextCursor_CounterValuesRoundTrip(t *testing.T) {
+	last := Article{ID: "a2", Stats: ArticleStats{ViewCount: 42, ClapCount: 7, TrendingScore: 0.1 + 0.2}}
+	for field, want := range map[string]interface{}{
+		SortByViewCount: 42,
+		SortByClapCount: 7,
+		SortByTrending:  0.1 + 0.2,
+	} {
+		next := (Pagination{PageSize: 2, SortField: field, SortOrder: "desc"}).NextCursor([]Article{{ID: "a1"}, last})
+		c, err := DecodeArticleCursor(next)
+		if err != nil {
+			t.Fatalf("%s: decode: %v", field, err)
+		}
+		v, err := c.TypedValue()
+		if err != nil || v != want || c.ID != "a2" {
+			t.Fatalf("%s: unexpected cursor %+v value %v (%v)", field, *c, v, err)
+		}
+	}
+}
@@ -38,12 +38,23 @@ var (
 	ErrArticleInvalidSlug    = Error{Code: "ARTICLE_008", Message: "Invalid article slug"}
 	ErrAuthorNotFound        = Error{Code: "ARTICLE_009", Message: "Author not found"}
 	ErrArticleContentEmpty   = Error{Code: "ARTICLE_010", Message: "empty content"}
+	ErrInvalidSEOMetadata    = Error{Code: "ARTICLE_011", Message: "Invalid SEO metadata"}
+	ErrInvalidCursor         = Error{Code: "ARTICLE_012", Message: "Invalid pagination cursor"}
+	ErrInvalidSearchQuery    = Error{Code: "ARTICLE_013", Message: "Search query needs at least one word or phrase to match"}
+	ErrUnsupportedSearchSort = Error{Code: "ARTICLE_014", Message: "Search results cannot be sorted by this field"}
 	// Tag
-	ErrTagNotFound      = Error{Code: "TAG001", Message: "Tag not found"}
-	ErrInvalidTagName   = Error{Code: "TAG002", Message: "Invalid tag name"}
-	ErrTagAlreadyExists = Error{Code: "TAG003", Message: "Tag already exists"}
-	ErrTagRejected      = Error{Code: "TAG004", Message: "Tag rejected"}
-	ErrUnapprovedTags   = Error{Code: "TAG005", Message: "article contains unapproved tags"}
+	ErrTagNotFound         = Error{Code: "TAG001", Message: "Tag not found"}
+	ErrInvalidTagName      = Error{Code: "TAG002", Message: "Invalid tag name"}
+	ErrTagAlreadyExists    = Error{Code: "TAG003", Message: "Tag already exists"}
+	ErrTagRejected         = Error{Code: "TAG004", Message: "Tag rejected"}
+	ErrUnapprovedTags      = Error{Code: "TAG005", Message: "article contains unapproved tags"}
+	ErrTagNotApproved      = Error{Code: "TAG006", Message: "Only approved tags can be followed"}
+	ErrAlreadyFollowingTag = Error{Code: "TAG007", Message: "Already following this tag"}
+	ErrTagFollowNotFound   = Error{Code: "TAG008", Message: "Not following this tag"}
+	ErrSynonymInUse        = Error{Code: "TAG009", Message: "Synonym is already used by another tag"}
+	ErrTagHierarchyCycle   = Error{Code: "TAG010", Message: "Tag cannot be nested under itself or its descendants"}
+	ErrTagMergeSelf        = Error{Code: "TAG011", Message: "Cannot merge a tag into itself"}
+	ErrTagMergeTarget      = Error{Code: "TAG012", Message: "Tags can only be merged into an approved tag"}
 
 	// CLAP
 	ErrClapLimitExceeded = Error{Code: "CLAP001", Message: "clap limit exceeded"}
@@ -99,6 +110,8 @@ var (
 	ErrInvalidCommentID    = Error{Code: "COMMENT_002", Message: "Invalid comment ID format"}
 	ErrEmptyCommentContent = Error{Code: "COMMENT_003", Message: "Comment content cannot be empty"}
 	ErrCommentPermission   = Error{Code: "COMMENT_004", Message: "You do not have permission to modify this comment"}
+	ErrCommentParent       = Error{Code: "COMMENT_005", Message: "Parent comment belongs to another article"}
+	ErrCommentPinReply     = Error{Code: "COMMENT_006", Message: "Only top-level comments can be pinned"}
 
 	// Reaction-related errors
 
@@ -117,4 +130,20 @@ var (
 	ErrReportNotFound        = Error{Code: "REPORT_001", Message: "Report not found"}
 	ErrInvalidReportTarget   = Error{Code: "REPORT_002", Message: "Invalid report target"}
 	ErrReportAlreadyResolved = Error{Code: "REPORT_003", Message: "Report already resolved"}
+
+	// Notification errors
+
+	ErrNotificationNotFound          = Error{Code: "NOTIFICATION_001", Message: "Notification not found"}
+	ErrInvalidNotificationType       = Error{Code: "NOTIFICATION_002", Message: "Invalid notification type"}
+	ErrNotificationStreamUnavailable = Error{Code: "NOTIFICATION_003", Message: "Notification stream unavailable"}
+
+	// Moderation errors
+
+	ErrContentBlocked = Error{Code: "MODERATION_001", Message: "Content blocked by moderation"}
+
+	// Prompt template errors
+
+	ErrPromptTemplateNotFound = Error{Code: "PROMPT_001", Message: "Prompt template not found"}
+	ErrInvalidPromptTemplate  = Error{Code: "PROMPT_002", Message: "Invalid prompt template"}
+	ErrPromptRenderFailed     = Error{Code: "PROMPT_003", Message: "Failed to render prompt template"}
 )
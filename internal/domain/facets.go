@@ -0,0 +1,57 @@
+package domain
+
+import "strings"
+
+// MaxFacetValues caps the tag, author and language facets to their most common values.
+const MaxFacetValues = 20
+
+// wordsPerMinute is the reading speed reading times are estimated with.
+const wordsPerMinute = 200
+
+// FacetCount is the number of matching articles that share one facet value.
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// ArticleFacets counts the articles matching a search or filter by the values
+// a filter sidebar offers. Publish months are formatted "2006-01", newest first;
+// reading times use ReadingTimeBucketLabels, shortest first.
+type ArticleFacets struct {
+	Tags          []FacetCount
+	Authors       []FacetCount
+	Languages     []FacetCount
+	PublishMonths []FacetCount
+	ReadingTimes  []FacetCount
+}
+
+// ReadingTimeBuckets are the lower bounds, in minutes, of the reading-time
+// facet buckets; each bucket runs up to the next bound.
+var ReadingTimeBuckets = []int{0, 3, 5, 10, 20}
+
+// ReadingTimeBucketLabels name the buckets in ReadingTimeBuckets, in order.
+var ReadingTimeBucketLabels = []string{"0-3", "3-5", "5-10", "10-20", "20+"}
+
+// ReadingTimeUnknown labels articles stored before reading times were recorded.
+const ReadingTimeUnknown = "unknown"
+
+// ReadingTimeMinutes estimates how long the article takes to read, rounded up
+// to whole minutes and never less than one.
+func ReadingTimeMinutes(a Article) int {
+	words := len(strings.Fields(a.Title)) + len(strings.Fields(ArticleBodyText(a)))
+	minutes := (words + wordsPerMinute - 1) / wordsPerMinute
+	if minutes < 1 {
+		return 1
+	}
+	return minutes
+}
+
+// ReadingTimeBucket returns the label of the bucket a reading time falls into.
+func ReadingTimeBucket(minutes int) string {
+	for i := len(ReadingTimeBuckets) - 1; i >= 0; i-- {
+		if minutes >= ReadingTimeBuckets[i] {
+			return ReadingTimeBucketLabels[i]
+		}
+	}
+	return ReadingTimeUnknown
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadingTimeMinutes(t *testing.T) {
+	words := func(n int) string { return strings.TrimSpace(strings.Repeat("word ", n)) }
+	cases := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"empty article still takes a minute", "", 1},
+		{"exactly one minute", words(199), 1},
+		{"rounds up", words(200), 2},
+		{"long read", words(2199), 11},
+	}
+	for _, c := range cases {
+		a := Article{Title: "Title", ContentBlocks: []ContentBlock{
+			{Content: BlockContent{Paragraph: &ParagraphContent{Text: c.body}}},
+		}}
+		if got := ReadingTimeMinutes(a); got != c.want {
+			t.Errorf("%s: got %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestReadingTimeBucket(t *testing.T) {
+	cases := map[int]string{-1: ReadingTimeUnknown, 0: "0-3", 2: "0-3", 3: "3-5", 9: "5-10", 10: "10-20", 45: "20+"}
+	for minutes, want := range cases {
+		if got := ReadingTimeBucket(minutes); got != want {
+			t.Errorf("ReadingTimeBucket(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
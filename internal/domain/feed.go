@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+const (
+	// FeedWindow is how far back followed authors and tags are read.
+	FeedWindow = 14 * 24 * time.Hour
+	// FeedSourceLimit bounds how many articles each feed source contributes.
+	FeedSourceLimit = 200
+	// FeedInterestTags is how many of the reader's favourite tags feed the tag source.
+	FeedInterestTags = 10
+)
+
+// Feed sources move an article ahead of its publish time, so a followed
+// author's post from yesterday still beats an unrelated one from this morning.
+// Boosts add up when an article comes from several sources.
+const (
+	FeedAuthorBoost   = 48 * time.Hour
+	FeedTagBoost      = 24 * time.Hour
+	FeedTrendingBoost = 12 * time.Hour
+)
+
+// Reasons an article appears in a feed.
+const (
+	FeedReasonFollowing = "following"
+	FeedReasonTag       = "tag"
+	FeedReasonTrending  = "trending"
+)
+
+// FeedItem is one article in a reader's home feed.
+type FeedItem struct {
+	Article Article
+	// Rank orders the feed: publish time plus the boosts of its sources
+	Rank    time.Time
+	Reasons []string
+}
+
+// FeedCursor points after the last item of a feed page. Ranks only depend on
+// publish times and the reader's follows, so pages do not shift when new
+// articles arrive.
+type FeedCursor struct {
+	Rank time.Time `json:"r"`
+	ID   string    `json:"id"`
+}
+
+func (c FeedCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeFeedCursor(s string) (*FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c FeedCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" || c.Rank.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// After reports whether item comes after the cursor in feed order.
+func (c FeedCursor) After(item FeedItem) bool {
+	if !item.Rank.Equal(c.Rank) {
+		return item.Rank.Before(c.Rank)
+	}
+	return item.ID() < c.ID
+}
+
+func (f FeedItem) ID() string { return f.Article.ID }
+
+// SortFeed orders newest rank first, ties by descending ID.
+func SortFeed(items []FeedItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].Rank.Equal(items[j].Rank) {
+			return items[i].Rank.After(items[j].Rank)
+		}
+		return items[i].ID() > items[j].ID()
+	})
+}
+
+type IFeedRepository interface {
+	RecentByAuthors(ctx context.Context, authorIDs []string, since time.Time, limit int) ([]Article, error)
+	RecentByTags(ctx context.Context, tags []string, since time.Time, limit int) ([]Article, error)
+	TopTrending(ctx context.Context, since time.Time, limit int) ([]Article, error)
+	// TagInterests returns the tags of the articles the user clapped most for
+	TagInterests(ctx context.Context, userID string, limit int) ([]string, error)
+	// ReadArticleIDs returns which of articleIDs the user has already viewed
+	ReadArticleIDs(ctx context.Context, userID string, articleIDs []string) (map[string]bool, error)
+}
+
+type IFeedUsecase interface {
+	// GetFeed returns a page of the reader's feed and the cursor of the next page
+	GetFeed(ctx context.Context, userID string, pageSize int, cursor string) ([]FeedItem, string, error)
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxMentions caps how many users one comment or article can mention.
+const MaxMentions = 20
+
+// Mention is a resolved @username. The username is kept as it was written so
+// the text can be linked even after the user renames.
+type Mention struct {
+	UserID   string
+	Username string
+}
+
+// ProfilePath is where a mention links to.
+func (m Mention) ProfilePath() string {
+	return "/authors/" + m.UserID + "/articles"
+}
+
+// A mention starts at the beginning of the text or after a character that
+// cannot be part of a username or email address.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\pL\pN_@.])@([\pL\pN_][\pL\pN_.-]*)`)
+
+// ParseMentions returns the distinct usernames mentioned in text, in order.
+func ParseMentions(text string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		// Trailing dots and dashes are punctuation: "thanks @alice."
+		name := strings.TrimRight(m[1], ".-")
+		if len(name) < 3 || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// Mentions returns the distinct users mentioned across the article's paragraphs.
+func (a Article) Mentions() []Mention {
+	var out []Mention
+	seen := map[string]bool{}
+	for _, b := range a.ContentBlocks {
+		if b.Content.Paragraph == nil {
+			continue
+		}
+		for _, m := range b.Content.Paragraph.Mentions {
+			if !seen[m.UserID] {
+				seen[m.UserID] = true
+				out = append(out, m)
+			}
+		}
+	}
+	return out
+}
+
+type MentionSource string
+
+const (
+	MentionInComment MentionSource = "comment"
+	MentionInArticle MentionSource = "article"
+)
+
+// MentionEvent tells listeners that UserID was mentioned by ActorID.
+type MentionEvent struct {
+	Source MentionSource
+	// SourceID is the comment or article holding the mention
+	SourceID  string
+	ArticleID string
+	ActorID   string
+	UserID    string
+	Username  string
+	CreatedAt time.Time
+}
+
+// IMentionListener consumes mention events, e.g. to notify or email the user.
+type IMentionListener interface {
+	OnMention(ctx context.Context, event MentionEvent)
+}
+
+type IMentionService interface {
+	// Resolve returns the existing users mentioned in text; unknown usernames are skipped
+	Resolve(ctx context.Context, text string) []Mention
+	// ResolveArticle stores the mentions of each paragraph block on the block
+	ResolveArticle(ctx context.Context, article *Article)
+	// Publish emits event once for each mention that is not in previous,
+	// skipping the actor mentioning themselves
+	Publish(ctx context.Context, event MentionEvent, mentions, previous []Mention)
+	Subscribe(listener IMentionListener)
+}
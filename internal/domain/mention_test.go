@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	cases := map[string][]string{
+		"thanks @alice and @bob_92!":        {"alice", "bob_92"},
+		"@alice: see @alice.":               {"alice"},
+		"(@jo.doe), mail me at me@site.com": {"jo.doe"},
+		"@ab is too short, @@carol too":     nil,
+		"no mentions here":                  nil,
+		"@émile wrote this":                 {"émile"},
+	}
+	for text, want := range cases {
+		if got := ParseMentions(text); !reflect.DeepEqual(got, want) {
+			t.Fatalf("ParseMentions(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestArticleMentions_Distinct(t *testing.T) {
+	p := func(ms ...Mention) ContentBlock {
+		return ContentBlock{Type: BlockParagraph, Content: BlockContent{Paragraph: &ParagraphContent{Mentions: ms}}}
+	}
+	a := Article{ContentBlocks: []ContentBlock{
+		p(Mention{UserID: "u1", Username: "alice"}),
+		{Type: BlockHeading, Content: BlockContent{Heading: &HeadingContent{Text: "@bob"}}},
+		p(Mention{UserID: "u2", Username: "bob"}, Mention{UserID: "u1", Username: "alice"}),
+	}}
+	want := []Mention{{UserID: "u1", Username: "alice"}, {UserID: "u2", Username: "bob"}}
+	if got := a.Mentions(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected mentions %v", got)
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "context"
+
+// ContentKind identifies where a piece of moderated text comes from.
+type ContentKind string
+
+const (
+	ContentKindAIOutput   ContentKind = "ai_output"
+	ContentKindArticle    ContentKind = "article"
+	ContentKindComment    ContentKind = "comment"
+	ContentKindProfileBio ContentKind = "profile_bio"
+)
+
+// ModerationAction is the outcome of a moderation check, ordered by severity.
+type ModerationAction string
+
+const (
+	ModerationAllow ModerationAction = "allow"
+	ModerationFlag  ModerationAction = "flag"
+	ModerationBlock ModerationAction = "block"
+)
+
+var moderationRank = map[ModerationAction]int{ModerationAllow: 0, ModerationFlag: 1, ModerationBlock: 2}
+
+// ModerationReason explains a single finding of a check. Findings with the
+// allow action are informational only.
+type ModerationReason struct {
+	Check  string
+	Action ModerationAction
+	Detail string
+}
+
+// ModerationVerdict is the combined result of every check; Action is the most
+// severe action among its reasons.
+type ModerationVerdict struct {
+	Action  ModerationAction
+	Reasons []ModerationReason
+}
+
+// Add records a finding and escalates the verdict action when needed.
+func (v *ModerationVerdict) Add(reason ModerationReason) {
+	v.Reasons = append(v.Reasons, reason)
+	if moderationRank[reason.Action] > moderationRank[v.Action] {
+		v.Action = reason.Action
+	}
+}
+
+//=============================================================================//
+//                          Moderation Interface                               //
+//=============================================================================//
+
+// IModerationCheck is a single pluggable moderation rule.
+type IModerationCheck interface {
+	Name() string
+	Check(ctx context.Context, kind ContentKind, text string) ([]ModerationReason, error)
+}
+
+type IModerationService interface {
+	Moderate(ctx context.Context, kind ContentKind, text string) (*ModerationVerdict, error)
+	// FlagForReview files a pending report so admins can review flagged content.
+	FlagForReview(ctx context.Context, kind ContentKind, targetID string, verdict *ModerationVerdict) error
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+)
+
+type NotificationType string
+
+const (
+	NotificationNewFollower     NotificationType = "new_follower"
+	NotificationArticleComment  NotificationType = "article_comment"
+	NotificationCommentReply    NotificationType = "comment_reply"
+	NotificationClapMilestone   NotificationType = "clap_milestone"
+	NotificationMention         NotificationType = "mention"
+	NotificationArticleApproved NotificationType = "article_approved"
+	NotificationArticleRejected NotificationType = "article_rejected"
+	NotificationReportResolved  NotificationType = "report_resolved"
+)
+
+// NotificationTypes lists every type, in the order preferences are shown.
+var NotificationTypes = []NotificationType{
+	NotificationNewFollower,
+	NotificationArticleComment,
+	NotificationCommentReply,
+	NotificationClapMilestone,
+	NotificationMention,
+	NotificationArticleApproved,
+	NotificationArticleRejected,
+	NotificationReportResolved,
+}
+
+func (t NotificationType) Valid() bool {
+	for _, known := range NotificationTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ClapMilestones are the article clap totals its author is told about.
+var ClapMilestones = []int{10, 50, 100, 500, 1000, 5000, 10000}
+
+func IsClapMilestone(total int) bool {
+	for _, m := range ClapMilestones {
+		if total == m {
+			return true
+		}
+	}
+	return false
+}
+
+// SystemReporterID is the reporter recorded on reports filed by the moderation pipeline.
+const SystemReporterID = "system:moderation"
+
+const (
+	DefaultNotificationPageSize = 20
+	MaxNotificationPageSize     = 100
+)
+
+// Notification tells UserID that ActorID did something. The IDs that apply to
+// its type are set; the rest are empty.
+type Notification struct {
+	ID        string
+	UserID    string
+	Type      NotificationType
+	ActorID   string
+	ArticleID string
+	CommentID string
+	ReportID  string
+	Message   string
+	Read      bool
+	CreatedAt int64
+}
+
+// NotificationCursor points after the last notification of a page, newest first.
+type NotificationCursor struct {
+	CreatedAt int64  `json:"t"`
+	ID        string `json:"id"`
+}
+
+func (c NotificationCursor) Encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeNotificationCursor(s string) (*NotificationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c NotificationCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+type NotificationQuery struct {
+	UnreadOnly bool
+	After      *NotificationCursor
+	Limit      int
+}
+
+// NotificationPreferences maps every type to whether the user receives it.
+type NotificationPreferences map[NotificationType]bool
+
+// INotificationHub fans notifications out to the streams open in this process.
+type INotificationHub interface {
+	// Subscribe returns the user's live notifications and a func that ends the
+	// subscription. The channel is closed when the subscriber falls behind.
+	Subscribe(userID string) (<-chan Notification, func())
+	Deliver(n Notification)
+}
+
+// INotificationBroker carries stored notifications to the hub of every
+// instance, so a stream receives them whichever instance stored them.
+type INotificationBroker interface {
+	Publish(ctx context.Context, n Notification) error
+	// Run passes every published notification to deliver until ctx ends
+	Run(ctx context.Context, deliver func(Notification)) error
+}
+
+type INotificationRepository interface {
+	Create(ctx context.Context, n *Notification) error
+	// List returns the user's notifications newest first
+	List(ctx context.Context, userID string, query NotificationQuery) ([]Notification, error)
+	// ListAfter returns up to limit notifications stored after lastID, oldest first
+	ListAfter(ctx context.Context, userID, lastID string, limit int) ([]Notification, error)
+	CountUnread(ctx context.Context, userID string) (int, error)
+	MarkRead(ctx context.Context, userID, notificationID string) error
+	MarkAllRead(ctx context.Context, userID string) (int, error)
+	GetDisabledTypes(ctx context.Context, userID string) ([]NotificationType, error)
+	SetDisabledTypes(ctx context.Context, userID string, types []NotificationType) error
+}
+
+type INotificationUsecase interface {
+	// Notify stores n unless its recipient is the actor or turned its type
+	// off. Other usecases call it when something happens; a failure should
+	// not fail their own operation.
+	Notify(ctx context.Context, n Notification) error
+	// List pages through the user's notifications and returns the next cursor
+	List(ctx context.Context, userID string, unreadOnly bool, pageSize int, cursor string) ([]Notification, string, error)
+	UnreadCount(ctx context.Context, userID string) (int, error)
+	MarkRead(ctx context.Context, userID, notificationID string) error
+	MarkAllRead(ctx context.Context, userID string) (int, error)
+	GetPreferences(ctx context.Context, userID string) (NotificationPreferences, error)
+	// UpdatePreferences changes the listed types and returns the full set
+	UpdatePreferences(ctx context.Context, userID string, changes NotificationPreferences) (NotificationPreferences, error)
+	// Stream replays the notifications stored after lastEventID, if set, then
+	// delivers new ones. The channel is closed when ctx ends or the stream
+	// falls behind; the client reconnects with the last ID it received.
+	Stream(ctx context.Context, userID, lastEventID string) (<-chan Notification, error)
+}
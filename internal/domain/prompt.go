@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Names of the prompt templates used by the AI features.
+const (
+	PromptAISuggestions      = "ai.suggestions"
+	PromptAIGenerateContent  = "ai.generate_content"
+	PromptArticleEditContent = "article.edit_content"
+	PromptArticleSuggestTags = "article.suggest_tags"
+	PromptArticleSEO         = "article.seo"
+	PromptModerationClassify = "moderation.classify"
+)
+
+// PromptTemplate is a named, versioned text/template body used to build AI prompts.
+// Version 0 is reserved for the built-in default shipped with the binary.
+type PromptTemplate struct {
+	ID          string
+	Name        string
+	Version     int
+	Body        string
+	Description string
+	UpdatedBy   string
+	CreatedAt   time.Time
+}
+
+// RenderedPrompt is the output of executing a template, together with the
+// template version that produced it.
+type RenderedPrompt struct {
+	Name    string
+	Version int
+	Text    string
+}
+
+//=============================================================================//
+//                        Prompt Template Interface                            //
+//=============================================================================//
+type IPromptTemplateRepository interface {
+	// Create stores a new version of a template; versions are never overwritten.
+	Create(ctx context.Context, tpl *PromptTemplate) error
+	GetLatest(ctx context.Context, name string) (*PromptTemplate, error)
+	ListVersions(ctx context.Context, name string) ([]PromptTemplate, error)
+}
+
+type IPromptTemplateUsecase interface {
+	Render(ctx context.Context, name string, vars map[string]interface{}) (*RenderedPrompt, error)
+	ListTemplates(ctx context.Context) ([]PromptTemplate, error)
+	GetTemplate(ctx context.Context, name string) (*PromptTemplate, []PromptTemplate, error)
+	UpdateTemplate(ctx context.Context, userID, name, body, description string) (*PromptTemplate, error)
+	PreviewTemplate(ctx context.Context, name, body string, vars map[string]interface{}) (*RenderedPrompt, error)
+}
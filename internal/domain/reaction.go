@@ -1,6 +1,10 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"regexp"
+	"strings"
+)
 
 type ReactionType string
 
@@ -9,6 +13,47 @@ const (
 	ReactionDislike ReactionType = "dislike"
 )
 
+// ReactionOption is a reaction users can pick, with the emoji clients show for it.
+type ReactionOption struct {
+	Type  ReactionType
+	Emoji string
+}
+
+// DefaultReactionSet is offered unless REACTION_TYPES configures another one.
+var DefaultReactionSet = []ReactionOption{
+	{Type: ReactionLike, Emoji: "👍"},
+	{Type: ReactionDislike, Emoji: "👎"},
+	{Type: "love", Emoji: "❤️"},
+	{Type: "laugh", Emoji: "😂"},
+	{Type: "wow", Emoji: "😮"},
+	{Type: "sad", Emoji: "😢"},
+	{Type: "celebrate", Emoji: "🎉"},
+	{Type: "insightful", Emoji: "💡"},
+}
+
+var reactionTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// ParseReactionSet reads a comma separated list of type=emoji pairs, e.g.
+// "like=👍,love=❤️". An empty string gives the default set.
+func ParseReactionSet(s string) ([]ReactionOption, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultReactionSet, nil
+	}
+	var set []ReactionOption
+	seen := map[ReactionType]bool{}
+	for _, item := range strings.Split(s, ",") {
+		name, emoji, _ := strings.Cut(strings.TrimSpace(item), "=")
+		t := ReactionType(strings.TrimSpace(name))
+		emoji = strings.TrimSpace(emoji)
+		if !reactionTypePattern.MatchString(string(t)) || emoji == "" || seen[t] {
+			return nil, ErrInvalidReactionType
+		}
+		seen[t] = true
+		set = append(set, ReactionOption{Type: t, Emoji: emoji})
+	}
+	return set, nil
+}
+
 type Reaction struct {
 	ID        string
 	PostID    string
@@ -18,20 +63,58 @@ type Reaction struct {
 	CreatedAt int64
 }
 
+// ReactionTarget is an article, or one of its comments when CommentID is set.
+type ReactionTarget struct {
+	PostID    string
+	CommentID string
+}
+
+func (r Reaction) Target() ReactionTarget {
+	t := ReactionTarget{PostID: r.PostID}
+	if r.CommentID != nil {
+		t.CommentID = *r.CommentID
+	}
+	return t
+}
+
+// ReactionSummary holds the count of every reaction type on a target and the
+// types the caller reacted with.
+type ReactionSummary struct {
+	Counts map[ReactionType]int
+	Mine   []ReactionType
+}
+
 // Reaction Interface for reaction operations
 type IReactionRepository interface {
-	AddReaction(ctx context.Context, reaction *Reaction) error
+	// AddReaction is idempotent: a user has at most one reaction of each type
+	// per target, and adding it again returns the existing one. It reports
+	// whether this call inserted the reaction
+	AddReaction(ctx context.Context, reaction *Reaction) (bool, error)
+	// ToggleReaction removes the user's reaction of that type from the target
+	// if there is one and adds it otherwise; it reports whether it was added
+	ToggleReaction(ctx context.Context, reaction *Reaction) (bool, error)
 	RemoveReaction(ctx context.Context, reactionID string) error
+	GetByID(ctx context.Context, reactionID string) (*Reaction, error)
 	GetReactionsByPost(ctx context.Context, postID string) ([]*Reaction, error)
 	GetReactionsByUser(ctx context.Context, userID string) ([]*Reaction, error)
 	CountReactions(ctx context.Context, postID string, reactionType ReactionType) (int, error)
+	// CountByType counts the reactions on the target, by type
+	CountByType(ctx context.Context, target ReactionTarget) (map[ReactionType]int, error)
+	// GetUserReactionTypes returns the types the user reacted to the target with
+	GetUserReactionTypes(ctx context.Context, userID string, target ReactionTarget) ([]ReactionType, error)
 }
 
 // Reaction Usecase interface for reaction operations
 type IReactionUsecase interface {
 	AddReaction(ctx context.Context, reaction *Reaction) error
-	RemoveReaction(ctx context.Context, reactionID string) error
+	ToggleReaction(ctx context.Context, reaction *Reaction) (bool, error)
+	// RemoveReaction only removes the caller's own reaction
+	RemoveReaction(ctx context.Context, userID, reactionID string) error
 	GetReactionsByPost(ctx context.Context, postID string) ([]*Reaction, error)
 	GetReactionsByUser(ctx context.Context, userID string) ([]*Reaction, error)
 	CountReactions(ctx context.Context, postID string, reactionType ReactionType) (int, error)
-}
\ No newline at end of file
+	// GetReactionSummary counts every configured type on the target; Mine is
+	// only filled for a signed in userID
+	GetReactionSummary(ctx context.Context, userID string, target ReactionTarget) (*ReactionSummary, error)
+	ReactionTypes() []ReactionOption
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "testing"
+
+func TestParseReactionSet(t *testing.T) {
+	set, err := ParseReactionSet("")
+	if err != nil || len(set) != len(DefaultReactionSet) {
+		t.Fatalf("expected the default set, got %v (%v)", set, err)
+	}
+
+	set, err = ParseReactionSet(" like=👍 , fire=🔥")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(set) != 2 || set[0] != (ReactionOption{Type: "like", Emoji: "👍"}) || set[1].Type != "fire" {
+		t.Fatalf("unexpected set %v", set)
+	}
+
+	for _, bad := range []string{"like", "like=👍,like=👎", "Like=👍", "has space=👍", "like=👍,"} {
+		if _, err := ParseReactionSet(bad); err != ErrInvalidReactionType {
+			t.Errorf("%q: expected invalid reaction type, got %v", bad, err)
+		}
+	}
+}
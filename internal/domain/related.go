@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+const (
+	DefaultRelatedLimit = 5
+	MaxRelatedLimit     = 20
+	// RelatedCandidatePool bounds how many articles each signal contributes
+	// before ranking.
+	RelatedCandidatePool = 100
+)
+
+// Weights of the related-article signals. Co-engagement is damped with a log so
+// a handful of heavy readers cannot outweigh a shared topic.
+const (
+	RelatedTagWeight          = 3.0
+	RelatedAuthorWeight       = 2.0
+	RelatedCoEngagementWeight = 1.5
+)
+
+// Reasons reported with a related article.
+const (
+	RelatedReasonTags         = "tags"
+	RelatedReasonAuthor       = "author"
+	RelatedReasonCoEngagement = "co_engagement"
+)
+
+// RelatedArticle is a recommendation to read after an article.
+type RelatedArticle struct {
+	Article    Article
+	Score      float64
+	SharedTags []string
+	// CoReaders is how many readers clapped or viewed both articles
+	CoReaders int
+	Reasons   []string
+}
+
+type IRelatedRepository interface {
+	// TagAuthorCandidates returns published articles sharing a tag or the author with article
+	TagAuthorCandidates(ctx context.Context, article Article, limit int) ([]Article, error)
+	// CoEngaged counts, per other article, the readers who clapped or viewed both
+	CoEngaged(ctx context.Context, articleID string, limit int) (map[string]int, error)
+}
+
+type IRelatedUsecase interface {
+	GetRelatedArticles(ctx context.Context, articleID, userID string, limit int) ([]RelatedArticle, error)
+}
+
+// ScoreRelated explains and scores how related candidate is to base.
+func ScoreRelated(base, candidate Article, coReaders int) RelatedArticle {
+	r := RelatedArticle{Article: candidate, CoReaders: coReaders}
+	baseTags := make(map[string]bool, len(base.Tags))
+	for _, t := range base.Tags {
+		baseTags[t] = true
+	}
+	for _, t := range candidate.Tags {
+		if baseTags[t] {
+			r.SharedTags = append(r.SharedTags, t)
+			baseTags[t] = false
+		}
+	}
+	if len(r.SharedTags) > 0 {
+		r.Score += RelatedTagWeight * float64(len(r.SharedTags))
+		r.Reasons = append(r.Reasons, RelatedReasonTags)
+	}
+	if base.AuthorID != "" && candidate.AuthorID == base.AuthorID {
+		r.Score += RelatedAuthorWeight
+		r.Reasons = append(r.Reasons, RelatedReasonAuthor)
+	}
+	if coReaders > 0 {
+		r.Score += RelatedCoEngagementWeight * math.Log1p(float64(coReaders))
+		r.Reasons = append(r.Reasons, RelatedReasonCoEngagement)
+	}
+	return r
+}
+
+// SortRelated orders by score, then the more recently published, then ID.
+func SortRelated(related []RelatedArticle) {
+	sort.SliceStable(related, func(i, j int) bool {
+		a, b := related[i], related[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		ap, bp := a.Article.Timestamps.PublishedAt, b.Article.Timestamps.PublishedAt
+		if ap != nil && bp != nil && !ap.Equal(*bp) {
+			return ap.After(*bp)
+		}
+		if (ap == nil) != (bp == nil) {
+			return ap != nil
+		}
+		return a.Article.ID < b.Article.ID
+	})
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScoreRelated(t *testing.T) {
+	base := Article{ID: "base", AuthorID: "au1", Tags: []string{"go", "testing", "ci"}}
+
+	r := ScoreRelated(base, Article{ID: "a", AuthorID: "au1", Tags: []string{"ci", "go", "rust"}}, 3)
+	if !reflect.DeepEqual(r.SharedTags, []string{"ci", "go"}) {
+		t.Fatalf("unexpected shared tags %v", r.SharedTags)
+	}
+	if !reflect.DeepEqual(r.Reasons, []string{RelatedReasonTags, RelatedReasonAuthor, RelatedReasonCoEngagement}) {
+		t.Fatalf("unexpected reasons %v", r.Reasons)
+	}
+	if r.Score <= 2*RelatedTagWeight+RelatedAuthorWeight {
+		t.Fatalf("co-engagement should add to the score, got %v", r.Score)
+	}
+
+	if r := ScoreRelated(base, Article{ID: "b", AuthorID: "au2"}, 0); r.Score != 0 || r.Reasons != nil {
+		t.Fatalf("unrelated article should score 0, got %+v", r)
+	}
+}
+
+func TestSortRelated(t *testing.T) {
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	related := []RelatedArticle{
+		{Article: Article{ID: "low"}, Score: 1},
+		{Article: Article{ID: "old", Timestamps: ArticleTimes{PublishedAt: &older}}, Score: 3},
+		{Article: Article{ID: "new", Timestamps: ArticleTimes{PublishedAt: &newer}}, Score: 3},
+	}
+	SortRelated(related)
+	var ids []string
+	for _, r := range related {
+		ids = append(ids, r.Article.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"new", "old", "low"}) {
+		t.Fatalf("unexpected order %v", ids)
+	}
+}
@@ -25,12 +25,14 @@ type Report struct {
 type IReportRepository interface {
 	CreateReport(ctx context.Context, report *Report) error
 	GetReports(ctx context.Context, filter map[string]interface{}) ([]*Report, error)
+	GetReportByID(ctx context.Context, reportID string) (*Report, error)
 	UpdateReportStatus(ctx context.Context, reportID string, status ReportStatus) error
 }
 
 // IReportUsecase interface for report operations
 type IReportUsecase interface {
 	CreateReport(ctx context.Context, report *Report) error
-	GetReports(ctx context.Context, filter map[string]interface{}) ([]*Report, error)
-	UpdateReportStatus(ctx context.Context, reportID string, status ReportStatus) error
+	// GetReports and UpdateReportStatus are admin only
+	GetReports(ctx context.Context, role UserRole, filter map[string]interface{}) ([]*Report, error)
+	UpdateReportStatus(ctx context.Context, role UserRole, reportID string, status ReportStatus) error
 }
\ No newline at end of file
@@ -0,0 +1,317 @@
+package domain
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SearchQuery is a full-text search request. Text supports "quoted phrases"
+// and -excluded terms; AuthorID and Tags narrow the results.
+type SearchQuery struct {
+	Text     string
+	AuthorID string
+	Tags     []string
+}
+
+// ISearchIndex answers article searches. Backends that keep an index of their
+// own are told about every change to an article so results follow MongoDB.
+type ISearchIndex interface {
+	Search(ctx context.Context, query SearchQuery, pag Pagination) ([]SearchHit, int, error)
+	// Facets counts every article the query matches, not just one page
+	Facets(ctx context.Context, query SearchQuery) (*ArticleFacets, error)
+	// Index adds or refreshes articles; any that are not published are dropped
+	Index(ctx context.Context, articles ...Article) error
+	Remove(ctx context.Context, articleID string) error
+	// Reset empties the index ahead of a rebuild
+	Reset(ctx context.Context) error
+}
+
+// ParsedSearch is the search text split into its parts. Terms are lower-cased.
+type ParsedSearch struct {
+	Terms    []string
+	Phrases  []string
+	Excluded []string
+}
+
+// ParseSearchText splits raw search text into terms, "phrases" and -exclusions.
+func ParseSearchText(raw string) (ParsedSearch, error) {
+	var p ParsedSearch
+	rest := raw
+	for {
+		start := strings.IndexByte(rest, '"')
+		if start == -1 {
+			break
+		}
+		end := strings.IndexByte(rest[start+1:], '"')
+		if end == -1 {
+			// An unterminated quote is treated as ordinary text
+			rest = rest[:start] + " " + rest[start+1:]
+			break
+		}
+		phrase := strings.Join(strings.Fields(strings.ToLower(rest[start+1:start+1+end])), " ")
+		excluded := start > 0 && rest[start-1] == '-'
+		cut := start
+		if excluded {
+			cut--
+		}
+		if phrase != "" {
+			if excluded {
+				p.Excluded = append(p.Excluded, phrase)
+			} else {
+				p.Phrases = append(p.Phrases, phrase)
+			}
+		}
+		rest = rest[:cut] + " " + rest[start+end+2:]
+	}
+	for _, f := range strings.Fields(strings.ToLower(rest)) {
+		if strings.HasPrefix(f, "-") {
+			if t := strings.TrimLeft(f, "-"); t != "" {
+				p.Excluded = append(p.Excluded, t)
+			}
+			continue
+		}
+		p.Terms = append(p.Terms, f)
+	}
+	if len(p.Terms) == 0 && len(p.Phrases) == 0 {
+		return p, ErrInvalidSearchQuery
+	}
+	return p, nil
+}
+
+// String renders the query in the syntax understood by MongoDB $text.
+func (p ParsedSearch) String() string {
+	parts := make([]string, 0, len(p.Terms)+len(p.Phrases)+len(p.Excluded))
+	for _, ph := range p.Phrases {
+		parts = append(parts, `"`+ph+`"`)
+	}
+	parts = append(parts, p.Terms...)
+	for _, ex := range p.Excluded {
+		if strings.Contains(ex, " ") {
+			parts = append(parts, `-"`+ex+`"`)
+		} else {
+			parts = append(parts, "-"+ex)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// SearchSnippet is an excerpt of one field around a match. Text is HTML-escaped
+// with matches wrapped in <mark> tags.
+type SearchSnippet struct {
+	Field string
+	Text  string
+}
+
+// SearchHit is a search result with its relevance score and highlighted snippets.
+type SearchHit struct {
+	Article  Article
+	Score    float64
+	Snippets []SearchSnippet
+}
+
+const (
+	maxSearchSnippets   = 3
+	snippetContextRunes = 60
+)
+
+// searchableField is a piece of article text that snippets can be taken from.
+type searchableField struct {
+	name string
+	text string
+}
+
+// articleSearchFields lists the article's searchable text in reading order,
+// labelled with the field it comes from.
+func articleSearchFields(a Article) []searchableField {
+	fields := []searchableField{{"title", a.Title}, {"excerpt", a.Excerpt}}
+	blocks := append([]ContentBlock(nil), a.ContentBlocks...)
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].Order < blocks[j].Order })
+	for _, b := range blocks {
+		c := b.Content
+		switch {
+		case c.Heading != nil:
+			fields = append(fields, searchableField{"heading", c.Heading.Text})
+		case c.Paragraph != nil:
+			fields = append(fields, searchableField{"paragraph", c.Paragraph.Text})
+		case c.List != nil:
+			fields = append(fields, searchableField{"list", strings.Join(c.List.Items, " · ")})
+		case c.Code != nil:
+			fields = append(fields, searchableField{"code", c.Code.Code})
+		case c.Image != nil:
+			fields = append(fields, searchableField{"image_caption", strings.TrimSpace(c.Image.Caption + " " + c.Image.Alt)})
+		}
+	}
+	return fields
+}
+
+// ArticleBodyText joins the text of the article's content blocks in reading order.
+func ArticleBodyText(a Article) string {
+	fields := articleSearchFields(a)[2:]
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f.text != "" {
+			parts = append(parts, f.text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// BuildSnippets returns up to three highlighted snippets showing where the
+// query matched, preferring the body over the title and excerpt.
+func BuildSnippets(a Article, q ParsedSearch) []SearchSnippet {
+	needles := append(append([]string{}, q.Phrases...), q.Terms...)
+	var body, head []SearchSnippet
+	for _, f := range articleSearchFields(a) {
+		matches := findWordMatches(f.text, needles)
+		if len(matches) == 0 {
+			continue
+		}
+		s := SearchSnippet{Field: f.name, Text: highlight(f.text, matches)}
+		if f.name == "title" || f.name == "excerpt" {
+			head = append(head, s)
+		} else {
+			body = append(body, s)
+		}
+	}
+	snippets := append(body, head...)
+	if len(snippets) > maxSearchSnippets {
+		snippets = snippets[:maxSearchSnippets]
+	}
+	return snippets
+}
+
+type textMatch struct{ start, end int }
+
+// findWordMatches finds case-insensitive whole-word occurrences of the needles,
+// as byte ranges into text, ordered and without overlaps.
+func findWordMatches(text string, needles []string) []textMatch {
+	lower := strings.ToLower(text)
+	// ToLower can change byte lengths for some scripts; offsets would not line up
+	if len(lower) != len(text) {
+		return nil
+	}
+	var matches []textMatch
+	for _, n := range needles {
+		if n == "" {
+			continue
+		}
+		for from := 0; ; {
+			i := strings.Index(lower[from:], n)
+			if i == -1 {
+				break
+			}
+			start, end := from+i, from+i+len(n)
+			if isWordBoundary(lower, start, end) {
+				matches = append(matches, textMatch{start, end})
+			}
+			from = start + 1
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	merged := matches[:0]
+	for _, m := range matches {
+		if len(merged) > 0 && m.start < merged[len(merged)-1].end {
+			if m.end > merged[len(merged)-1].end {
+				merged[len(merged)-1].end = m.end
+			}
+			continue
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+func isWordBoundary(s string, start, end int) bool {
+	if start > 0 {
+		r := lastRune(s[:start])
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+	if end < len(s) {
+		r := []rune(s[end:min(end+4, len(s))])[0]
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func lastRune(s string) rune {
+	r := []rune(s[max(0, len(s)-4):])
+	return r[len(r)-1]
+}
+
+// highlight cuts a window around the first match and marks every match inside it.
+func highlight(text string, matches []textMatch) string {
+	start := backRunes(text, matches[0].start, snippetContextRunes)
+	end := forwardRunes(text, matches[0].end, 2*snippetContextRunes)
+	// Avoid cutting words in half at the window edges
+	if start > 0 {
+		if i := strings.IndexAny(text[start:matches[0].start], " \n\t"); i != -1 {
+			start += i + 1
+		}
+	}
+	if end < len(text) {
+		if i := strings.LastIndexAny(text[matches[0].end:end], " \n\t"); i != -1 {
+			end = matches[0].end + i
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, m := range matches {
+		if m.start < pos || m.end > end {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:m.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[m.start:m.end]))
+		b.WriteString("</mark>")
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func backRunes(s string, from, n int) int {
+	for i := from; i > 0; {
+		if n == 0 {
+			return i
+		}
+		i--
+		for i > 0 && !isRuneStart(s[i]) {
+			i--
+		}
+		n--
+		if i == 0 {
+			return 0
+		}
+	}
+	return 0
+}
+
+func forwardRunes(s string, from, n int) int {
+	for i := from; i < len(s); {
+		if n == 0 {
+			return i
+		}
+		i++
+		for i < len(s) && !isRuneStart(s[i]) {
+			i++
+		}
+		n--
+	}
+	return len(s)
+}
+
+func isRuneStart(b byte) bool { return b&0xC0 != 0x80 }
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSearchText(t *testing.T) {
+	p, err := ParseSearchText(`Go "error  Handling" -java -"big data" channels`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ParsedSearch{
+		Terms:    []string{"go", "channels"},
+		Phrases:  []string{"error handling"},
+		Excluded: []string{"big data", "java"},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Fatalf("got %+v, want %+v", p, want)
+	}
+	if got := p.String(); got != `"error handling" go channels -"big data" -java` {
+		t.Fatalf("unexpected $text string %q", got)
+	}
+}
+
+func TestParseSearchText_OnlyExclusions(t *testing.T) {
+	if _, err := ParseSearchText(`-java -"big data"`); err != ErrInvalidSearchQuery {
+		t.Fatalf("expected ErrInvalidSearchQuery, got %v", err)
+	}
+	// An unterminated quote is plain text
+	p, err := ParseSearchText(`"golang`)
+	if err != nil || !reflect.DeepEqual(p.Terms, []string{"golang"}) {
+		t.Fatalf("unexpected %+v (%v)", p, err)
+	}
+}
+
+func TestBuildSnippets(t *testing.T) {
+	long := strings.Repeat("filler words here ", 10)
+	a := Article{
+		Title: "Concurrency in Go",
+		ContentBlocks: []ContentBlock{
+			{Order: 2, Content: BlockContent{Code: &CodeContent{Code: "go func() { <-done }()"}}},
+			{Order: 1, Content: BlockContent{Paragraph: &ParagraphContent{Text: long + "Use Go channels & goroutines. Google is not a match."}}},
+		},
+	}
+	p, _ := ParseSearchText("go")
+
+	snippets := BuildSnippets(a, p)
+	if len(snippets) != 3 {
+		t.Fatalf("expected 3 snippets, got %+v", snippets)
+	}
+	// Body fields come first, in block order; the title comes last
+	if snippets[0].Field != "paragraph" || snippets[1].Field != "code" || snippets[2].Field != "title" {
+		t.Fatalf("unexpected order %+v", snippets)
+	}
+	para := snippets[0].Text
+	if !strings.HasPrefix(para, "…") || !strings.Contains(para, "Use <mark>Go</mark> channels &amp; goroutines") {
+		t.Fatalf("unexpected paragraph snippet %q", para)
+	}
+	if strings.Contains(para, "<mark>Go</mark>ogle") {
+		t.Fatalf("matched inside a word: %q", para)
+	}
+	if snippets[1].Text != "<mark>go</mark> func() { &lt;-done }()" {
+		t.Fatalf("unexpected code snippet %q", snippets[1].Text)
+	}
+}
+
+func TestBuildSnippets_Phrase(t *testing.T) {
+	a := Article{Excerpt: "Error handling in Go is explicit."}
+	p, _ := ParseSearchText(`"error handling"`)
+	snippets := BuildSnippets(a, p)
+	if len(snippets) != 1 || snippets[0].Text != "<mark>Error handling</mark> in Go is explicit." {
+		t.Fatalf("unexpected snippets %+v", snippets)
+	}
+}
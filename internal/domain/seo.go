@@ -0,0 +1,66 @@
+package domain
+
+import "strings"
+
+// Search engines truncate titles and descriptions beyond these lengths.
+const (
+	MaxMetaTitleLength       = 60
+	MaxMetaDescriptionLength = 160
+	MaxSEOKeywords           = 10
+)
+
+// ArticleSEO holds author-editable search and link-preview metadata.
+// Empty fields fall back to the article's own title, excerpt and images.
+type ArticleSEO struct {
+	MetaTitle       string
+	MetaDescription string
+	Keywords        []string
+	CanonicalURL    string
+	OGImage         string
+}
+
+// SocialCard is the Open Graph / Twitter card data clients use to render link previews.
+type SocialCard struct {
+	Type        string // og:type
+	Title       string
+	Description string
+	URL         string
+	Image       string
+	TwitterCard string // "summary" or "summary_large_image"
+}
+
+// BuildSocialCard derives the link-preview card for an article, preferring the
+// SEO fields and falling back to the title, excerpt and first image block.
+func BuildSocialCard(a *Article) SocialCard {
+	card := SocialCard{
+		Type:        "article",
+		Title:       firstNonEmpty(a.SEO.MetaTitle, a.Title),
+		Description: firstNonEmpty(a.SEO.MetaDescription, a.Excerpt),
+		URL:         a.SEO.CanonicalURL,
+		Image:       firstNonEmpty(a.SEO.OGImage, FirstImageURL(a)),
+		TwitterCard: "summary",
+	}
+	if card.Image != "" {
+		card.TwitterCard = "summary_large_image"
+	}
+	return card
+}
+
+// FirstImageURL returns the URL of the first image block, if any.
+func FirstImageURL(a *Article) string {
+	for _, b := range a.ContentBlocks {
+		if b.Content.Image != nil && b.Content.Image.URL != "" {
+			return b.Content.Image.URL
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestBuildSocialCard_FallsBackToArticleFields(t *testing.T) {
+	a := &Article{Title: "Go generics", Excerpt: "A tour", ContentBlocks: []ContentBlock{
+		{Type: BlockParagraph, Content: BlockContent{Paragraph: &ParagraphContent{Text: "x"}}},
+		{Type: BlockImage, Content: BlockContent{Image: &ImageContent{URL: "https://img/1.png"}}},
+	}}
+	card := BuildSocialCard(a)
+	if card.Title != "Go generics" || card.Description != "A tour" {
+		t.Fatalf("unexpected card text: %+v", card)
+	}
+	if card.Image != "https://img/1.png" || card.TwitterCard != "summary_large_image" {
+		t.Fatalf("unexpected card image: %+v", card)
+	}
+}
+
+func TestBuildSocialCard_PrefersSEOFields(t *testing.T) {
+	a := &Article{Title: "t", Excerpt: "e", SEO: ArticleSEO{MetaTitle: "Meta", MetaDescription: "Desc", CanonicalURL: "https://x/a"}}
+	card := BuildSocialCard(a)
+	if card.Title != "Meta" || card.Description != "Desc" || card.URL != "https://x/a" {
+		t.Fatalf("unexpected card: %+v", card)
+	}
+	if card.TwitterCard != "summary" {
+		t.Fatalf("expected summary card without image, got %s", card.TwitterCard)
+	}
+}
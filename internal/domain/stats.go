@@ -0,0 +1,61 @@
+package domain
+
+import "context"
+
+// StatCounter names one of the counters kept on ArticleStats.
+type StatCounter string
+
+const (
+	StatViews       StatCounter = "view_count"
+	StatUniqueViews StatCounter = "unique_view_count"
+	StatClaps       StatCounter = "clap_count"
+	StatComments    StatCounter = "comment_count"
+	StatReactions   StatCounter = "reaction_count"
+)
+
+func (c StatCounter) Valid() bool {
+	switch c {
+	case StatViews, StatUniqueViews, StatClaps, StatComments, StatReactions:
+		return true
+	}
+	return false
+}
+
+// StatsDrift is an article whose stored counters differ from the totals
+// recomputed from the readers, claps, comments and reactions collections.
+type StatsDrift struct {
+	ArticleID string
+	Stored    ArticleStats
+	Actual    ArticleStats
+}
+
+// StatsReconcileReport summarises one reconciliation run. Fixed counts the
+// drifted articles that were rewritten; it stays zero on a dry run.
+type StatsReconcileReport struct {
+	Checked int
+	Drifted []StatsDrift
+	Fixed   int
+	DryRun  bool
+}
+
+//=============================================================================//
+//                        Stats Reconcile Interfaces                           //
+//=============================================================================//
+
+type IArticleStatsRepository interface {
+	// StoredStats returns the counters currently stored on every article.
+	StoredStats(ctx context.Context) (map[string]ArticleStats, error)
+	// CountEngagement recomputes unique view, clap, comment and reaction totals
+	// per article from their source collections. Articles without any are left out.
+	CountEngagement(ctx context.Context) (map[string]ArticleStats, error)
+	// FixStats writes each drift's actual unique view, clap, comment and reaction counts,
+	// but only where the stored counters still match drift.Stored, so that
+	// increments racing the run are not lost. It returns how many were written.
+	FixStats(ctx context.Context, drifts []StatsDrift) (int, error)
+}
+
+type IStatsReconcileUsecase interface {
+	// Reconcile compares every article's counters with its source collections
+	// and, unless dryRun is set, corrects the ones that drifted.
+	Reconcile(ctx context.Context, dryRun bool) (*StatsReconcileReport, error)
+}
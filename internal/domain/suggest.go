@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// SuggestionKind is what a type-ahead suggestion points at.
+type SuggestionKind string
+
+const (
+	SuggestArticle SuggestionKind = "article"
+	SuggestTag     SuggestionKind = "tag"
+	SuggestUser    SuggestionKind = "user"
+)
+
+const (
+	DefaultSuggestLimit = 5
+	MaxSuggestLimit     = 10
+)
+
+// Suggestion is a published article title, approved tag or username offered
+// by the search box. Weight ranks suggestions that match equally well.
+type Suggestion struct {
+	Kind   SuggestionKind
+	ID     string
+	Text   string
+	Slug   string // articles only
+	Weight float64
+}
+
+// SuggestLimits caps how many suggestions of each kind are returned.
+type SuggestLimits struct {
+	Articles int
+	Tags     int
+	Users    int
+}
+
+// Suggestions are the matches for one prefix, best first within each kind.
+type Suggestions struct {
+	Articles []Suggestion
+	Tags     []Suggestion
+	Users    []Suggestion
+}
+
+type ISuggestRepository interface {
+	// SuggestionCandidates loads everything that can be suggested
+	SuggestionCandidates(ctx context.Context) ([]Suggestion, error)
+}
+
+type ISuggestUsecase interface {
+	Suggest(ctx context.Context, prefix string, limits SuggestLimits) (*Suggestions, error)
+	// Refresh reloads the candidates and reports how many there are
+	Refresh(ctx context.Context) (int, error)
+}
+
+// NormalizeSuggestText lower-cases text and reduces everything but letters and
+// digits to single spaces, so "Go_Tips: Part 2" and "go tips part 2" match.
+func NormalizeSuggestText(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "testing"
+
+func TestNormalizeSuggestText(t *testing.T) {
+	cases := map[string]string{
+		"Go_Tips: Part 2":   "go tips part 2",
+		"  Ünïcode  Café ":  "ünïcode café",
+		"---":               "",
+		"C++ & Rust/WASM!!": "c rust wasm",
+	}
+	for in, want := range cases {
+		if got := NormalizeSuggestText(in); got != want {
+			t.Fatalf("NormalizeSuggestText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package domain
 
 import (
-	"time"
 	"context"
+	"strings"
+	"time"
 )
 
 type TagStatus string
@@ -19,6 +20,21 @@ type Tag struct {
 	Status    TagStatus
 	CreatedBy string
 	CreatedAt time.Time
+	// Synonyms are alternative spellings, stored lower-cased, that resolve to this tag
+	Synonyms []string
+	// ParentID nests the tag under a broader one; listing the parent includes it
+	ParentID string
+}
+
+// TagMergeResult reports what a merge rewrote.
+type TagMergeResult struct {
+	Tag        Tag
+	ArticleIDs []string
+}
+
+// NormalizeSynonym is the form synonyms are stored and looked up in.
+func NormalizeSynonym(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
 }
 
 //=============================================================================//
@@ -29,6 +45,7 @@ type TagRepository interface {
 	Update(ctx context.Context, tag *Tag) (*Tag, error)
 	GetByID(ctx context.Context, id string) (*Tag, error)
 	GetByName(ctx context.Context, name string) (*Tag, error)
+	GetBySynonym(ctx context.Context, synonym string) (*Tag, error)
 	List(ctx context.Context, filter TagFilter) ([]Tag, error)
 	Delete(ctx context.Context, id string) error
 }
@@ -41,8 +58,41 @@ type TagUsecase interface {
 	DeleteTag(ctx context.Context, tagID string) error
 	IsTagApproved(name string) bool
 	ValidateTags(tags []string) error
+	// CanonicalTags maps synonyms onto their tag names and drops duplicates
+	CanonicalTags(tags []string) []string
+	// ExpandTags returns the canonical tags plus all approved descendants
+	ExpandTags(tags []string) []string
+
+	AddSynonym(ctx context.Context, tagID, synonym string) (*Tag, error)
+	RemoveSynonym(ctx context.Context, tagID, synonym string) (*Tag, error)
+	SetParent(ctx context.Context, tagID, parentID string) (*Tag, error)
+	MergeTags(ctx context.Context, sourceID, targetID string) (*TagMergeResult, error)
 }
 
 type TagFilter struct {
-	Status TagStatus
-}
\ No newline at end of file
+	Status   TagStatus
+	ParentID string
+}
+
+// TagMatchType describes how an AI-suggested tag was mapped onto an approved tag.
+type TagMatchType string
+
+const (
+	TagMatchExact           TagMatchType = "exact"
+	TagMatchCaseInsensitive TagMatchType = "case_insensitive"
+	TagMatchFuzzy           TagMatchType = "fuzzy"
+)
+
+// TagMatch is an AI suggestion that maps onto an existing approved tag.
+type TagMatch struct {
+	Suggested string
+	Tag       Tag
+	MatchType TagMatchType
+}
+
+// TagSuggestions separates approved tags that can be used right away from new
+// proposals that would first have to go through CreateTag and admin approval.
+type TagSuggestions struct {
+	Approved []TagMatch
+	Proposed []string
+}
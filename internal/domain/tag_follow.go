@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TagFollow subscribes a user to an approved tag. TagName is kept alongside the
+// ID because articles reference tags by name.
+type TagFollow struct {
+	UserID    string
+	TagID     string
+	TagName   string
+	CreatedAt time.Time
+}
+
+// FollowedTag is a tag the user follows with its current follower count.
+type FollowedTag struct {
+	TagID      string
+	Name       string
+	Followers  int
+	FollowedAt time.Time
+}
+
+type ITagFollowRepository interface {
+	Follow(ctx context.Context, follow *TagFollow) error
+	Unfollow(ctx context.Context, userID, tagID string) error
+	ListByUser(ctx context.Context, userID string) ([]TagFollow, error)
+	FollowerCounts(ctx context.Context, tagIDs []string) (map[string]int, error)
+	// FollowerIDs returns the users following any of the named tags
+	FollowerIDs(ctx context.Context, tagNames []string) ([]string, error)
+	// MoveFollows hands a merged tag's followers to the target, once per user
+	MoveFollows(ctx context.Context, fromTagID, toTagID, toTagName string) error
+}
+
+type ITagFollowUsecase interface {
+	FollowTag(ctx context.Context, userID, tagID string) error
+	UnfollowTag(ctx context.Context, userID, tagID string) error
+	ListFollowedTags(ctx context.Context, userID string) ([]FollowedTag, error)
+	FollowerCount(ctx context.Context, tagID string) (int, error)
+	// FollowedTagNames is what feed and notification features match articles against
+	FollowedTagNames(ctx context.Context, userID string) ([]string, error)
+	FollowersOfTags(ctx context.Context, tagNames []string) ([]string, error)
+}
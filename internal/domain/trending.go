@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EngagementKind is a type of reader interaction that feeds the trending score.
+type EngagementKind string
+
+const (
+	EngagementView     EngagementKind = "view"
+	EngagementClap     EngagementKind = "clap"
+	EngagementComment  EngagementKind = "comment"
+	EngagementReaction EngagementKind = "reaction"
+)
+
+// EngagementBucket counts one kind of engagement on an article within the hour starting at Hour.
+type EngagementBucket struct {
+	ArticleID string
+	Kind      EngagementKind
+	Hour      time.Time
+	Count     int
+}
+
+// DefaultTrendingWindow is how far back trending looks when no window is configured.
+const DefaultTrendingWindow = 7 * 24 * time.Hour
+
+// TrendingConfig controls how trending scores are computed. Engagement older
+// than Window is ignored; within it each event's weight halves every HalfLife.
+type TrendingConfig struct {
+	Window         time.Duration
+	HalfLife       time.Duration
+	ViewWeight     float64
+	ClapWeight     float64
+	CommentWeight  float64
+	ReactionWeight float64
+}
+
+func (c TrendingConfig) Weight(kind EngagementKind) float64 {
+	switch kind {
+	case EngagementView:
+		return c.ViewWeight
+	case EngagementClap:
+		return c.ClapWeight
+	case EngagementComment:
+		return c.CommentWeight
+	case EngagementReaction:
+		return c.ReactionWeight
+	}
+	return 0
+}
+
+//=============================================================================//
+//                          Trending Interfaces                                //
+//=============================================================================//
+
+type ITrendingRepository interface {
+	// EngagementSince returns hourly engagement counts per article since the given time.
+	EngagementSince(ctx context.Context, since time.Time) ([]EngagementBucket, error)
+	// SaveTrendingScores stores the given scores and resets every other article to zero.
+	SaveTrendingScores(ctx context.Context, scores map[string]float64) error
+}
+
+type ITrendingUsecase interface {
+	// RecomputeScores refreshes all trending scores and returns how many articles scored.
+	RecomputeScores(ctx context.Context) (int, error)
+}
@@ -11,6 +11,11 @@ const (
     RoleAdmin UserRole = "admin"
     RoleSuperAdmin UserRole = "super_admin"
 )
+
+// IsAdmin reports whether the role may use admin-only operations.
+func (r UserRole) IsAdmin() bool {
+    return r == RoleAdmin || r == RoleSuperAdmin
+}
 type User struct {
     ID             string    
     Username       string   
@@ -24,6 +29,8 @@ type User struct {
     IsActive       bool      
     CreatedAt      time.Time 
     UpdatedAt      time.Time 
+    // FollowedTags is filled in for profiles; follows live in their own collection
+    FollowedTags   []string
 }
 
 type RefreshToken struct {
@@ -1,32 +1,77 @@
 package domain
 
 import (
-	"time"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
 )
 
+// View is one counted read of an article. Views feed the trending score.
 type View struct {
 	ID        string
 	UserID    string
 	ArticleID string
-	ClientIP  string
+	ReaderKey string
 	CreatedAt time.Time
 }
 
+// ViewReader is whoever is reading an article. Signed-in readers are known by
+// their user ID; anonymous readers by their IP address and user agent.
+type ViewReader struct {
+	UserID    string
+	ClientIP  string
+	UserAgent string
+}
 
-// type View struct {
-// 	ID        string    `json:"id"`
-// 	UserID    string    `json:"user_id,omitempty"` // Optional for authenticated users
-// 	ArticleID string    `json:"article_id"`
-// 	ClientIP  string    `json:"client_ip"`
-// 	CreatedAt time.Time `json:"created_at"`
-// }
+// Key identifies the reader for deduplication. The IP address is hashed so
+// it is never stored.
+func (r ViewReader) Key() string {
+	if r.UserID != "" {
+		return "user:" + r.UserID
+	}
+	sum := sha256.Sum256([]byte(r.ClientIP + "\n" + r.UserAgent))
+	return "anon:" + hex.EncodeToString(sum[:])
+}
 
+// IsBot reports whether the read comes from a crawler or script. Anonymous
+// requests without a user agent count as scripts.
+func (r ViewReader) IsBot() bool {
+	if r.UserAgent == "" {
+		return r.UserID == ""
+	}
+	return IsBotUserAgent(r.UserAgent)
+}
+
+// botUserAgentMarkers are lower-case fragments of crawler, preview and
+// HTTP-library user agents.
+var botUserAgentMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "facebookexternalhit", "embedly",
+	"headlesschrome", "phantomjs", "lighthouse",
+	"curl/", "wget/", "python-requests", "python-urllib", "go-http-client", "okhttp", "axios/", "java/",
+}
+
+func IsBotUserAgent(userAgent string) bool {
+	ua := strings.ToLower(userAgent)
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(ua, marker) {
+			return true
+		}
+	}
+	return false
+}
 
 type ViewRepository interface {
+	// TrackRead records a read of the article. counted is true when the
+	// reader's previous counted read is older than window (or there was
+	// none); first is true when the reader never read the article before.
+	TrackRead(ctx context.Context, articleID, readerKey string, window time.Duration) (counted, first bool, err error)
 	Create(ctx context.Context, view *View) error
 }
 
 type ViewUsecase interface {
-	RecordView(ctx context.Context, userID, articleID, clientIP string) error
-}
\ No newline at end of file
+	// RecordView counts a read of a published article once per reader per
+	// window, ignoring the author's own reads and bots.
+	RecordView(ctx context.Context, article *Article, reader ViewReader) error
+}
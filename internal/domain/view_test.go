@@ -0,0 +1,34 @@
+package domain
+
+import "testing"
+
+func TestViewReader_KeyAndBots(t *testing.T) {
+	anon := ViewReader{ClientIP: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+	if anon.Key() != (ViewReader{ClientIP: "203.0.113.7", UserAgent: "Mozilla/5.0"}).Key() {
+		t.Fatalf("expected a stable key for the same reader")
+	}
+	if anon.Key() == (ViewReader{ClientIP: "203.0.113.8", UserAgent: "Mozilla/5.0"}).Key() {
+		t.Fatalf("expected different IPs to be different readers")
+	}
+	if got := (ViewReader{UserID: "u1", ClientIP: "203.0.113.7"}).Key(); got != "user:u1" {
+		t.Fatalf("expected signed-in readers to be keyed by user, got %q", got)
+	}
+
+	cases := []struct {
+		reader ViewReader
+		bot    bool
+	}{
+		{ViewReader{UserAgent: "Mozilla/5.0 (Macintosh) Safari/605.1.15"}, false},
+		{ViewReader{UserAgent: "Mozilla/5.0 (compatible; bingbot/2.0)"}, true},
+		{ViewReader{UserAgent: "facebookexternalhit/1.1"}, true},
+		{ViewReader{UserAgent: "curl/8.5.0"}, true},
+		{ViewReader{}, true},
+		// Signed-in reads from internal calls carry no user agent
+		{ViewReader{UserID: "u1"}, false},
+	}
+	for _, c := range cases {
+		if got := c.reader.IsBot(); got != c.bot {
+			t.Fatalf("IsBot(%+v) = %v, want %v", c.reader, got, c.bot)
+		}
+	}
+}
@@ -48,6 +48,32 @@ func (m *Middleware) Authmiddleware() gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthmiddleware identifies the caller on public routes: a valid bearer
+// token sets user_id and role, anything else continues as an anonymous request.
+func (m *Middleware) OptionalAuthmiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authParts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(authParts) == 2 && strings.ToLower(authParts[0]) == "bearer" {
+			if authclaim, err := m.tokenService.ValidateAccessToken(authParts[1]); err == nil {
+				c.Set("user_id", authclaim.UserID)
+				c.Set("role", authclaim.Role)
+			}
+		}
+		c.Next()
+	}
+}
+
+// StreamAuthmiddleware also takes the access token from the access_token query
+// parameter, because browsers cannot set headers on an EventSource.
+func (m *Middleware) StreamAuthmiddleware() gin.HandlerFunc {
+	auth := m.Authmiddleware()
+	return func(c *gin.Context) {
+		if token := c.Query("access_token"); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		auth(c)
+	}
+}
 
 func RequireRole(roles ...domain.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,70 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode/utf8"
+	"write_base/internal/domain"
+)
+
+const maxClassifierInput = 4000
+
+// AIClassifierCheck asks the AI provider to classify user-written text. AI
+// output itself is skipped since it is produced by the same provider.
+type AIClassifierCheck struct {
+	ai      domain.IAI
+	prompts domain.IPromptTemplateUsecase
+}
+
+func NewAIClassifierCheck(ai domain.IAI, prompts domain.IPromptTemplateUsecase) *AIClassifierCheck {
+	return &AIClassifierCheck{ai: ai, prompts: prompts}
+}
+
+func (c *AIClassifierCheck) Name() string { return "ai_classifier" }
+
+func (c *AIClassifierCheck) Check(ctx context.Context, kind domain.ContentKind, text string) ([]domain.ModerationReason, error) {
+	if kind == domain.ContentKindAIOutput {
+		return nil, nil
+	}
+	if len(text) > maxClassifierInput {
+		text = text[:maxClassifierInput]
+		for !utf8.ValidString(text) {
+			text = text[:len(text)-1]
+		}
+	}
+	prompt, err := c.prompts.Render(ctx, domain.PromptModerationClassify, map[string]interface{}{
+		"Kind": string(kind),
+		"Text": text,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.ai.GenerateContent(ctx, prompt.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := strings.Index(resp, "{"), strings.LastIndex(resp, "}")
+	if start == -1 || end <= start {
+		return nil, errors.New("classifier returned no JSON")
+	}
+	var parsed struct {
+		Action string `json:"action"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(resp[start:end+1]), &parsed); err != nil {
+		return nil, err
+	}
+
+	action := domain.ModerationAction(strings.ToLower(strings.TrimSpace(parsed.Action)))
+	switch action {
+	case domain.ModerationAllow:
+		return nil, nil
+	case domain.ModerationFlag, domain.ModerationBlock:
+		return []domain.ModerationReason{{Check: c.Name(), Action: action, Detail: parsed.Reason}}, nil
+	default:
+		return nil, errors.New("classifier returned unknown action " + parsed.Action)
+	}
+}
@@ -0,0 +1,178 @@
+package moderation_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/infrastructure/moderation"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReportRepo struct {
+	created []*domain.Report
+}
+
+func (f *fakeReportRepo) CreateReport(ctx context.Context, report *domain.Report) error {
+	f.created = append(f.created, report)
+	return nil
+}
+func (f *fakeReportRepo) GetReports(ctx context.Context, filter map[string]interface{}) ([]*domain.Report, error) {
+	return f.created, nil
+}
+func (f *fakeReportRepo) GetReportByID(ctx context.Context, reportID string) (*domain.Report, error) {
+	return nil, domain.ErrReportNotFound
+}
+func (f *fakeReportRepo) UpdateReportStatus(ctx context.Context, reportID string, status domain.ReportStatus) error {
+	return nil
+}
+
+type failingCheck struct{ calls int }
+
+func (f *failingCheck) Name() string { return "failing" }
+func (f *failingCheck) Check(ctx context.Context, kind domain.ContentKind, text string) ([]domain.ModerationReason, error) {
+	f.calls++
+	return nil, errors.New("boom")
+}
+
+func TestWordListCheck_Severities(t *testing.T) {
+	check := moderation.NewWordListCheck([]moderation.WordListEntry{
+		{Term: "spoiler", Severity: moderation.SeverityLow},
+		{Term: "scam", Severity: moderation.SeverityMedium},
+		{Term: "18+", Severity: moderation.SeverityHigh},
+	})
+	ctx := context.Background()
+
+	reasons, err := check.Check(ctx, domain.ContentKindComment, "Spoiler: this is a SCAM")
+	require.NoError(t, err)
+	require.Len(t, reasons, 2)
+	require.Equal(t, domain.ModerationAllow, reasons[0].Action)
+	require.Equal(t, domain.ModerationFlag, reasons[1].Action)
+
+	reasons, _ = check.Check(ctx, domain.ContentKindComment, "content for 18+ only")
+	require.Len(t, reasons, 1)
+	require.Equal(t, domain.ModerationBlock, reasons[0].Action)
+
+	// Whole words only
+	reasons, _ = check.Check(ctx, domain.ContentKindComment, "scampering squirrels, 180 pages")
+	require.Empty(t, reasons)
+}
+
+func TestLoadWordList(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "words.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"term":"scam","severity":"medium"}]`), 0o600))
+
+	entries, err := moderation.LoadWordList(path)
+	require.NoError(t, err)
+	require.Equal(t, []moderation.WordListEntry{{Term: "scam", Severity: moderation.SeverityMedium}}, entries)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"term":"scam","severity":"extreme"}]`), 0o600))
+	_, err = moderation.LoadWordList(path)
+	require.Error(t, err)
+}
+
+func TestSpamCheck(t *testing.T) {
+	check := moderation.NewSpamCheck(2)
+	ctx := context.Background()
+
+	reasons, _ := check.Check(ctx, domain.ContentKindComment, "see https://a.io and https://b.io and https://c.io")
+	require.Len(t, reasons, 1)
+	require.Equal(t, domain.ModerationFlag, reasons[0].Action)
+
+	reasons, _ = check.Check(ctx, domain.ContentKindComment, strings.Repeat("https://a.io ", 5))
+	require.Equal(t, domain.ModerationBlock, reasons[0].Action)
+
+	// Articles get a larger link allowance
+	reasons, _ = check.Check(ctx, domain.ContentKindArticle, "see https://a.io and https://b.io and https://c.io")
+	require.Empty(t, reasons)
+
+	reasons, _ = check.Check(ctx, domain.ContentKindComment, "THIS IS THE BEST ARTICLE I HAVE EVER READ")
+	require.Len(t, reasons, 1)
+	require.Contains(t, reasons[0].Detail, "upper-case")
+
+	reasons, _ = check.Check(ctx, domain.ContentKindComment, strings.Repeat("buy ", 8)+"this now")
+	require.Len(t, reasons, 1)
+	require.Contains(t, reasons[0].Detail, `"buy"`)
+}
+
+func TestService_AggregatesAndSkipsFailingChecks(t *testing.T) {
+	failing := &failingCheck{}
+	svc := moderation.NewService(nil,
+		failing,
+		moderation.NewWordListCheck([]moderation.WordListEntry{{Term: "scam", Severity: moderation.SeverityMedium}}),
+		moderation.NewSpamCheck(1),
+	)
+
+	verdict, err := svc.Moderate(context.Background(), domain.ContentKindComment, "scam https://a.io https://b.io")
+	require.NoError(t, err)
+	require.Equal(t, domain.ModerationFlag, verdict.Action)
+	require.Len(t, verdict.Reasons, 2)
+	require.Equal(t, 1, failing.calls)
+
+	verdict, err = svc.Moderate(context.Background(), domain.ContentKindComment, "   ")
+	require.NoError(t, err)
+	require.Equal(t, domain.ModerationAllow, verdict.Action)
+}
+
+func TestService_StopsAfterBlock(t *testing.T) {
+	after := &failingCheck{}
+	svc := moderation.NewService(nil, moderation.NewWordListCheck(moderation.DefaultWordList), after)
+
+	verdict, err := svc.Moderate(context.Background(), domain.ContentKindComment, "free porn here")
+	require.NoError(t, err)
+	require.Equal(t, domain.ModerationBlock, verdict.Action)
+	require.Zero(t, after.calls)
+}
+
+func TestService_FlagForReview(t *testing.T) {
+	repo := &fakeReportRepo{}
+	svc := moderation.NewService(repo)
+	verdict := &domain.ModerationVerdict{Action: domain.ModerationAllow}
+	verdict.Add(domain.ModerationReason{Check: "word_list", Action: domain.ModerationFlag, Detail: "contains term"})
+
+	require.NoError(t, svc.FlagForReview(context.Background(), domain.ContentKindComment, "c1", verdict))
+	require.Len(t, repo.created, 1)
+	r := repo.created[0]
+	require.Equal(t, moderation.SystemReporterID, r.ReporterID)
+	require.Equal(t, "c1", r.TargetID)
+	require.Equal(t, "comment", r.TargetType)
+	require.Equal(t, domain.ReportPending, r.Status)
+	require.Equal(t, "word_list: contains term", r.Reason)
+
+	// Allowed content is never reported
+	require.NoError(t, svc.FlagForReview(context.Background(), domain.ContentKindComment, "c2", &domain.ModerationVerdict{Action: domain.ModerationAllow}))
+	require.Len(t, repo.created, 1)
+}
+
+func TestAIClassifierCheck(t *testing.T) {
+	prompts := usecase.NewPromptTemplateUsecase(&mocks.PromptTemplateRepositoryMock{}, &mocks.UtilsMock{})
+	response := "```json\n{\"action\": \"Flag\", \"reason\": \"harassment\"}\n```"
+	calls := 0
+	ai := &mocks.AIMock{GenerateContentFn: func(ctx context.Context, prompt string) (string, error) {
+		calls++
+		require.Contains(t, prompt, "you are all idiots")
+		return response, nil
+	}}
+	check := moderation.NewAIClassifierCheck(ai, prompts)
+
+	reasons, err := check.Check(context.Background(), domain.ContentKindComment, "you are all idiots")
+	require.NoError(t, err)
+	require.Equal(t, []domain.ModerationReason{{Check: "ai_classifier", Action: domain.ModerationFlag, Detail: "harassment"}}, reasons)
+
+	response = `{"action": "maybe"}`
+	_, err = check.Check(context.Background(), domain.ContentKindComment, "you are all idiots")
+	require.Error(t, err)
+
+	// AI output is not classified by the same provider
+	reasons, err = check.Check(context.Background(), domain.ContentKindAIOutput, "you are all idiots")
+	require.NoError(t, err)
+	require.Empty(t, reasons)
+	require.Equal(t, 2, calls)
+}
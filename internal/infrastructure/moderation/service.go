@@ -0,0 +1,71 @@
+package moderation
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"write_base/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// SystemReporterID is the reporter recorded on reports filed by the moderation pipeline.
+const SystemReporterID = domain.SystemReporterID
+
+// Service runs every configured check and combines their findings into one verdict.
+type Service struct {
+	checks  []domain.IModerationCheck
+	reports domain.IReportRepository
+}
+
+var _ domain.IModerationService = (*Service)(nil)
+
+func NewService(reports domain.IReportRepository, checks ...domain.IModerationCheck) *Service {
+	return &Service{checks: checks, reports: reports}
+}
+
+func (s *Service) Moderate(ctx context.Context, kind domain.ContentKind, text string) (*domain.ModerationVerdict, error) {
+	verdict := &domain.ModerationVerdict{Action: domain.ModerationAllow, Reasons: []domain.ModerationReason{}}
+	if strings.TrimSpace(text) == "" {
+		return verdict, nil
+	}
+	for _, check := range s.checks {
+		// Cheap checks run first; no need to ask anything else once blocked
+		if verdict.Action == domain.ModerationBlock {
+			break
+		}
+		reasons, err := check.Check(ctx, kind, text)
+		if err != nil {
+			// A failing check must not take the write path down with it
+			log.Printf("moderation check %s failed: %v", check.Name(), err)
+			continue
+		}
+		for _, r := range reasons {
+			verdict.Add(r)
+		}
+	}
+	return verdict, nil
+}
+
+func (s *Service) FlagForReview(ctx context.Context, kind domain.ContentKind, targetID string, verdict *domain.ModerationVerdict) error {
+	if s.reports == nil || verdict == nil || verdict.Action == domain.ModerationAllow {
+		return nil
+	}
+	details := make([]string, 0, len(verdict.Reasons))
+	for _, r := range verdict.Reasons {
+		if r.Action == domain.ModerationAllow {
+			continue
+		}
+		details = append(details, r.Check+": "+r.Detail)
+	}
+	return s.reports.CreateReport(ctx, &domain.Report{
+		ID:         uuid.New().String(),
+		ReporterID: SystemReporterID,
+		TargetID:   targetID,
+		TargetType: string(kind),
+		Reason:     strings.Join(details, "; "),
+		Status:     domain.ReportPending,
+		CreatedAt:  time.Now().Unix(),
+	})
+}
@@ -0,0 +1,88 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"write_base/internal/domain"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// SpamCheck applies link and repetition heuristics. Articles legitimately link
+// a lot, so the link limit is scaled up for them.
+type SpamCheck struct {
+	MaxLinks int
+}
+
+func NewSpamCheck(maxLinks int) *SpamCheck {
+	return &SpamCheck{MaxLinks: maxLinks}
+}
+
+func (c *SpamCheck) Name() string { return "spam" }
+
+func (c *SpamCheck) Check(ctx context.Context, kind domain.ContentKind, text string) ([]domain.ModerationReason, error) {
+	var reasons []domain.ModerationReason
+	add := func(action domain.ModerationAction, detail string) {
+		reasons = append(reasons, domain.ModerationReason{Check: c.Name(), Action: action, Detail: detail})
+	}
+
+	maxLinks := c.MaxLinks
+	if kind == domain.ContentKindArticle {
+		maxLinks *= 10
+	}
+	if links := len(linkPattern.FindAllString(text, -1)); maxLinks > 0 && links > maxLinks {
+		action := domain.ModerationFlag
+		if links > 2*maxLinks {
+			action = domain.ModerationBlock
+		}
+		add(action, fmt.Sprintf("contains %d links (limit %d)", links, maxLinks))
+	}
+
+	if shoutingRatio(text) > 0.7 {
+		add(domain.ModerationFlag, "mostly upper-case text")
+	}
+
+	if word, share := dominantWord(text); share > 0.5 {
+		add(domain.ModerationFlag, fmt.Sprintf("word %q makes up %.0f%% of the text", word, share*100))
+	}
+	return reasons, nil
+}
+
+// shoutingRatio is the share of upper-case letters; short texts are ignored.
+func shoutingRatio(text string) float64 {
+	letters, upper := 0, 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters < 20 {
+		return 0
+	}
+	return float64(upper) / float64(letters)
+}
+
+// dominantWord returns the most repeated word and its share, for texts of at least 10 words.
+func dominantWord(text string) (string, float64) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) < 10 {
+		return "", 0
+	}
+	counts := map[string]int{}
+	best, bestCount := "", 0
+	for _, w := range words {
+		counts[w]++
+		if counts[w] > bestCount {
+			best, bestCount = w, counts[w]
+		}
+	}
+	return best, float64(bestCount) / float64(len(words))
+}
@@ -0,0 +1,96 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"write_base/internal/domain"
+)
+
+// Severity of a word list entry: high blocks, medium flags for review and low
+// is only recorded as an informational reason.
+type Severity string
+
+const (
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+var severityAction = map[Severity]domain.ModerationAction{
+	SeverityLow:    domain.ModerationAllow,
+	SeverityMedium: domain.ModerationFlag,
+	SeverityHigh:   domain.ModerationBlock,
+}
+
+type WordListEntry struct {
+	Term     string   `json:"term"`
+	Severity Severity `json:"severity"`
+}
+
+// DefaultWordList is used when no word list file is configured.
+var DefaultWordList = []WordListEntry{
+	{Term: "sex", Severity: SeverityHigh},
+	{Term: "porn", Severity: SeverityHigh},
+	{Term: "xxx", Severity: SeverityHigh},
+	{Term: "18+", Severity: SeverityHigh},
+}
+
+// LoadWordList reads a JSON array of {"term", "severity"} entries.
+func LoadWordList(path string) ([]WordListEntry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []WordListEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("invalid word list %s: %w", path, err)
+	}
+	for _, e := range entries {
+		if _, ok := severityAction[e.Severity]; !ok || strings.TrimSpace(e.Term) == "" {
+			return nil, fmt.Errorf("invalid word list entry %q (%s)", e.Term, e.Severity)
+		}
+	}
+	return entries, nil
+}
+
+type wordPattern struct {
+	term     string
+	severity Severity
+	re       *regexp.Regexp
+}
+
+// WordListCheck matches whole words or phrases, case-insensitively.
+type WordListCheck struct {
+	patterns []wordPattern
+}
+
+func NewWordListCheck(entries []WordListEntry) *WordListCheck {
+	patterns := make([]wordPattern, 0, len(entries))
+	for _, e := range entries {
+		term := strings.TrimSpace(e.Term)
+		// \b does not work next to non-word characters such as the "+" in "18+"
+		re := regexp.MustCompile(`(?i)(^|[^\pL\pN])` + regexp.QuoteMeta(term) + `($|[^\pL\pN])`)
+		patterns = append(patterns, wordPattern{term: term, severity: e.Severity, re: re})
+	}
+	return &WordListCheck{patterns: patterns}
+}
+
+func (c *WordListCheck) Name() string { return "word_list" }
+
+func (c *WordListCheck) Check(ctx context.Context, kind domain.ContentKind, text string) ([]domain.ModerationReason, error) {
+	var reasons []domain.ModerationReason
+	for _, p := range c.patterns {
+		if p.re.MatchString(text) {
+			reasons = append(reasons, domain.ModerationReason{
+				Check:  c.Name(),
+				Action: severityAction[p.severity],
+				Detail: fmt.Sprintf("contains %s-severity term %q", p.severity, p.term),
+			})
+		}
+	}
+	return reasons, nil
+}
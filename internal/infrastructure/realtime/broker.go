@@ -0,0 +1,46 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+	"write_base/internal/domain"
+)
+
+// LocalBroker delivers notifications within the process that stored them.
+// It suits a single instance; several instances need a shared broker such as
+// the MongoDB change stream.
+type LocalBroker struct {
+	mu       sync.RWMutex
+	handlers map[int]func(domain.Notification)
+	next     int
+}
+
+func NewLocalBroker() *LocalBroker {
+	return &LocalBroker{handlers: map[int]func(domain.Notification){}}
+}
+
+var _ domain.INotificationBroker = (*LocalBroker)(nil)
+
+func (b *LocalBroker) Publish(ctx context.Context, n domain.Notification) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, deliver := range b.handlers {
+		deliver(n)
+	}
+	return nil
+}
+
+func (b *LocalBroker) Run(ctx context.Context, deliver func(domain.Notification)) error {
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.handlers[id] = deliver
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.handlers, id)
+	b.mu.Unlock()
+	return ctx.Err()
+}
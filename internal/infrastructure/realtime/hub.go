@@ -0,0 +1,80 @@
+// Package realtime delivers notifications to the streams clients hold open.
+package realtime
+
+import (
+	"sync"
+	"write_base/internal/domain"
+)
+
+// subscriberBuffer is how many notifications a stream may lag behind before
+// it is dropped.
+const subscriberBuffer = 32
+
+type subscriber struct {
+	ch     chan domain.Notification
+	closed bool
+}
+
+// Hub fans notifications out to every stream a user has open in this process.
+type Hub struct {
+	mu   sync.Mutex
+	subs map[string]map[*subscriber]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: map[string]map[*subscriber]struct{}{}}
+}
+
+var _ domain.INotificationHub = (*Hub)(nil)
+
+func (h *Hub) Subscribe(userID string) (<-chan domain.Notification, func()) {
+	s := &subscriber{ch: make(chan domain.Notification, subscriberBuffer)}
+	h.mu.Lock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = map[*subscriber]struct{}{}
+	}
+	h.subs[userID][s] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return s.ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			h.remove(userID, s)
+		})
+	}
+}
+
+// Deliver never blocks: a subscriber whose buffer is full is closed, and its
+// client catches up by reconnecting with the last event ID it saw.
+func (h *Hub) Deliver(n domain.Notification) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subs[n.UserID] {
+		select {
+		case s.ch <- n:
+		default:
+			h.remove(n.UserID, s)
+		}
+	}
+}
+
+// Subscribers returns how many streams the user has open.
+func (h *Hub) Subscribers(userID string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[userID])
+}
+
+// remove must be called with mu held.
+func (h *Hub) remove(userID string, s *subscriber) {
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+	delete(h.subs[userID], s)
+	if len(h.subs[userID]) == 0 {
+		delete(h.subs, userID)
+	}
+}
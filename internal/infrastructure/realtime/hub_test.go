@@ -0,0 +1,84 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+)
+
+func TestHub_FansOutPerUser(t *testing.T) {
+	h := NewHub()
+	a, stopA := h.Subscribe("u1")
+	b, stopB := h.Subscribe("u1")
+	other, stopOther := h.Subscribe("u2")
+	defer stopA()
+	defer stopB()
+	defer stopOther()
+
+	h.Deliver(domain.Notification{ID: "n1", UserID: "u1"})
+	for _, ch := range []<-chan domain.Notification{a, b} {
+		if n := <-ch; n.ID != "n1" {
+			t.Fatalf("unexpected notification %+v", n)
+		}
+	}
+	select {
+	case n := <-other:
+		t.Fatalf("u2 received %+v", n)
+	default:
+	}
+}
+
+func TestHub_UnsubscribeAndSlowSubscribers(t *testing.T) {
+	h := NewHub()
+	ch, stop := h.Subscribe("u1")
+	stop()
+	stop()
+	if _, ok := <-ch; ok || h.Subscribers("u1") != 0 {
+		t.Fatal("expected the subscription to be closed and removed")
+	}
+
+	slow, stopSlow := h.Subscribe("u1")
+	defer stopSlow()
+	for i := 0; i <= subscriberBuffer; i++ {
+		h.Deliver(domain.Notification{UserID: "u1"})
+	}
+	if h.Subscribers("u1") != 0 {
+		t.Fatal("expected a subscriber that fell behind to be dropped")
+	}
+	received := 0
+	for range slow {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Fatalf("expected the buffered %d notifications before close, got %d", subscriberBuffer, received)
+	}
+}
+
+func TestLocalBroker_DeliversWhileRunning(t *testing.T) {
+	b := NewLocalBroker()
+	got := make(chan domain.Notification, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- b.Run(ctx, func(n domain.Notification) { got <- n }) }()
+
+	deadline := time.After(time.Second)
+	for {
+		_ = b.Publish(context.Background(), domain.Notification{ID: "n1"})
+		select {
+		case n := <-got:
+			if n.ID != "n1" {
+				t.Fatalf("unexpected notification %+v", n)
+			}
+		case <-time.After(5 * time.Millisecond):
+			continue
+		case <-deadline:
+			t.Fatal("broker never delivered")
+		}
+		break
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("expected Run to stop with the context, got %v", err)
+	}
+}
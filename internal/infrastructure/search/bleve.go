@@ -0,0 +1,487 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+	"write_base/internal/domain"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// Field boosts: a match in the title outranks one in the excerpt, which
+// outranks one in the body. Typo matches score at half their exact weight.
+var boostedFields = []struct {
+	name  string
+	boost float64
+}{
+	{"title", 4},
+	{"excerpt", 2},
+	{"body", 1},
+}
+
+const fuzzyBoost = 0.5
+
+// sortFields maps the listing sort keys the index can order by to its fields.
+// Counters change too often to keep in the index, so they are not sortable.
+var sortFields = map[string]string{
+	domain.SortByPublishedAt: "published_at",
+	domain.SortByCreatedAt:   "created_at",
+}
+
+// BleveIndex is an embedded full-text index kept on local disk. It only stores
+// what is needed to match and order articles; hits are loaded from MongoDB.
+type BleveIndex struct {
+	path     string
+	articles domain.IArticleRepository
+
+	mu    sync.RWMutex
+	index bleve.Index
+}
+
+var _ domain.ISearchIndex = (*BleveIndex)(nil)
+
+// NewBleveIndex opens the index at path, creating it when it does not exist.
+// An empty path keeps the index in memory.
+func NewBleveIndex(path string, articles domain.IArticleRepository) (*BleveIndex, error) {
+	idx, err := openIndex(path)
+	if err != nil {
+		return nil, err
+	}
+	return &BleveIndex{path: path, articles: articles, index: idx}, nil
+}
+
+// indexVersion changes whenever the indexed fields do. An index written by
+// another version is recreated empty, so it is rebuilt from MongoDB.
+const indexVersion = "2"
+
+var versionKey = []byte("index_version")
+
+func openIndex(path string) (bleve.Index, error) {
+	if path == "" {
+		return createIndex(path)
+	}
+	idx, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		return createIndex(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if v, err := idx.GetInternal(versionKey); err == nil && string(v) == indexVersion {
+		return idx, nil
+	}
+	if err := idx.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return nil, err
+	}
+	return createIndex(path)
+}
+
+func createIndex(path string) (bleve.Index, error) {
+	var idx bleve.Index
+	var err error
+	if path == "" {
+		idx, err = bleve.NewMemOnly(newIndexMapping())
+	} else {
+		idx, err = bleve.New(path, newIndexMapping())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.SetInternal(versionKey, []byte(indexVersion)); err != nil {
+		idx.Close()
+		return nil, err
+	}
+	return idx, nil
+}
+
+func newIndexMapping() mapping.IndexMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = standard.Name
+	text.Store = false
+	text.IncludeInAll = false
+
+	keyword := bleve.NewKeywordFieldMapping()
+	keyword.Store = false
+	keyword.IncludeInAll = false
+
+	date := bleve.NewDateTimeFieldMapping()
+	date.Store = false
+	date.IncludeInAll = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	for _, f := range boostedFields {
+		doc.AddFieldMappingsAt(f.name, text)
+	}
+	doc.AddFieldMappingsAt("id", keyword)
+	doc.AddFieldMappingsAt("status", keyword)
+	doc.AddFieldMappingsAt("author_id", keyword)
+	doc.AddFieldMappingsAt("tags", keyword)
+	doc.AddFieldMappingsAt("language", keyword)
+	doc.AddFieldMappingsAt("publish_month", keyword)
+	doc.AddFieldMappingsAt("reading_time", keyword)
+	doc.AddFieldMappingsAt("created_at", date)
+	doc.AddFieldMappingsAt("published_at", date)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	m.DefaultAnalyzer = standard.Name
+	return m
+}
+
+// toDocument flattens an article into the indexed fields. Times are cut to
+// MongoDB's millisecond precision so cursors built from stored articles line up.
+func toDocument(a domain.Article) map[string]interface{} {
+	doc := map[string]interface{}{
+		"id":           a.ID,
+		"title":        a.Title,
+		"excerpt":      a.Excerpt,
+		"body":         domain.ArticleBodyText(a),
+		"status":       string(a.Status),
+		"author_id":    a.AuthorID,
+		"tags":         a.Tags,
+		"language":     a.Language,
+		"reading_time": domain.ReadingTimeBucket(domain.ReadingTimeMinutes(a)),
+		"created_at":   a.Timestamps.CreatedAt.Truncate(time.Millisecond),
+	}
+	if a.Timestamps.PublishedAt != nil {
+		doc["published_at"] = a.Timestamps.PublishedAt.Truncate(time.Millisecond)
+		// Months are bucketed in UTC, as MongoDB's $dateToString does
+		doc["publish_month"] = a.Timestamps.PublishedAt.UTC().Format("2006-01")
+	}
+	return doc
+}
+
+func (b *BleveIndex) Index(ctx context.Context, articles ...domain.Article) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	batch := b.index.NewBatch()
+	for _, a := range articles {
+		if a.Status != domain.StatusPublished {
+			batch.Delete(a.ID)
+			continue
+		}
+		if err := batch.Index(a.ID, toDocument(a)); err != nil {
+			return err
+		}
+	}
+	return b.index.Batch(batch)
+}
+
+func (b *BleveIndex) Remove(ctx context.Context, articleID string) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.index.Delete(articleID)
+}
+
+// Reset replaces the index with an empty one. Searches running meanwhile see
+// an empty index until the rebuild repopulates it.
+func (b *BleveIndex) Reset(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.index.Close(); err != nil {
+		return err
+	}
+	if b.path != "" {
+		if err := os.RemoveAll(b.path); err != nil {
+			return err
+		}
+	}
+	idx, err := openIndex(b.path)
+	if err != nil {
+		return err
+	}
+	b.index = idx
+	return nil
+}
+
+// DocCount reports how many articles are indexed.
+func (b *BleveIndex) DocCount() (uint64, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.index.DocCount()
+}
+
+func (b *BleveIndex) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.index.Close()
+}
+
+func (b *BleveIndex) Search(ctx context.Context, q domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error) {
+	parsed, err := domain.ParseSearchText(q.Text)
+	if err != nil {
+		return nil, 0, err
+	}
+	bq := buildQuery(parsed, q)
+
+	field, order, err := pag.EffectiveSort()
+	if err != nil {
+		return nil, 0, err
+	}
+	var req *bleve.SearchRequest
+	if field == "" {
+		// relevance order; it cannot be used as a cursor
+		req = bleve.NewSearchRequestOptions(bq, pag.PageSize, (pag.Page-1)*pag.PageSize, false)
+	} else {
+		indexField, ok := sortFields[field]
+		if !ok {
+			return nil, 0, domain.ErrUnsupportedSearchSort
+		}
+		from := (pag.Page - 1) * pag.PageSize
+		if pag.Cursor != "" {
+			after, err := afterCursor(pag.Cursor, indexField)
+			if err != nil {
+				return nil, 0, err
+			}
+			bq = bleve.NewConjunctionQuery(bq, after)
+			from = 0
+		}
+		req = bleve.NewSearchRequestOptions(bq, pag.PageSize, from, false)
+		if order == "desc" {
+			req.SortBy([]string{"-" + indexField, "-id"})
+		} else {
+			req.SortBy([]string{indexField, "id"})
+		}
+	}
+
+	b.mu.RLock()
+	res, err := b.index.SearchInContext(ctx, req)
+	b.mu.RUnlock()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits, err := b.load(ctx, res)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Cursor mode skips the count; next_cursor tells clients whether to continue
+	if pag.Cursor != "" {
+		return hits, 0, nil
+	}
+	if res.Total == 0 {
+		return nil, 0, domain.ErrArticleNotFound
+	}
+	return hits, int(res.Total), nil
+}
+
+// facetFields maps each facet to the indexed keyword field it counts.
+var facetFields = map[string]string{
+	"tags":          "tags",
+	"authors":       "author_id",
+	"languages":     "language",
+	"publishMonths": "publish_month",
+	"readingTimes":  "reading_time",
+}
+
+func (b *BleveIndex) Facets(ctx context.Context, q domain.SearchQuery) (*domain.ArticleFacets, error) {
+	parsed, err := domain.ParseSearchText(q.Text)
+	if err != nil {
+		return nil, err
+	}
+	req := bleve.NewSearchRequestOptions(buildQuery(parsed, q), 0, 0, false)
+	for name, field := range facetFields {
+		size := domain.MaxFacetValues
+		if name == "publishMonths" {
+			// every month is listed; a generous cap keeps the request bounded
+			size = 240
+		}
+		req.AddFacet(name, bleve.NewFacetRequest(field, size))
+	}
+
+	b.mu.RLock()
+	res, err := b.index.SearchInContext(ctx, req)
+	b.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := func(name string) []domain.FacetCount {
+		fc := []domain.FacetCount{}
+		fr, ok := res.Facets[name]
+		if !ok || fr == nil {
+			return fc
+		}
+		for _, t := range fr.Terms.Terms() {
+			if t.Term == "" {
+				continue
+			}
+			fc = append(fc, domain.FacetCount{Value: t.Term, Count: t.Count})
+		}
+		return fc
+	}
+	facets := &domain.ArticleFacets{
+		Tags:          counts("tags"),
+		Authors:       counts("authors"),
+		Languages:     counts("languages"),
+		PublishMonths: counts("publishMonths"),
+		ReadingTimes:  counts("readingTimes"),
+	}
+	// Match the MongoDB ordering: newest month first, shortest reading time first
+	sort.Slice(facets.PublishMonths, func(i, j int) bool {
+		return facets.PublishMonths[i].Value > facets.PublishMonths[j].Value
+	})
+	sort.Slice(facets.ReadingTimes, func(i, j int) bool {
+		return bucketPosition(facets.ReadingTimes[i].Value) < bucketPosition(facets.ReadingTimes[j].Value)
+	})
+	return facets, nil
+}
+
+func bucketPosition(label string) int {
+	for i, l := range domain.ReadingTimeBucketLabels {
+		if l == label {
+			return i
+		}
+	}
+	return len(domain.ReadingTimeBucketLabels)
+}
+
+// load fetches the matched articles from MongoDB in hit order. Queries only
+// match published documents, but an index update can fail after the article
+// changed; such stale hits are left out and removed so later pages line up.
+func (b *BleveIndex) load(ctx context.Context, res *bleve.SearchResult) ([]domain.SearchHit, error) {
+	if len(res.Hits) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(res.Hits))
+	for i, h := range res.Hits {
+		ids[i] = h.ID
+	}
+	articles, err := b.articles.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]domain.Article, len(articles))
+	for _, a := range articles {
+		byID[a.ID] = a
+	}
+	hits := make([]domain.SearchHit, 0, len(res.Hits))
+	for _, h := range res.Hits {
+		a, ok := byID[h.ID]
+		if !ok || a.Status != domain.StatusPublished {
+			_ = b.Remove(ctx, h.ID)
+			continue
+		}
+		hits = append(hits, domain.SearchHit{Article: a, Score: h.Score})
+	}
+	return hits, nil
+}
+
+// buildQuery matches any term (allowing typos), requires every phrase and
+// rejects exclusions, within the author and tag filters. Only published
+// documents match, so paging and totals count what is returned.
+func buildQuery(parsed domain.ParsedSearch, q domain.SearchQuery) query.Query {
+	bq := bleve.NewBooleanQuery()
+	for _, term := range parsed.Terms {
+		bq.AddShould(termQuery(term))
+	}
+	for _, phrase := range parsed.Phrases {
+		bq.AddMust(phraseQuery(phrase))
+	}
+	for _, ex := range parsed.Excluded {
+		bq.AddMustNot(phraseQuery(ex))
+	}
+	if q.AuthorID != "" {
+		bq.AddMust(keywordQuery("author_id", q.AuthorID))
+	}
+	for _, tag := range q.Tags {
+		bq.AddMust(keywordQuery("tags", tag))
+	}
+	// Should clauses are optional next to must clauses unless asked otherwise
+	if len(parsed.Terms) > 0 && len(parsed.Phrases) == 0 {
+		bq.SetMinShould(1)
+	}
+	return bleve.NewConjunctionQuery(bq, keywordQuery("status", string(domain.StatusPublished)))
+}
+
+func termQuery(term string) query.Query {
+	dq := bleve.NewDisjunctionQuery()
+	fuzziness := typoAllowance(term)
+	for _, f := range boostedFields {
+		exact := bleve.NewMatchQuery(term)
+		exact.SetField(f.name)
+		exact.SetBoost(f.boost)
+		dq.AddQuery(exact)
+		if fuzziness > 0 {
+			fuzzy := bleve.NewMatchQuery(term)
+			fuzzy.SetField(f.name)
+			fuzzy.SetFuzziness(fuzziness)
+			fuzzy.SetBoost(f.boost * fuzzyBoost)
+			dq.AddQuery(fuzzy)
+		}
+	}
+	return dq
+}
+
+func phraseQuery(phrase string) query.Query {
+	dq := bleve.NewDisjunctionQuery()
+	for _, f := range boostedFields {
+		pq := bleve.NewMatchPhraseQuery(phrase)
+		pq.SetField(f.name)
+		pq.SetBoost(f.boost)
+		dq.AddQuery(pq)
+	}
+	return dq
+}
+
+func keywordQuery(field, value string) query.Query {
+	tq := bleve.NewTermQuery(value)
+	tq.SetField(field)
+	return tq
+}
+
+// typoAllowance is the edit distance tolerated for a term: none for short
+// words, where a typo usually makes a different word, and at most two.
+func typoAllowance(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// afterCursor matches the documents that sort after the cursor position:
+// a later (or earlier) date, or the same date with a later (or earlier) ID.
+func afterCursor(raw, field string) (query.Query, error) {
+	c, err := domain.DecodeArticleCursor(raw)
+	if err != nil {
+		return nil, err
+	}
+	v, err := c.TypedValue()
+	if err != nil {
+		return nil, err
+	}
+	at, ok := v.(time.Time)
+	if !ok {
+		return nil, domain.ErrInvalidCursor
+	}
+	yes, no := true, false
+	var beyond *query.DateRangeQuery
+	var tie *query.TermRangeQuery
+	if c.SortOrder == "desc" {
+		beyond = bleve.NewDateRangeInclusiveQuery(time.Time{}, at, nil, &no)
+		tie = bleve.NewTermRangeInclusiveQuery("", c.ID, nil, &no)
+	} else {
+		beyond = bleve.NewDateRangeInclusiveQuery(at, time.Time{}, &no, nil)
+		tie = bleve.NewTermRangeInclusiveQuery(c.ID, "", &no, nil)
+	}
+	beyond.SetField(field)
+	tie.SetField("id")
+	same := bleve.NewDateRangeInclusiveQuery(at, at, &yes, &yes)
+	same.SetField(field)
+	return bleve.NewDisjunctionQuery(beyond, bleve.NewConjunctionQuery(same, tie)), nil
+}
@@ -0,0 +1,238 @@
+package search_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+	"write_base/internal/infrastructure/search"
+	"write_base/internal/mocks"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// storeRepo serves GetByIDs from an in-memory set of articles.
+func storeRepo(articles ...domain.Article) *mocks.ArticleRepositoryMock {
+	byID := map[string]domain.Article{}
+	for _, a := range articles {
+		byID[a.ID] = a
+	}
+	return &mocks.ArticleRepositoryMock{
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]domain.Article, error) {
+			var out []domain.Article
+			for _, id := range ids {
+				if a, ok := byID[id]; ok {
+					out = append(out, a)
+				}
+			}
+			return out, nil
+		},
+	}
+}
+
+func published(id, title, body string, at time.Time) domain.Article {
+	return domain.Article{
+		ID:       id,
+		Title:    title,
+		AuthorID: "au1",
+		Tags:     []string{"go"},
+		Status:   domain.StatusPublished,
+		ContentBlocks: []domain.ContentBlock{
+			{Content: domain.BlockContent{Paragraph: &domain.ParagraphContent{Text: body}}},
+		},
+		Timestamps: domain.ArticleTimes{CreatedAt: at, PublishedAt: &at},
+	}
+}
+
+func hitIDs(hits []domain.SearchHit) []string {
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.Article.ID
+	}
+	return ids
+}
+
+func newIndex(t *testing.T, articles ...domain.Article) *search.BleveIndex {
+	t.Helper()
+	idx, err := search.NewBleveIndex("", storeRepo(articles...))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+	require.NoError(t, idx.Index(context.Background(), articles...))
+	return idx
+}
+
+var firstPage = domain.Pagination{Page: 1, PageSize: 10}
+
+func TestBleveIndex_TitleOutranksBody(t *testing.T) {
+	now := time.Now()
+	idx := newIndex(t,
+		published("body", "Notes", "A long walk through concurrency patterns.", now),
+		published("title", "Concurrency in practice", "Channels and goroutines.", now),
+	)
+	hits, total, err := idx.Search(context.Background(), domain.SearchQuery{Text: "concurrency"}, firstPage)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, []string{"title", "body"}, hitIDs(hits))
+	require.Greater(t, hits[0].Score, hits[1].Score)
+}
+
+func TestBleveIndex_ToleratesTypos(t *testing.T) {
+	idx := newIndex(t, published("a1", "Understanding concurrency", "Goroutines explained.", time.Now()))
+	hits, _, err := idx.Search(context.Background(), domain.SearchQuery{Text: "concurency"}, firstPage)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a1"}, hitIDs(hits))
+
+	// Short words must match exactly
+	_, _, err = idx.Search(context.Background(), domain.SearchQuery{Text: "gp"}, firstPage)
+	require.ErrorIs(t, err, domain.ErrArticleNotFound)
+}
+
+func TestBleveIndex_PhrasesExclusionsAndFilters(t *testing.T) {
+	now := time.Now()
+	a := published("a1", "Error handling", "Wrap errors with context in Go.", now)
+	b := published("a2", "Error handling", "Java exceptions with context.", now)
+	b.Tags = []string{"java"}
+	idx := newIndex(t, a, b)
+	ctx := context.Background()
+
+	hits, _, err := idx.Search(ctx, domain.SearchQuery{Text: `"wrap errors"`}, firstPage)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a1"}, hitIDs(hits))
+
+	hits, _, err = idx.Search(ctx, domain.SearchQuery{Text: "error -java"}, firstPage)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a1"}, hitIDs(hits))
+
+	hits, _, err = idx.Search(ctx, domain.SearchQuery{Text: "error", Tags: []string{"java"}}, firstPage)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a2"}, hitIDs(hits))
+
+	_, _, err = idx.Search(ctx, domain.SearchQuery{Text: "error", AuthorID: "someone-else"}, firstPage)
+	require.ErrorIs(t, err, domain.ErrArticleNotFound)
+}
+
+func TestBleveIndex_UnpublishedArticlesAreDropped(t *testing.T) {
+	a := published("a1", "Generics", "Type parameters.", time.Now())
+	idx := newIndex(t, a)
+	ctx := context.Background()
+
+	a.Status = domain.StatusDraft
+	require.NoError(t, idx.Index(ctx, a))
+	_, _, err := idx.Search(ctx, domain.SearchQuery{Text: "generics"}, firstPage)
+	require.ErrorIs(t, err, domain.ErrArticleNotFound)
+
+	a.Status = domain.StatusPublished
+	require.NoError(t, idx.Index(ctx, a))
+	require.NoError(t, idx.Remove(ctx, "a1"))
+	n, err := idx.DocCount()
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestBleveIndex_StaleHitsLeaveTheIndex(t *testing.T) {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	a1 := published("a1", "Go tips", "", base)
+	a2 := published("a2", "Go tips", "", base.Add(time.Hour))
+	ctx := context.Background()
+	idx, err := search.NewBleveIndex("", storeRepo(a1))
+	require.NoError(t, err)
+	defer idx.Close()
+	// a2 was unpublished in MongoDB but the index update never landed
+	require.NoError(t, idx.Index(ctx, a1, a2))
+
+	pag := domain.Pagination{Page: 1, PageSize: 1, SortField: domain.SortByPublishedAt, SortOrder: "desc"}
+	hits, _, err := idx.Search(ctx, domain.SearchQuery{Text: "go"}, pag)
+	require.NoError(t, err)
+	require.Empty(t, hits)
+
+	hits, total, err := idx.Search(ctx, domain.SearchQuery{Text: "go"}, pag)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, []string{"a1"}, hitIDs(hits))
+}
+
+func TestBleveIndex_CursorPagesByPublishDate(t *testing.T) {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	a1 := published("a1", "Go tips", "", base)
+	a2 := published("a2", "Go tips", "", base.Add(time.Hour))
+	a3 := published("a3", "Go tips", "", base.Add(time.Hour))
+	idx := newIndex(t, a1, a2, a3)
+	ctx := context.Background()
+
+	pag := domain.Pagination{Page: 1, PageSize: 2, SortField: domain.SortByPublishedAt, SortOrder: "desc"}
+	hits, _, err := idx.Search(ctx, domain.SearchQuery{Text: "go"}, pag)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a3", "a2"}, hitIDs(hits))
+
+	next := pag.NextCursor([]domain.Article{hits[0].Article, hits[1].Article})
+	hits, total, err := idx.Search(ctx, domain.SearchQuery{Text: "go"}, domain.Pagination{PageSize: 2, Cursor: next})
+	require.NoError(t, err)
+	require.Zero(t, total)
+	require.Equal(t, []string{"a1"}, hitIDs(hits))
+
+	_, _, err = idx.Search(ctx, domain.SearchQuery{Text: "go"}, domain.Pagination{Page: 1, PageSize: 2, SortField: domain.SortByViewCount})
+	require.ErrorIs(t, err, domain.ErrUnsupportedSearchSort)
+}
+
+func TestBleveIndex_PersistsAndResets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search.bleve")
+	a := published("a1", "Profiling", "pprof walkthrough.", time.Now())
+	ctx := context.Background()
+
+	idx, err := search.NewBleveIndex(path, storeRepo(a))
+	require.NoError(t, err)
+	require.NoError(t, idx.Index(ctx, a))
+	require.NoError(t, idx.Close())
+
+	idx, err = search.NewBleveIndex(path, storeRepo(a))
+	require.NoError(t, err)
+	defer idx.Close()
+	hits, _, err := idx.Search(ctx, domain.SearchQuery{Text: "profiling"}, firstPage)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a1"}, hitIDs(hits))
+
+	require.NoError(t, idx.Reset(ctx))
+	n, err := idx.DocCount()
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestBleveIndex_RecreatesIndexFromOlderVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search.bleve")
+	old, err := bleve.New(path, bleve.NewIndexMapping())
+	require.NoError(t, err)
+	require.NoError(t, old.Index("a1", map[string]interface{}{"title": "Profiling"}))
+	require.NoError(t, old.Close())
+
+	idx, err := search.NewBleveIndex(path, storeRepo())
+	require.NoError(t, err)
+	defer idx.Close()
+	n, err := idx.DocCount()
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestBleveIndex_Facets(t *testing.T) {
+	march := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+	a1 := published("a1", "Go channels", "", march)
+	a1.Language = "en"
+	a2 := published("a2", "Go generics", strings.Repeat("word ", 700), april)
+	a2.Language = "en"
+	a2.Tags = []string{"go", "generics"}
+	a3 := published("a3", "Go en français", "", april)
+	a3.Language = "fr"
+	a3.AuthorID = "au2"
+	idx := newIndex(t, a1, a2, a3, published("other", "Rust traits", "", april))
+
+	facets, err := idx.Facets(context.Background(), domain.SearchQuery{Text: "go"})
+	require.NoError(t, err)
+	require.Equal(t, []domain.FacetCount{{Value: "go", Count: 3}, {Value: "generics", Count: 1}}, facets.Tags)
+	require.Equal(t, []domain.FacetCount{{Value: "au1", Count: 2}, {Value: "au2", Count: 1}}, facets.Authors)
+	require.Equal(t, []domain.FacetCount{{Value: "en", Count: 2}, {Value: "fr", Count: 1}}, facets.Languages)
+	require.Equal(t, []domain.FacetCount{{Value: "2025-04", Count: 2}, {Value: "2025-03", Count: 1}}, facets.PublishMonths)
+	require.Equal(t, []domain.FacetCount{{Value: "0-3", Count: 2}, {Value: "3-5", Count: 1}}, facets.ReadingTimes)
+}
@@ -0,0 +1,32 @@
+package search
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+// MongoIndex searches with the MongoDB text index on the articles collection.
+// MongoDB maintains that index itself, so writes are no-ops.
+type MongoIndex struct {
+	articles domain.IArticleRepository
+}
+
+var _ domain.ISearchIndex = (*MongoIndex)(nil)
+
+func NewMongoIndex(articles domain.IArticleRepository) *MongoIndex {
+	return &MongoIndex{articles: articles}
+}
+
+func (m *MongoIndex) Search(ctx context.Context, query domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error) {
+	return m.articles.Search(ctx, query, pag)
+}
+
+func (m *MongoIndex) Facets(ctx context.Context, query domain.SearchQuery) (*domain.ArticleFacets, error) {
+	return m.articles.SearchFacets(ctx, query)
+}
+
+func (m *MongoIndex) Index(ctx context.Context, articles ...domain.Article) error { return nil }
+
+func (m *MongoIndex) Remove(ctx context.Context, articleID string) error { return nil }
+
+func (m *MongoIndex) Reset(ctx context.Context) error { return nil }
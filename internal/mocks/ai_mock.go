@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+// AIMock implements domain.IAI with pluggable funcs.
+type AIMock struct {
+	GenerateContentFn func(ctx context.Context, prompt string) (string, error)
+	GenerateSlugFn    func(ctx context.Context, title string) (string, error)
+}
+
+var _ domain.IAI = (*AIMock)(nil)
+
+func (m *AIMock) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	if m.GenerateContentFn != nil {
+		return m.GenerateContentFn(ctx, prompt)
+	}
+	return "", nil
+}
+func (m *AIMock) GenerateSlug(ctx context.Context, title string) (string, error) {
+	if m.GenerateSlugFn != nil {
+		return m.GenerateSlugFn(ctx, title)
+	}
+	return "", nil
+}
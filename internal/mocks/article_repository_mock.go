@@ -21,19 +21,25 @@ type ArticleRepositoryMock struct {
 	ArchiveFn              func(ctx context.Context, articleID string, archiveAt time.Time) error
 	UnarchiveFn            func(ctx context.Context, articleID string) error
 	ListByAuthorFn         func(ctx context.Context, authorID string, pag domain.Pagination) ([]domain.Article, int, error)
-	FindTrendingFn         func(ctx context.Context, windowDays int, pag domain.Pagination) ([]domain.Article, int, error)
+	FindTrendingFn         func(ctx context.Context, window time.Duration, pag domain.Pagination) ([]domain.Article, int, error)
 	FindNewArticlesFn      func(ctx context.Context, pag domain.Pagination) ([]domain.Article, int, error)
 	FindPopularArticlesFn  func(ctx context.Context, pag domain.Pagination) ([]domain.Article, int, error)
 	FilterAuthorArticlesFn func(ctx context.Context, authorID string, filter domain.ArticleFilter, pag domain.Pagination) ([]domain.Article, int, error)
 	FilterFn               func(ctx context.Context, filter domain.ArticleFilter, pag domain.Pagination) ([]domain.Article, int, error)
-	SearchFn               func(ctx context.Context, query string, pag domain.Pagination) ([]domain.Article, int, error)
+	SearchFn               func(ctx context.Context, query domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error)
 	ListByTagsFn           func(ctx context.Context, tags []string, pag domain.Pagination) ([]domain.Article, int, error)
+	ReplaceTagFn           func(ctx context.Context, from, to string) ([]string, error)
 	EmptyTrashFn           func(ctx context.Context, userID string) error
 	DeleteFromTrashFn      func(ctx context.Context, articleID, userID string) error
 	AdminListAllArticlesFn func(ctx context.Context, pag domain.Pagination) ([]domain.Article, int, error)
 	HardDeleteFn           func(ctx context.Context, articleID string) error
+	FilterFacetsFn         func(ctx context.Context, filter domain.ArticleFilter) (*domain.ArticleFacets, error)
+	SearchFacetsFn         func(ctx context.Context, query domain.SearchQuery) (*domain.ArticleFacets, error)
+	GetByIDsFn             func(ctx context.Context, articleIDs []string) ([]domain.Article, error)
+	ForEachPublishedFn     func(ctx context.Context, batchSize int, fn func([]domain.Article) error) error
 	IncrementViewFn        func(ctx context.Context, articleID string) error
-	UpdateClapCountFn      func(ctx context.Context, articleID string, count int) error
+	IncrementStatFn        func(ctx context.Context, articleID string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error)
+	MarkClapMilestoneFn    func(ctx context.Context, articleID string, milestone int) (bool, error)
 }
 
 func (m *ArticleRepositoryMock) Create(ctx context.Context, a *domain.Article) error {
@@ -114,9 +120,9 @@ func (m *ArticleRepositoryMock) ListByAuthor(ctx context.Context, authorID strin
 	}
 	return nil, 0, nil
 }
-func (m *ArticleRepositoryMock) FindTrending(ctx context.Context, windowDays int, pag domain.Pagination) ([]domain.Article, int, error) {
+func (m *ArticleRepositoryMock) FindTrending(ctx context.Context, window time.Duration, pag domain.Pagination) ([]domain.Article, int, error) {
 	if m.FindTrendingFn != nil {
-		return m.FindTrendingFn(ctx, windowDays, pag)
+		return m.FindTrendingFn(ctx, window, pag)
 	}
 	return nil, 0, nil
 }
@@ -144,7 +150,7 @@ func (m *ArticleRepositoryMock) Filter(ctx context.Context, filter domain.Articl
 	}
 	return nil, 0, nil
 }
-func (m *ArticleRepositoryMock) Search(ctx context.Context, query string, pag domain.Pagination) ([]domain.Article, int, error) {
+func (m *ArticleRepositoryMock) Search(ctx context.Context, query domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error) {
 	if m.SearchFn != nil {
 		return m.SearchFn(ctx, query, pag)
 	}
@@ -156,6 +162,12 @@ func (m *ArticleRepositoryMock) ListByTags(ctx context.Context, tags []string, p
 	}
 	return nil, 0, nil
 }
+func (m *ArticleRepositoryMock) ReplaceTag(ctx context.Context, from, to string) ([]string, error) {
+	if m.ReplaceTagFn != nil {
+		return m.ReplaceTagFn(ctx, from, to)
+	}
+	return nil, nil
+}
 func (m *ArticleRepositoryMock) EmptyTrash(ctx context.Context, userID string) error {
 	if m.EmptyTrashFn != nil {
 		return m.EmptyTrashFn(ctx, userID)
@@ -180,15 +192,45 @@ func (m *ArticleRepositoryMock) HardDelete(ctx context.Context, articleID string
 	}
 	return nil
 }
+func (m *ArticleRepositoryMock) FilterFacets(ctx context.Context, filter domain.ArticleFilter) (*domain.ArticleFacets, error) {
+	if m.FilterFacetsFn != nil {
+		return m.FilterFacetsFn(ctx, filter)
+	}
+	return &domain.ArticleFacets{}, nil
+}
+func (m *ArticleRepositoryMock) SearchFacets(ctx context.Context, query domain.SearchQuery) (*domain.ArticleFacets, error) {
+	if m.SearchFacetsFn != nil {
+		return m.SearchFacetsFn(ctx, query)
+	}
+	return &domain.ArticleFacets{}, nil
+}
+func (m *ArticleRepositoryMock) GetByIDs(ctx context.Context, articleIDs []string) ([]domain.Article, error) {
+	if m.GetByIDsFn != nil {
+		return m.GetByIDsFn(ctx, articleIDs)
+	}
+	return nil, nil
+}
+func (m *ArticleRepositoryMock) ForEachPublished(ctx context.Context, batchSize int, fn func([]domain.Article) error) error {
+	if m.ForEachPublishedFn != nil {
+		return m.ForEachPublishedFn(ctx, batchSize, fn)
+	}
+	return nil
+}
 func (m *ArticleRepositoryMock) IncrementView(ctx context.Context, articleID string) error {
 	if m.IncrementViewFn != nil {
 		return m.IncrementViewFn(ctx, articleID)
 	}
 	return nil
 }
-func (m *ArticleRepositoryMock) UpdateClapCount(ctx context.Context, articleID string, count int) error {
-	if m.UpdateClapCountFn != nil {
-		return m.UpdateClapCountFn(ctx, articleID, count)
+func (m *ArticleRepositoryMock) IncrementStat(ctx context.Context, articleID string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+	if m.IncrementStatFn != nil {
+		return m.IncrementStatFn(ctx, articleID, counter, delta)
 	}
-	return nil
+	return &domain.ArticleStats{}, nil
+}
+func (m *ArticleRepositoryMock) MarkClapMilestone(ctx context.Context, articleID string, milestone int) (bool, error) {
+	if m.MarkClapMilestoneFn != nil {
+		return m.MarkClapMilestoneFn(ctx, articleID, milestone)
+	}
+	return true, nil
 }
@@ -12,7 +12,7 @@ type ArticleUsecaseMock struct {
 	DeleteArticleFn             func(ctx context.Context, articleID, userID string) error
 	RestoreArticleFn            func(ctx context.Context, userID string, articleID string) error
 	GetArticleByIDFn            func(ctx context.Context, articleID, userID string) (*domain.Article, error)
-	GetArticleBySlugFn          func(ctx context.Context, slug string, clientIP string) (*domain.Article, error)
+	GetArticleBySlugFn          func(ctx context.Context, slug string, reader domain.ViewReader) (*domain.Article, error)
 	GetArticleStatsFn           func(ctx context.Context, articleID, userID string) (*domain.ArticleStats, error)
 	GetAllArticleStatsFn        func(ctx context.Context, userID string) ([]domain.ArticleStats, int, error)
 	PublishArticleFn            func(ctx context.Context, articleID, userID string) (*domain.Article, error)
@@ -25,16 +25,23 @@ type ArticleUsecaseMock struct {
 	GetPopularArticlesFn        func(ctx context.Context, userID string, pag domain.Pagination) ([]domain.Article, int, error)
 	FilterAuthorArticlesFn      func(ctx context.Context, callerID, authorID string, filter domain.ArticleFilter, pag domain.Pagination) ([]domain.Article, int, error)
 	FilterArticlesFn            func(ctx context.Context, filter domain.ArticleFilter, pag domain.Pagination) ([]domain.Article, int, error)
-	SearchArticlesFn            func(ctx context.Context, userID, query string, pag domain.Pagination) ([]domain.Article, int, error)
+	SearchArticlesFn            func(ctx context.Context, userID string, query domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error)
 	ListArticlesByTagsFn        func(ctx context.Context, userID string, tags []string, pag domain.Pagination) ([]domain.Article, int, error)
 	EmptyTrashFn                func(ctx context.Context, userID string) error
 	DeleteArticleFromTrashFn    func(ctx context.Context, articleID, userID string) error
 	AdminListAllArticlesFn      func(ctx context.Context, userID, userRole string, pag domain.Pagination) ([]domain.Article, int, error)
 	AdminHardDeleteArticleFn    func(ctx context.Context, userID, userRole, articleID string) error
 	AdminUnpublishArticleFn     func(ctx context.Context, userID, userRole, articleID string) (*domain.Article, error)
+	GetFilterFacetsFn           func(ctx context.Context, filter domain.ArticleFilter) (*domain.ArticleFacets, error)
+	GetSearchFacetsFn           func(ctx context.Context, userID string, query domain.SearchQuery) (*domain.ArticleFacets, error)
+	AdminRebuildSearchIndexFn   func(ctx context.Context, userID, userRole string) (int, error)
 	AddClapFn                   func(ctx context.Context, userID, articleID string) (domain.ArticleStats, error)
+	RemoveClapsFn               func(ctx context.Context, userID, articleID string) (domain.ArticleStats, error)
+	GetUserClapsFn              func(ctx context.Context, userID, articleID string) (domain.UserClapState, error)
 	GenerateContentForArticleFn func(ctx context.Context, article *domain.Article, instructions string) (*domain.Article, error)
 	GenerateSlugForTitleFn      func(ctx context.Context, title string) (string, error)
+	SuggestTagsForArticleFn     func(ctx context.Context, articleID, userID string) (*domain.TagSuggestions, error)
+	GenerateSEOForArticleFn     func(ctx context.Context, articleID, userID string) (*domain.ArticleSEO, error)
 }
 
 func (m *ArticleUsecaseMock) CreateArticle(ctx context.Context, userID string, input *domain.Article) (string, error) {
@@ -67,9 +74,9 @@ func (m *ArticleUsecaseMock) GetArticleByID(ctx context.Context, articleID, user
 	}
 	return nil, nil
 }
-func (m *ArticleUsecaseMock) GetArticleBySlug(ctx context.Context, slug string, clientIP string) (*domain.Article, error) {
+func (m *ArticleUsecaseMock) GetArticleBySlug(ctx context.Context, slug string, reader domain.ViewReader) (*domain.Article, error) {
 	if m.GetArticleBySlugFn != nil {
-		return m.GetArticleBySlugFn(ctx, slug, clientIP)
+		return m.GetArticleBySlugFn(ctx, slug, reader)
 	}
 	return nil, nil
 }
@@ -145,7 +152,7 @@ func (m *ArticleUsecaseMock) FilterArticles(ctx context.Context, filter domain.A
 	}
 	return nil, 0, nil
 }
-func (m *ArticleUsecaseMock) SearchArticles(ctx context.Context, userID, query string, pag domain.Pagination) ([]domain.Article, int, error) {
+func (m *ArticleUsecaseMock) SearchArticles(ctx context.Context, userID string, query domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error) {
 	if m.SearchArticlesFn != nil {
 		return m.SearchArticlesFn(ctx, userID, query, pag)
 	}
@@ -187,12 +194,42 @@ func (m *ArticleUsecaseMock) AdminUnpublishArticle(ctx context.Context, userID,
 	}
 	return nil, nil
 }
+func (m *ArticleUsecaseMock) GetFilterFacets(ctx context.Context, filter domain.ArticleFilter) (*domain.ArticleFacets, error) {
+	if m.GetFilterFacetsFn != nil {
+		return m.GetFilterFacetsFn(ctx, filter)
+	}
+	return &domain.ArticleFacets{}, nil
+}
+func (m *ArticleUsecaseMock) GetSearchFacets(ctx context.Context, userID string, query domain.SearchQuery) (*domain.ArticleFacets, error) {
+	if m.GetSearchFacetsFn != nil {
+		return m.GetSearchFacetsFn(ctx, userID, query)
+	}
+	return &domain.ArticleFacets{}, nil
+}
+func (m *ArticleUsecaseMock) AdminRebuildSearchIndex(ctx context.Context, userID, userRole string) (int, error) {
+	if m.AdminRebuildSearchIndexFn != nil {
+		return m.AdminRebuildSearchIndexFn(ctx, userID, userRole)
+	}
+	return 0, nil
+}
 func (m *ArticleUsecaseMock) AddClap(ctx context.Context, userID, articleID string) (domain.ArticleStats, error) {
 	if m.AddClapFn != nil {
 		return m.AddClapFn(ctx, userID, articleID)
 	}
 	return domain.ArticleStats{}, nil
 }
+func (m *ArticleUsecaseMock) RemoveClaps(ctx context.Context, userID, articleID string) (domain.ArticleStats, error) {
+	if m.RemoveClapsFn != nil {
+		return m.RemoveClapsFn(ctx, userID, articleID)
+	}
+	return domain.ArticleStats{}, nil
+}
+func (m *ArticleUsecaseMock) GetUserClaps(ctx context.Context, userID, articleID string) (domain.UserClapState, error) {
+	if m.GetUserClapsFn != nil {
+		return m.GetUserClapsFn(ctx, userID, articleID)
+	}
+	return domain.UserClapState{Max: domain.MaxClapsPerUser}, nil
+}
 func (m *ArticleUsecaseMock) GenerateContentForArticle(ctx context.Context, article *domain.Article, instructions string) (*domain.Article, error) {
 	if m.GenerateContentForArticleFn != nil {
 		return m.GenerateContentForArticleFn(ctx, article, instructions)
@@ -205,3 +242,15 @@ func (m *ArticleUsecaseMock) GenerateSlugForTitle(ctx context.Context, title str
 	}
 	return "", nil
 }
+func (m *ArticleUsecaseMock) SuggestTagsForArticle(ctx context.Context, articleID, userID string) (*domain.TagSuggestions, error) {
+	if m.SuggestTagsForArticleFn != nil {
+		return m.SuggestTagsForArticleFn(ctx, articleID, userID)
+	}
+	return &domain.TagSuggestions{}, nil
+}
+func (m *ArticleUsecaseMock) GenerateSEOForArticle(ctx context.Context, articleID, userID string) (*domain.ArticleSEO, error) {
+	if m.GenerateSEOForArticleFn != nil {
+		return m.GenerateSEOForArticleFn(ctx, articleID, userID)
+	}
+	return &domain.ArticleSEO{}, nil
+}
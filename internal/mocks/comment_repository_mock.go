@@ -6,15 +6,24 @@ import (
 )
 
 type CommentRepositoryMock struct {
-	CreateFn      func(ctx context.Context, comment *domain.Comment) error
-	UpdateFn      func(ctx context.Context, comment *domain.Comment) error
-	DeleteFn      func(ctx context.Context, commentID string) error
-	GetByIDFn     func(ctx context.Context, commentID string) (*domain.Comment, error)
-	GetByPostIDFn func(ctx context.Context, postID string) ([]*domain.Comment, error)
-	GetByUserIDFn func(ctx context.Context, userID string) ([]*domain.Comment, error)
-	GetRepliesFn  func(ctx context.Context, parentID string) ([]*domain.Comment, error)
+	CreateFn       func(ctx context.Context, comment *domain.Comment) error
+	UpdateFn       func(ctx context.Context, comment *domain.Comment) error
+	DeleteFn       func(ctx context.Context, commentID string) error
+	GetByIDFn      func(ctx context.Context, commentID string) (*domain.Comment, error)
+	GetByPostIDFn  func(ctx context.Context, postID string) ([]*domain.Comment, error)
+	GetByUserIDFn  func(ctx context.Context, userID string) ([]*domain.Comment, error)
+	GetRepliesFn   func(ctx context.Context, parentID string) ([]*domain.Comment, error)
+	ListThreadFn   func(ctx context.Context, query domain.CommentListQuery) ([]domain.CommentNode, error)
+	ListRepliesFn  func(ctx context.Context, parentIDs []string, sort domain.CommentSort, perParent int) (map[string][]domain.CommentNode, error)
+	CountRepliesFn func(ctx context.Context, parentIDs []string) (map[string]int, error)
+	GetHistoryFn   func(ctx context.Context, commentID string) ([]domain.CommentRevision, error)
+	SetHiddenFn    func(ctx context.Context, commentID string, hidden bool) error
+	SetPinnedFn    func(ctx context.Context, postID, commentID string) error
+	GetPinnedFn    func(ctx context.Context, postID string) (*domain.CommentNode, error)
 }
 
+var _ domain.ICommentRepository = (*CommentRepositoryMock)(nil)
+
 func (m *CommentRepositoryMock) Create(ctx context.Context, c *domain.Comment) error {
 	if m.CreateFn != nil {
 		return m.CreateFn(ctx, c)
@@ -57,3 +66,45 @@ func (m *CommentRepositoryMock) GetReplies(ctx context.Context, parentID string)
 	}
 	return nil, nil
 }
+func (m *CommentRepositoryMock) ListThread(ctx context.Context, query domain.CommentListQuery) ([]domain.CommentNode, error) {
+	if m.ListThreadFn != nil {
+		return m.ListThreadFn(ctx, query)
+	}
+	return nil, nil
+}
+func (m *CommentRepositoryMock) ListReplies(ctx context.Context, parentIDs []string, sort domain.CommentSort, perParent int) (map[string][]domain.CommentNode, error) {
+	if m.ListRepliesFn != nil {
+		return m.ListRepliesFn(ctx, parentIDs, sort, perParent)
+	}
+	return map[string][]domain.CommentNode{}, nil
+}
+func (m *CommentRepositoryMock) CountReplies(ctx context.Context, parentIDs []string) (map[string]int, error) {
+	if m.CountRepliesFn != nil {
+		return m.CountRepliesFn(ctx, parentIDs)
+	}
+	return map[string]int{}, nil
+}
+func (m *CommentRepositoryMock) GetHistory(ctx context.Context, commentID string) ([]domain.CommentRevision, error) {
+	if m.GetHistoryFn != nil {
+		return m.GetHistoryFn(ctx, commentID)
+	}
+	return nil, nil
+}
+func (m *CommentRepositoryMock) SetHidden(ctx context.Context, commentID string, hidden bool) error {
+	if m.SetHiddenFn != nil {
+		return m.SetHiddenFn(ctx, commentID, hidden)
+	}
+	return nil
+}
+func (m *CommentRepositoryMock) SetPinned(ctx context.Context, postID, commentID string) error {
+	if m.SetPinnedFn != nil {
+		return m.SetPinnedFn(ctx, postID, commentID)
+	}
+	return nil
+}
+func (m *CommentRepositoryMock) GetPinned(ctx context.Context, postID string) (*domain.CommentNode, error) {
+	if m.GetPinnedFn != nil {
+		return m.GetPinnedFn(ctx, postID)
+	}
+	return nil, nil
+}
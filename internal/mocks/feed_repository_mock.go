@@ -0,0 +1,75 @@
+package mocks
+
+import (
+	"context"
+	"time"
+	"write_base/internal/domain"
+)
+
+// FeedRepositoryMock implements domain.IFeedRepository with pluggable funcs.
+type FeedRepositoryMock struct {
+	RecentByAuthorsFn func(ctx context.Context, authorIDs []string, since time.Time, limit int) ([]domain.Article, error)
+	RecentByTagsFn    func(ctx context.Context, tags []string, since time.Time, limit int) ([]domain.Article, error)
+	TopTrendingFn     func(ctx context.Context, since time.Time, limit int) ([]domain.Article, error)
+	TagInterestsFn    func(ctx context.Context, userID string, limit int) ([]string, error)
+	ReadArticleIDsFn  func(ctx context.Context, userID string, articleIDs []string) (map[string]bool, error)
+}
+
+var _ domain.IFeedRepository = (*FeedRepositoryMock)(nil)
+
+func (m *FeedRepositoryMock) RecentByAuthors(ctx context.Context, authorIDs []string, since time.Time, limit int) ([]domain.Article, error) {
+	if m.RecentByAuthorsFn != nil {
+		return m.RecentByAuthorsFn(ctx, authorIDs, since, limit)
+	}
+	return nil, nil
+}
+func (m *FeedRepositoryMock) RecentByTags(ctx context.Context, tags []string, since time.Time, limit int) ([]domain.Article, error) {
+	if m.RecentByTagsFn != nil {
+		return m.RecentByTagsFn(ctx, tags, since, limit)
+	}
+	return nil, nil
+}
+func (m *FeedRepositoryMock) TopTrending(ctx context.Context, since time.Time, limit int) ([]domain.Article, error) {
+	if m.TopTrendingFn != nil {
+		return m.TopTrendingFn(ctx, since, limit)
+	}
+	return nil, nil
+}
+func (m *FeedRepositoryMock) TagInterests(ctx context.Context, userID string, limit int) ([]string, error) {
+	if m.TagInterestsFn != nil {
+		return m.TagInterestsFn(ctx, userID, limit)
+	}
+	return nil, nil
+}
+func (m *FeedRepositoryMock) ReadArticleIDs(ctx context.Context, userID string, articleIDs []string) (map[string]bool, error) {
+	if m.ReadArticleIDsFn != nil {
+		return m.ReadArticleIDsFn(ctx, userID, articleIDs)
+	}
+	return map[string]bool{}, nil
+}
+
+// FollowRepositoryMock implements domain.IFollowRepository with pluggable funcs.
+type FollowRepositoryMock struct {
+	GetFollowingFn func(ctx context.Context, userID string) ([]*domain.User, error)
+}
+
+var _ domain.IFollowRepository = (*FollowRepositoryMock)(nil)
+
+func (m *FollowRepositoryMock) FollowUser(ctx context.Context, followerID, followeeID string) error {
+	return nil
+}
+func (m *FollowRepositoryMock) UnfollowUser(ctx context.Context, followerID, followeeID string) error {
+	return nil
+}
+func (m *FollowRepositoryMock) GetFollowers(ctx context.Context, userID string) ([]*domain.User, error) {
+	return nil, nil
+}
+func (m *FollowRepositoryMock) GetFollowing(ctx context.Context, userID string) ([]*domain.User, error) {
+	if m.GetFollowingFn != nil {
+		return m.GetFollowingFn(ctx, userID)
+	}
+	return nil, nil
+}
+func (m *FollowRepositoryMock) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	return false, nil
+}
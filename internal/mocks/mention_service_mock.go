@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+type MentionServiceMock struct {
+	ResolveFn        func(ctx context.Context, text string) []domain.Mention
+	ResolveArticleFn func(ctx context.Context, article *domain.Article)
+	PublishFn        func(ctx context.Context, event domain.MentionEvent, mentions, previous []domain.Mention)
+	SubscribeFn      func(listener domain.IMentionListener)
+}
+
+var _ domain.IMentionService = (*MentionServiceMock)(nil)
+
+func (m *MentionServiceMock) Resolve(ctx context.Context, text string) []domain.Mention {
+	if m.ResolveFn != nil {
+		return m.ResolveFn(ctx, text)
+	}
+	return nil
+}
+func (m *MentionServiceMock) ResolveArticle(ctx context.Context, article *domain.Article) {
+	if m.ResolveArticleFn != nil {
+		m.ResolveArticleFn(ctx, article)
+	}
+}
+func (m *MentionServiceMock) Publish(ctx context.Context, event domain.MentionEvent, mentions, previous []domain.Mention) {
+	if m.PublishFn != nil {
+		m.PublishFn(ctx, event, mentions, previous)
+	}
+}
+func (m *MentionServiceMock) Subscribe(listener domain.IMentionListener) {
+	if m.SubscribeFn != nil {
+		m.SubscribeFn(listener)
+	}
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+// ModerationServiceMock implements domain.IModerationService with pluggable funcs.
+// By default every text is allowed.
+type ModerationServiceMock struct {
+	ModerateFn      func(ctx context.Context, kind domain.ContentKind, text string) (*domain.ModerationVerdict, error)
+	FlagForReviewFn func(ctx context.Context, kind domain.ContentKind, targetID string, verdict *domain.ModerationVerdict) error
+}
+
+var _ domain.IModerationService = (*ModerationServiceMock)(nil)
+
+func (m *ModerationServiceMock) Moderate(ctx context.Context, kind domain.ContentKind, text string) (*domain.ModerationVerdict, error) {
+	if m.ModerateFn != nil {
+		return m.ModerateFn(ctx, kind, text)
+	}
+	return &domain.ModerationVerdict{Action: domain.ModerationAllow}, nil
+}
+func (m *ModerationServiceMock) FlagForReview(ctx context.Context, kind domain.ContentKind, targetID string, verdict *domain.ModerationVerdict) error {
+	if m.FlagForReviewFn != nil {
+		return m.FlagForReviewFn(ctx, kind, targetID, verdict)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package mocks
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+type NotificationRepositoryMock struct {
+	CreateFn           func(ctx context.Context, n *domain.Notification) error
+	ListFn             func(ctx context.Context, userID string, query domain.NotificationQuery) ([]domain.Notification, error)
+	CountUnreadFn      func(ctx context.Context, userID string) (int, error)
+	MarkReadFn         func(ctx context.Context, userID, notificationID string) error
+	MarkAllReadFn      func(ctx context.Context, userID string) (int, error)
+	GetDisabledTypesFn func(ctx context.Context, userID string) ([]domain.NotificationType, error)
+	SetDisabledTypesFn func(ctx context.Context, userID string, types []domain.NotificationType) error
+	ListAfterFn        func(ctx context.Context, userID, lastID string, limit int) ([]domain.Notification, error)
+}
+
+var _ domain.INotificationRepository = (*NotificationRepositoryMock)(nil)
+
+func (m *NotificationRepositoryMock) Create(ctx context.Context, n *domain.Notification) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, n)
+	}
+	return nil
+}
+func (m *NotificationRepositoryMock) List(ctx context.Context, userID string, query domain.NotificationQuery) ([]domain.Notification, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx, userID, query)
+	}
+	return nil, nil
+}
+func (m *NotificationRepositoryMock) ListAfter(ctx context.Context, userID, lastID string, limit int) ([]domain.Notification, error) {
+	if m.ListAfterFn != nil {
+		return m.ListAfterFn(ctx, userID, lastID, limit)
+	}
+	return nil, nil
+}
+func (m *NotificationRepositoryMock) CountUnread(ctx context.Context, userID string) (int, error) {
+	if m.CountUnreadFn != nil {
+		return m.CountUnreadFn(ctx, userID)
+	}
+	return 0, nil
+}
+func (m *NotificationRepositoryMock) MarkRead(ctx context.Context, userID, notificationID string) error {
+	if m.MarkReadFn != nil {
+		return m.MarkReadFn(ctx, userID, notificationID)
+	}
+	return nil
+}
+func (m *NotificationRepositoryMock) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	if m.MarkAllReadFn != nil {
+		return m.MarkAllReadFn(ctx, userID)
+	}
+	return 0, nil
+}
+func (m *NotificationRepositoryMock) GetDisabledTypes(ctx context.Context, userID string) ([]domain.NotificationType, error) {
+	if m.GetDisabledTypesFn != nil {
+		return m.GetDisabledTypesFn(ctx, userID)
+	}
+	return nil, nil
+}
+func (m *NotificationRepositoryMock) SetDisabledTypes(ctx context.Context, userID string, types []domain.NotificationType) error {
+	if m.SetDisabledTypesFn != nil {
+		return m.SetDisabledTypesFn(ctx, userID, types)
+	}
+	return nil
+}
+
+// NotificationUsecaseMock records notifications sent with Notify unless NotifyFn is set.
+type NotificationUsecaseMock struct {
+	Sent []domain.Notification
+
+	NotifyFn            func(ctx context.Context, n domain.Notification) error
+	ListFn              func(ctx context.Context, userID string, unreadOnly bool, pageSize int, cursor string) ([]domain.Notification, string, error)
+	UnreadCountFn       func(ctx context.Context, userID string) (int, error)
+	MarkReadFn          func(ctx context.Context, userID, notificationID string) error
+	MarkAllReadFn       func(ctx context.Context, userID string) (int, error)
+	GetPreferencesFn    func(ctx context.Context, userID string) (domain.NotificationPreferences, error)
+	UpdatePreferencesFn func(ctx context.Context, userID string, changes domain.NotificationPreferences) (domain.NotificationPreferences, error)
+	StreamFn            func(ctx context.Context, userID, lastEventID string) (<-chan domain.Notification, error)
+}
+
+var _ domain.INotificationUsecase = (*NotificationUsecaseMock)(nil)
+
+func (m *NotificationUsecaseMock) Notify(ctx context.Context, n domain.Notification) error {
+	if m.NotifyFn != nil {
+		return m.NotifyFn(ctx, n)
+	}
+	m.Sent = append(m.Sent, n)
+	return nil
+}
+func (m *NotificationUsecaseMock) List(ctx context.Context, userID string, unreadOnly bool, pageSize int, cursor string) ([]domain.Notification, string, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx, userID, unreadOnly, pageSize, cursor)
+	}
+	return nil, "", nil
+}
+func (m *NotificationUsecaseMock) UnreadCount(ctx context.Context, userID string) (int, error) {
+	if m.UnreadCountFn != nil {
+		return m.UnreadCountFn(ctx, userID)
+	}
+	return 0, nil
+}
+func (m *NotificationUsecaseMock) MarkRead(ctx context.Context, userID, notificationID string) error {
+	if m.MarkReadFn != nil {
+		return m.MarkReadFn(ctx, userID, notificationID)
+	}
+	return nil
+}
+func (m *NotificationUsecaseMock) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	if m.MarkAllReadFn != nil {
+		return m.MarkAllReadFn(ctx, userID)
+	}
+	return 0, nil
+}
+func (m *NotificationUsecaseMock) GetPreferences(ctx context.Context, userID string) (domain.NotificationPreferences, error) {
+	if m.GetPreferencesFn != nil {
+		return m.GetPreferencesFn(ctx, userID)
+	}
+	return domain.NotificationPreferences{}, nil
+}
+func (m *NotificationUsecaseMock) UpdatePreferences(ctx context.Context, userID string, changes domain.NotificationPreferences) (domain.NotificationPreferences, error) {
+	if m.UpdatePreferencesFn != nil {
+		return m.UpdatePreferencesFn(ctx, userID, changes)
+	}
+	return changes, nil
+}
+func (m *NotificationUsecaseMock) Stream(ctx context.Context, userID, lastEventID string) (<-chan domain.Notification, error) {
+	if m.StreamFn != nil {
+		return m.StreamFn(ctx, userID, lastEventID)
+	}
+	return nil, domain.ErrNotificationStreamUnavailable
+}
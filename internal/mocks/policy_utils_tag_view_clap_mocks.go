@@ -82,6 +82,9 @@ func (u *UtilsMock) ValidateContent(blocks []domain.ContentBlock) bool {
 type TagUsecaseMock struct {
 	ValidateTagsFn  func([]string) error
 	IsTagApprovedFn func(string) bool
+	ListTagsFn      func(ctx context.Context, status domain.TagStatus) ([]domain.Tag, error)
+	CanonicalTagsFn func([]string) []string
+	ExpandTagsFn    func([]string) []string
 }
 
 func (t *TagUsecaseMock) CreateTag(ctx context.Context, userID string, name string) (*domain.Tag, error) {
@@ -94,6 +97,9 @@ func (t *TagUsecaseMock) RejectTag(ctx context.Context, tagID string) (*domain.T
 	return nil, nil
 }
 func (t *TagUsecaseMock) ListTags(ctx context.Context, status domain.TagStatus) ([]domain.Tag, error) {
+	if t.ListTagsFn != nil {
+		return t.ListTagsFn(ctx, status)
+	}
 	return nil, nil
 }
 func (t *TagUsecaseMock) DeleteTag(ctx context.Context, tagID string) error { return nil }
@@ -110,21 +116,48 @@ func (t *TagUsecaseMock) ValidateTags(tags []string) error {
 	return nil
 }
 
+func (t *TagUsecaseMock) CanonicalTags(tags []string) []string {
+	if t.CanonicalTagsFn != nil {
+		return t.CanonicalTagsFn(tags)
+	}
+	return tags
+}
+func (t *TagUsecaseMock) ExpandTags(tags []string) []string {
+	if t.ExpandTagsFn != nil {
+		return t.ExpandTagsFn(tags)
+	}
+	return tags
+}
+func (t *TagUsecaseMock) AddSynonym(ctx context.Context, tagID, synonym string) (*domain.Tag, error) {
+	return nil, nil
+}
+func (t *TagUsecaseMock) RemoveSynonym(ctx context.Context, tagID, synonym string) (*domain.Tag, error) {
+	return nil, nil
+}
+func (t *TagUsecaseMock) SetParent(ctx context.Context, tagID, parentID string) (*domain.Tag, error) {
+	return nil, nil
+}
+func (t *TagUsecaseMock) MergeTags(ctx context.Context, sourceID, targetID string) (*domain.TagMergeResult, error) {
+	return nil, nil
+}
+
 // View usecase mock
 type ViewUsecaseMock struct {
-	RecordViewFn func(ctx context.Context, userID, articleID, clientIP string) error
+	RecordViewFn func(ctx context.Context, article *domain.Article, reader domain.ViewReader) error
 }
 
-func (v *ViewUsecaseMock) RecordView(ctx context.Context, userID, articleID, clientIP string) error {
+func (v *ViewUsecaseMock) RecordView(ctx context.Context, article *domain.Article, reader domain.ViewReader) error {
 	if v.RecordViewFn != nil {
-		return v.RecordViewFn(ctx, userID, articleID, clientIP)
+		return v.RecordViewFn(ctx, article, reader)
 	}
 	return nil
 }
 
 // Clap usecase mock
 type ClapUsecaseMock struct {
-	AddClapFn func(ctx context.Context, userID, articleID string) (int, error)
+	AddClapFn      func(ctx context.Context, userID, articleID string) (int, error)
+	RemoveClapsFn  func(ctx context.Context, userID, articleID string) (int, error)
+	GetUserClapsFn func(ctx context.Context, userID, articleID string) (domain.UserClapState, error)
 }
 
 func (c *ClapUsecaseMock) AddClap(ctx context.Context, userID, articleID string) (int, error) {
@@ -133,3 +166,17 @@ func (c *ClapUsecaseMock) AddClap(ctx context.Context, userID, articleID string)
 	}
 	return 0, nil
 }
+
+func (c *ClapUsecaseMock) RemoveClaps(ctx context.Context, userID, articleID string) (int, error) {
+	if c.RemoveClapsFn != nil {
+		return c.RemoveClapsFn(ctx, userID, articleID)
+	}
+	return 0, nil
+}
+
+func (c *ClapUsecaseMock) GetUserClaps(ctx context.Context, userID, articleID string) (domain.UserClapState, error) {
+	if c.GetUserClapsFn != nil {
+		return c.GetUserClapsFn(ctx, userID, articleID)
+	}
+	return domain.UserClapState{Max: domain.MaxClapsPerUser}, nil
+}
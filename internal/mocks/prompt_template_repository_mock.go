@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+// PromptTemplateRepositoryMock implements domain.IPromptTemplateRepository with pluggable funcs.
+type PromptTemplateRepositoryMock struct {
+	CreateFn       func(ctx context.Context, tpl *domain.PromptTemplate) error
+	GetLatestFn    func(ctx context.Context, name string) (*domain.PromptTemplate, error)
+	ListVersionsFn func(ctx context.Context, name string) ([]domain.PromptTemplate, error)
+}
+
+func (m *PromptTemplateRepositoryMock) Create(ctx context.Context, tpl *domain.PromptTemplate) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, tpl)
+	}
+	return nil
+}
+func (m *PromptTemplateRepositoryMock) GetLatest(ctx context.Context, name string) (*domain.PromptTemplate, error) {
+	if m.GetLatestFn != nil {
+		return m.GetLatestFn(ctx, name)
+	}
+	return nil, domain.ErrPromptTemplateNotFound
+}
+func (m *PromptTemplateRepositoryMock) ListVersions(ctx context.Context, name string) ([]domain.PromptTemplate, error) {
+	if m.ListVersionsFn != nil {
+		return m.ListVersionsFn(ctx, name)
+	}
+	return nil, nil
+}
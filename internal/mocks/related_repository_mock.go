@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+// RelatedRepositoryMock implements domain.IRelatedRepository with pluggable funcs.
+type RelatedRepositoryMock struct {
+	TagAuthorCandidatesFn func(ctx context.Context, article domain.Article, limit int) ([]domain.Article, error)
+	CoEngagedFn           func(ctx context.Context, articleID string, limit int) (map[string]int, error)
+}
+
+var _ domain.IRelatedRepository = (*RelatedRepositoryMock)(nil)
+
+func (m *RelatedRepositoryMock) TagAuthorCandidates(ctx context.Context, article domain.Article, limit int) ([]domain.Article, error) {
+	if m.TagAuthorCandidatesFn != nil {
+		return m.TagAuthorCandidatesFn(ctx, article, limit)
+	}
+	return nil, nil
+}
+func (m *RelatedRepositoryMock) CoEngaged(ctx context.Context, articleID string, limit int) (map[string]int, error) {
+	if m.CoEngagedFn != nil {
+		return m.CoEngagedFn(ctx, articleID, limit)
+	}
+	return map[string]int{}, nil
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+// SearchIndexMock implements domain.ISearchIndex with pluggable funcs.
+type SearchIndexMock struct {
+	SearchFn func(ctx context.Context, query domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error)
+	FacetsFn func(ctx context.Context, query domain.SearchQuery) (*domain.ArticleFacets, error)
+	IndexFn  func(ctx context.Context, articles ...domain.Article) error
+	RemoveFn func(ctx context.Context, articleID string) error
+	ResetFn  func(ctx context.Context) error
+}
+
+var _ domain.ISearchIndex = (*SearchIndexMock)(nil)
+
+func (m *SearchIndexMock) Search(ctx context.Context, query domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error) {
+	if m.SearchFn != nil {
+		return m.SearchFn(ctx, query, pag)
+	}
+	return nil, 0, nil
+}
+func (m *SearchIndexMock) Facets(ctx context.Context, query domain.SearchQuery) (*domain.ArticleFacets, error) {
+	if m.FacetsFn != nil {
+		return m.FacetsFn(ctx, query)
+	}
+	return &domain.ArticleFacets{}, nil
+}
+func (m *SearchIndexMock) Index(ctx context.Context, articles ...domain.Article) error {
+	if m.IndexFn != nil {
+		return m.IndexFn(ctx, articles...)
+	}
+	return nil
+}
+func (m *SearchIndexMock) Remove(ctx context.Context, articleID string) error {
+	if m.RemoveFn != nil {
+		return m.RemoveFn(ctx, articleID)
+	}
+	return nil
+}
+func (m *SearchIndexMock) Reset(ctx context.Context) error {
+	if m.ResetFn != nil {
+		return m.ResetFn(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+// SuggestRepositoryMock implements domain.ISuggestRepository with a pluggable func.
+type SuggestRepositoryMock struct {
+	SuggestionCandidatesFn func(ctx context.Context) ([]domain.Suggestion, error)
+}
+
+var _ domain.ISuggestRepository = (*SuggestRepositoryMock)(nil)
+
+func (m *SuggestRepositoryMock) SuggestionCandidates(ctx context.Context) ([]domain.Suggestion, error) {
+	if m.SuggestionCandidatesFn != nil {
+		return m.SuggestionCandidatesFn(ctx)
+	}
+	return nil, nil
+}
+
+// SuggestUsecaseMock implements domain.ISuggestUsecase with pluggable funcs.
+type SuggestUsecaseMock struct {
+	SuggestFn func(ctx context.Context, prefix string, limits domain.SuggestLimits) (*domain.Suggestions, error)
+	RefreshFn func(ctx context.Context) (int, error)
+}
+
+var _ domain.ISuggestUsecase = (*SuggestUsecaseMock)(nil)
+
+func (m *SuggestUsecaseMock) Suggest(ctx context.Context, prefix string, limits domain.SuggestLimits) (*domain.Suggestions, error) {
+	if m.SuggestFn != nil {
+		return m.SuggestFn(ctx, prefix, limits)
+	}
+	return &domain.Suggestions{}, nil
+}
+func (m *SuggestUsecaseMock) Refresh(ctx context.Context) (int, error) {
+	if m.RefreshFn != nil {
+		return m.RefreshFn(ctx)
+	}
+	return 0, nil
+}
@@ -0,0 +1,112 @@
+package mocks
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+// TagFollowRepositoryMock implements domain.ITagFollowRepository with pluggable funcs.
+type TagFollowRepositoryMock struct {
+	FollowFn         func(ctx context.Context, follow *domain.TagFollow) error
+	UnfollowFn       func(ctx context.Context, userID, tagID string) error
+	ListByUserFn     func(ctx context.Context, userID string) ([]domain.TagFollow, error)
+	FollowerCountsFn func(ctx context.Context, tagIDs []string) (map[string]int, error)
+	FollowerIDsFn    func(ctx context.Context, tagNames []string) ([]string, error)
+	MoveFollowsFn    func(ctx context.Context, fromTagID, toTagID, toTagName string) error
+}
+
+var _ domain.ITagFollowRepository = (*TagFollowRepositoryMock)(nil)
+
+func (m *TagFollowRepositoryMock) Follow(ctx context.Context, follow *domain.TagFollow) error {
+	if m.FollowFn != nil {
+		return m.FollowFn(ctx, follow)
+	}
+	return nil
+}
+func (m *TagFollowRepositoryMock) Unfollow(ctx context.Context, userID, tagID string) error {
+	if m.UnfollowFn != nil {
+		return m.UnfollowFn(ctx, userID, tagID)
+	}
+	return nil
+}
+func (m *TagFollowRepositoryMock) ListByUser(ctx context.Context, userID string) ([]domain.TagFollow, error) {
+	if m.ListByUserFn != nil {
+		return m.ListByUserFn(ctx, userID)
+	}
+	return nil, nil
+}
+func (m *TagFollowRepositoryMock) FollowerCounts(ctx context.Context, tagIDs []string) (map[string]int, error) {
+	if m.FollowerCountsFn != nil {
+		return m.FollowerCountsFn(ctx, tagIDs)
+	}
+	return map[string]int{}, nil
+}
+func (m *TagFollowRepositoryMock) FollowerIDs(ctx context.Context, tagNames []string) ([]string, error) {
+	if m.FollowerIDsFn != nil {
+		return m.FollowerIDsFn(ctx, tagNames)
+	}
+	return nil, nil
+}
+
+func (m *TagFollowRepositoryMock) MoveFollows(ctx context.Context, fromTagID, toTagID, toTagName string) error {
+	if m.MoveFollowsFn != nil {
+		return m.MoveFollowsFn(ctx, fromTagID, toTagID, toTagName)
+	}
+	return nil
+}
+
+// TagRepositoryMock implements domain.TagRepository with pluggable funcs.
+type TagRepositoryMock struct {
+	CreateFn       func(ctx context.Context, tag *domain.Tag) (*domain.Tag, error)
+	UpdateFn       func(ctx context.Context, tag *domain.Tag) (*domain.Tag, error)
+	GetByIDFn      func(ctx context.Context, id string) (*domain.Tag, error)
+	GetByNameFn    func(ctx context.Context, name string) (*domain.Tag, error)
+	GetBySynonymFn func(ctx context.Context, synonym string) (*domain.Tag, error)
+	ListFn         func(ctx context.Context, filter domain.TagFilter) ([]domain.Tag, error)
+	DeleteFn       func(ctx context.Context, id string) error
+}
+
+var _ domain.TagRepository = (*TagRepositoryMock)(nil)
+
+func (m *TagRepositoryMock) Create(ctx context.Context, tag *domain.Tag) (*domain.Tag, error) {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, tag)
+	}
+	return tag, nil
+}
+func (m *TagRepositoryMock) Update(ctx context.Context, tag *domain.Tag) (*domain.Tag, error) {
+	if m.UpdateFn != nil {
+		return m.UpdateFn(ctx, tag)
+	}
+	return tag, nil
+}
+func (m *TagRepositoryMock) GetByID(ctx context.Context, id string) (*domain.Tag, error) {
+	if m.GetByIDFn != nil {
+		return m.GetByIDFn(ctx, id)
+	}
+	return nil, domain.ErrTagNotFound
+}
+func (m *TagRepositoryMock) GetByName(ctx context.Context, name string) (*domain.Tag, error) {
+	if m.GetByNameFn != nil {
+		return m.GetByNameFn(ctx, name)
+	}
+	return nil, domain.ErrTagNotFound
+}
+func (m *TagRepositoryMock) GetBySynonym(ctx context.Context, synonym string) (*domain.Tag, error) {
+	if m.GetBySynonymFn != nil {
+		return m.GetBySynonymFn(ctx, synonym)
+	}
+	return nil, domain.ErrTagNotFound
+}
+func (m *TagRepositoryMock) List(ctx context.Context, filter domain.TagFilter) ([]domain.Tag, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx, filter)
+	}
+	return nil, nil
+}
+func (m *TagRepositoryMock) Delete(ctx context.Context, id string) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, id)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+	"time"
+	"write_base/internal/domain"
+)
+
+// TrendingRepositoryMock implements domain.ITrendingRepository with pluggable funcs.
+type TrendingRepositoryMock struct {
+	EngagementSinceFn    func(ctx context.Context, since time.Time) ([]domain.EngagementBucket, error)
+	SaveTrendingScoresFn func(ctx context.Context, scores map[string]float64) error
+}
+
+var _ domain.ITrendingRepository = (*TrendingRepositoryMock)(nil)
+
+func (m *TrendingRepositoryMock) EngagementSince(ctx context.Context, since time.Time) ([]domain.EngagementBucket, error) {
+	if m.EngagementSinceFn != nil {
+		return m.EngagementSinceFn(ctx, since)
+	}
+	return nil, nil
+}
+func (m *TrendingRepositoryMock) SaveTrendingScores(ctx context.Context, scores map[string]float64) error {
+	if m.SaveTrendingScoresFn != nil {
+		return m.SaveTrendingScoresFn(ctx, scores)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"write_base/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AICacheRepositoryImpl is a MongoDB-backed domain.IAICache shared by every
+// API instance. Hit/miss counters live in a single stats document.
+type AICacheRepositoryImpl struct {
+	collection *mongo.Collection
+	stats      *mongo.Collection
+	ttl        time.Duration
+	maxEntries int64
+}
+
+type AICacheEntryDTO struct {
+	Key       string    `bson:"_id"`
+	Value     string    `bson:"value"`
+	CreatedAt time.Time `bson:"created_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+type AICacheStatsDTO struct {
+	Hits   int64 `bson:"hits"`
+	Misses int64 `bson:"misses"`
+}
+
+const aiCacheStatsID = "global"
+
+// NewAICacheRepository keeps entries until their TTL passes. The maxEntries cap
+// is enforced by Trim, which the caller runs periodically so writes stay cheap.
+func NewAICacheRepository(db *mongo.Database, ttl time.Duration, maxEntries int64) *AICacheRepositoryImpl {
+	collection := db.Collection("ai_cache")
+
+	// Let MongoDB drop entries once they pass their expiry time
+	collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.M{"expires_at": 1},
+			Options: options.Index().SetExpireAfterSeconds(0).SetName("ttl_expires_at"),
+		},
+		{
+			Keys:    bson.M{"created_at": 1},
+			Options: options.Index().SetName("created_at"),
+		},
+	})
+
+	return &AICacheRepositoryImpl{
+		collection: collection,
+		stats:      db.Collection("ai_cache_stats"),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+	}
+}
+
+func (r *AICacheRepositoryImpl) record(ctx context.Context, field string) {
+	_, _ = r.stats.UpdateByID(ctx, aiCacheStatsID, bson.M{"$inc": bson.M{field: 1}}, options.Update().SetUpsert(true))
+}
+
+func (r *AICacheRepositoryImpl) Get(ctx context.Context, key string) (string, bool, error) {
+	// The TTL monitor only runs periodically, so filter out expired entries here too
+	filter := bson.M{"_id": key, "expires_at": bson.M{"$gt": time.Now()}}
+
+	var dto AICacheEntryDTO
+	err := r.collection.FindOne(ctx, filter).Decode(&dto)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			r.record(ctx, "misses")
+			return "", false, nil
+		}
+		return "", false, domain.ErrInternalServer
+	}
+	r.record(ctx, "hits")
+	return dto.Value, true, nil
+}
+
+func (r *AICacheRepositoryImpl) Set(ctx context.Context, key, value string) error {
+	now := time.Now()
+	dto := AICacheEntryDTO{Key: key, Value: value, CreatedAt: now, ExpiresAt: now.Add(r.ttl)}
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": key}, dto, options.Replace().SetUpsert(true))
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+// Trim removes the oldest entries once the collection exceeds maxEntries and
+// returns how many it removed. The size comes from collection metadata, so the
+// cap can be overshot briefly between runs.
+func (r *AICacheRepositoryImpl) Trim(ctx context.Context) (int64, error) {
+	if r.maxEntries <= 0 {
+		return 0, nil
+	}
+	count, err := r.collection.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return 0, domain.ErrInternalServer
+	}
+	overflow := count - r.maxEntries
+	if overflow <= 0 {
+		return 0, nil
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetLimit(overflow).
+		SetProjection(bson.M{"_id": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return 0, domain.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var keys []string
+	for cursor.Next(ctx) {
+		var dto AICacheEntryDTO
+		if err := cursor.Decode(&dto); err != nil {
+			return 0, domain.ErrInternalServer
+		}
+		keys = append(keys, dto.Key)
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	res, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": keys}})
+	if err != nil {
+		return 0, domain.ErrInternalServer
+	}
+	return res.DeletedCount, nil
+}
+
+func (r *AICacheRepositoryImpl) Stats(ctx context.Context) (*domain.AICacheStats, error) {
+	entries, err := r.collection.CountDocuments(ctx, bson.M{"expires_at": bson.M{"$gt": time.Now()}})
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+
+	var dto AICacheStatsDTO
+	err = r.stats.FindOne(ctx, bson.M{"_id": aiCacheStatsID}).Decode(&dto)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, domain.ErrInternalServer
+	}
+	return &domain.AICacheStats{
+		Backend: "mongo",
+		Entries: entries,
+		Hits:    dto.Hits,
+		Misses:  dto.Misses,
+	}, nil
+}
@@ -18,9 +18,12 @@ type ArticleDTO struct {
 	Excerpt       string              `bson:"excerpt"`
 	Language      string              `bson:"language"`
 	Tags          []string            `bson:"tags"`
+	SEO           ArticleSEODTO       `bson:"seo"`
 	Status        string              `bson:"status"`
 	Stats         ArticleStatsDTO     `bson:"stats"`
 	Timestamps    ArticleTimesDTO     `bson:"timestamps"`
+	// ReadingTime is derived from the content on every write, for the reading-time facet
+	ReadingTime   int                 `bson:"reading_time"`
 }
 
 type ArticleListDTO struct {
@@ -30,12 +33,25 @@ type ArticleListDTO struct {
 	AuthorID      string              `bson:"author_id"`
 	Excerpt       string              `bson:"excerpt"`
 	Status        string              `bson:"status"`
+	Stats         ArticleStatsDTO     `bson:"stats"`
+	Timestamps    ArticleTimesDTO     `bson:"timestamps"`
 }
 
 // =================== Article List DTO (for list fetch) ===================
 type ArticleStatsDTO struct {
-	ViewsCount int `bson:"view_count"`
-	ClapCount  int `bson:"clap_count"`
+	ViewsCount      int     `bson:"view_count"`
+	UniqueViewCount int     `bson:"unique_view_count"`
+	ClapCount       int     `bson:"clap_count"`
+	CommentCount    int     `bson:"comment_count"`
+	ReactionCount   int     `bson:"reaction_count"`
+	TrendingScore   float64 `bson:"trending_score"`
+}
+type ArticleSEODTO struct {
+	MetaTitle       string   `bson:"meta_title,omitempty"`
+	MetaDescription string   `bson:"meta_description,omitempty"`
+	Keywords        []string `bson:"keywords,omitempty"`
+	CanonicalURL    string   `bson:"canonical_url,omitempty"`
+	OGImage         string   `bson:"og_image,omitempty"`
 }
 type ArticleTimesDTO struct {
 	CreatedAt   time.Time  `bson:"created_at"`
@@ -63,8 +79,13 @@ type HeadingContentDTO struct {
 	Level int    `bson:"level"`
 }
 type ParagraphContentDTO struct {
-	Text  string `bson:"text"`
-	Style string `bson:"style"`
+	Text     string       `bson:"text"`
+	Style    string       `bson:"style"`
+	Mentions []MentionDTO `bson:"mentions,omitempty"`
+}
+type MentionDTO struct {
+	UserID   string `bson:"user_id"`
+	Username string `bson:"username"`
 }
 type ImageContentDTO struct {
 	URL      string `bson:"url"`
@@ -101,9 +122,11 @@ func ToArticleDTO(article *domain.Article) *ArticleDTO {
 		Excerpt:       article.Excerpt,
 		Language:      article.Language,
 		Tags:          article.Tags,
+		SEO:           ToArticleSEODTO(article.SEO),
 		Status:        string(article.Status),
 		Stats:         ToArticleStatsDTO(article.Stats),
 		Timestamps:    ToArticleTimesDTO(article.Timestamps),
+		ReadingTime:   domain.ReadingTimeMinutes(*article),
 	}
 }
 func (ad *ArticleDTO) ToDomain() *domain.Article {
@@ -119,6 +142,7 @@ func (ad *ArticleDTO) ToDomain() *domain.Article {
 		Excerpt:       ad.Excerpt,
 		Language:      ad.Language,
 		Tags:          ad.Tags,
+		SEO:           FromArticleSEODTO(ad.SEO),
 		Status:        domain.ArticleStatus(ad.Status),
 		Stats:         FromArticleStatsDTO(ad.Stats),
 		Timestamps:    FromArticleTimesDTO(ad.Timestamps),
@@ -166,9 +190,17 @@ func ToParagraphContentDTO(content *domain.ParagraphContent) *ParagraphContentDT
 		return nil
 	}
 	return &ParagraphContentDTO{
-		Text:  content.Text,
-		Style: content.Style,
+		Text:     content.Text,
+		Style:    content.Style,
+		Mentions: ToMentionDTOs(content.Mentions),
+	}
+}
+func ToMentionDTOs(mentions []domain.Mention) []MentionDTO {
+	var out []MentionDTO
+	for _, m := range mentions {
+		out = append(out, MentionDTO{UserID: m.UserID, Username: m.Username})
 	}
+	return out
 }
 func ToImageContentDTO(content *domain.ImageContent) *ImageContentDTO {
 	if content == nil {
@@ -216,8 +248,21 @@ func ToDividerContentDTO(content *domain.DividerContent) *DividerContentDTO {
 }
 func ToArticleStatsDTO(stats domain.ArticleStats) ArticleStatsDTO {
 	return ArticleStatsDTO{
-		ViewsCount: stats.ViewCount,
-		ClapCount:  stats.ClapCount,
+		ViewsCount:      stats.ViewCount,
+		UniqueViewCount: stats.UniqueViewCount,
+		ClapCount:       stats.ClapCount,
+		CommentCount:    stats.CommentCount,
+		ReactionCount:   stats.ReactionCount,
+		TrendingScore:   stats.TrendingScore,
+	}
+}
+func ToArticleSEODTO(seo domain.ArticleSEO) ArticleSEODTO {
+	return ArticleSEODTO{
+		MetaTitle:       seo.MetaTitle,
+		MetaDescription: seo.MetaDescription,
+		Keywords:        seo.Keywords,
+		CanonicalURL:    seo.CanonicalURL,
+		OGImage:         seo.OGImage,
 	}
 }
 func ToArticleTimesDTO(times domain.ArticleTimes) ArticleTimesDTO {
@@ -251,6 +296,8 @@ func FromArticleListDTO(dto *ArticleListDTO) *domain.Article {
 		AuthorID:      dto.AuthorID,
 		Excerpt:       dto.Excerpt,
 		Status:       domain.ArticleStatus(dto.Status),
+		Stats:        FromArticleStatsDTO(dto.Stats),
+		Timestamps:   FromArticleTimesDTO(dto.Timestamps),
 	}
 }
 
@@ -264,6 +311,7 @@ func FromArticleDTO(dto *ArticleDTO) *domain.Article {
 		Excerpt:       dto.Excerpt,
 		Language:      dto.Language,
 		Tags:          dto.Tags,
+		SEO:          FromArticleSEODTO(dto.SEO),
 		Status:       domain.ArticleStatus(dto.Status),
 		Stats:        FromArticleStatsDTO(dto.Stats),
 		Timestamps:   FromArticleTimesDTO(dto.Timestamps),
@@ -302,9 +350,17 @@ func FromParagraphContentDTO(dto *ParagraphContentDTO) *domain.ParagraphContent
 		return nil
 	}
 	return &domain.ParagraphContent{
-		Text:  dto.Text,
-		Style: dto.Style,
+		Text:     dto.Text,
+		Style:    dto.Style,
+		Mentions: FromMentionDTOs(dto.Mentions),
+	}
+}
+func FromMentionDTOs(dtos []MentionDTO) []domain.Mention {
+	var out []domain.Mention
+	for _, m := range dtos {
+		out = append(out, domain.Mention{UserID: m.UserID, Username: m.Username})
 	}
+	return out
 }
 func FromImageContentDTO(dto *ImageContentDTO) *domain.ImageContent {
 	if dto == nil {
@@ -352,8 +408,21 @@ func FromDividerContentDTO(dto *DividerContentDTO) *domain.DividerContent {
 }
 func FromArticleStatsDTO(dto ArticleStatsDTO) domain.ArticleStats {
 	return domain.ArticleStats{
-		ViewCount: dto.ViewsCount,
-		ClapCount: dto.ClapCount,
+		ViewCount:       dto.ViewsCount,
+		UniqueViewCount: dto.UniqueViewCount,
+		ClapCount:       dto.ClapCount,
+		CommentCount:    dto.CommentCount,
+		ReactionCount:   dto.ReactionCount,
+		TrendingScore:   dto.TrendingScore,
+	}
+}
+func FromArticleSEODTO(dto ArticleSEODTO) domain.ArticleSEO {
+	return domain.ArticleSEO{
+		MetaTitle:       dto.MetaTitle,
+		MetaDescription: dto.MetaDescription,
+		Keywords:        dto.Keywords,
+		CanonicalURL:    dto.CanonicalURL,
+		OGImage:         dto.OGImage,
 	}
 }
 func FromArticleTimesDTO(dto ArticleTimesDTO) domain.ArticleTimes {
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"math"
 	"time"
 	"write_base/internal/domain"
 
@@ -84,6 +85,48 @@ func buildArticleFilterQuery(authorID string, filter domain.ArticleFilter) bson.
 	return q
 }
 
+// paginateArticles applies page (skip) or cursor (keyset) pagination to query.
+// A cursor carries its own sort; otherwise pag's sort applies, falling back to
+// defaultField descending. _id is always the tie-breaker so pages are stable.
+func paginateArticles(query bson.M, pag domain.Pagination, defaultField string) (bson.M, *options.FindOptions, error) {
+	field, order, err := pag.EffectiveSort()
+	if err != nil {
+		return nil, nil, err
+	}
+	if field == "" {
+		field, order = defaultField, "desc"
+	}
+	dir, cmp := 1, "$gt"
+	if order == "desc" {
+		dir, cmp = -1, "$lt"
+	}
+
+	opts := options.Find().
+		SetLimit(int64(pag.PageSize)).
+		SetSort(bson.D{{Key: field, Value: dir}, {Key: "_id", Value: dir}})
+
+	if pag.Cursor == "" {
+		return query, opts.SetSkip(int64((pag.Page - 1) * pag.PageSize)), nil
+	}
+
+	c, err := domain.DecodeArticleCursor(pag.Cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := c.TypedValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	after := bson.M{"$or": []bson.M{
+		{field: bson.M{cmp: value}},
+		{field: value, "_id": bson.M{cmp: c.ID}},
+	}}
+	if len(query) == 0 {
+		return after, opts, nil
+	}
+	return bson.M{"$and": []bson.M{query, after}}, opts, nil
+}
+
 // ===============================================================================//
 //
 //	CRUD                                           //
@@ -107,8 +150,25 @@ func (ar *ArticleRepository) Update(ctx context.Context, article *domain.Article
 	if articleDTO == nil {
 		return domain.ErrInternalServer
 	}
+	updatedAt := articleDTO.Timestamps.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+	// Only what an author edits is written; status, stats and the other
+	// timestamps are owned by their own operations
+	update := bson.M{"$set": bson.M{
+		"title":                 articleDTO.Title,
+		"slug":                  articleDTO.Slug,
+		"excerpt":               articleDTO.Excerpt,
+		"language":              articleDTO.Language,
+		"tags":                  articleDTO.Tags,
+		"content_blocks":        articleDTO.ContentBlocks,
+		"seo":                   articleDTO.SEO,
+		"reading_time":          articleDTO.ReadingTime,
+		"timestamps.updated_at": updatedAt,
+	}}
 	filter := bson.M{"_id": articleDTO.ID}
-	if _, err := ar.Collection.UpdateOne(ctx, filter, bson.M{"$set": articleDTO}); err != nil {
+	if _, err := ar.Collection.UpdateOne(ctx, filter, update); err != nil {
 		return domain.ErrInternalServer
 	}
 	return nil
@@ -193,6 +253,28 @@ func (ar *ArticleRepository) GetByID(ctx context.Context, articleID string) (*do
 	return FromArticleDTO(&articleDTO), nil
 }
 
+// GetByIDs loads full articles for the given IDs; missing IDs are skipped and
+// the result is in no particular order.
+func (ar *ArticleRepository) GetByIDs(ctx context.Context, articleIDs []string) ([]domain.Article, error) {
+	if len(articleIDs) == 0 {
+		return nil, nil
+	}
+	cursor, err := ar.Collection.Find(ctx, bson.M{"_id": bson.M{"$in": articleIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	articles := make([]domain.Article, 0, len(articleIDs))
+	for cursor.Next(ctx) {
+		var articleDTO ArticleDTO
+		if err := cursor.Decode(&articleDTO); err != nil {
+			return nil, err
+		}
+		articles = append(articles, *FromArticleDTO(&articleDTO))
+	}
+	return articles, cursor.Err()
+}
+
 // =============================== Article Stats ================================
 func (ar *ArticleRepository) GetStats(ctx context.Context, articleID string) (*domain.ArticleStats, error) {
 	opts := options.FindOne().SetProjection(bson.M{"stats": 1})
@@ -209,6 +291,18 @@ func (ar *ArticleRepository) GetStats(ctx context.Context, articleID string) (*d
 	return &stats, nil
 }
 
+// MarkClapMilestone adds the milestone to the article's notified_milestones
+// unless it is already there, so undoing and repeating claps announce it once.
+func (r *ArticleRepository) MarkClapMilestone(ctx context.Context, articleID string, milestone int) (bool, error) {
+	res, err := r.Collection.UpdateOne(ctx,
+		bson.M{"_id": articleID, "notified_milestones": bson.M{"$ne": milestone}},
+		bson.M{"$addToSet": bson.M{"notified_milestones": milestone}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
 // =================== All Article Stats of Author ================================
 func (ar *ArticleRepository) GetAllArticleStats(ctx context.Context, userID string) ([]domain.ArticleStats, int, error) {
 	opts := options.Find().SetProjection(bson.M{"stats": 1})
@@ -264,23 +358,10 @@ func (ar *ArticleRepository) GetBySlug(ctx context.Context, slug string) (*domai
 func (ar *ArticleRepository) ListByAuthor(ctx context.Context, authorID string, pag domain.Pagination) ([]domain.Article, int, error) {
 	var articles []domain.Article
 
-	// Build query
-	query := bson.M{"author_id": authorID, "status": domain.StatusPublished}
-
-	// Build options with pagination and sorting
-	opts := options.Find().
-		SetSkip(int64((pag.Page - 1) * pag.PageSize)).
-		SetLimit(int64(pag.PageSize))
-
-	// Add sorting if specified
-	if pag.SortField != "" {
-		sortOrder := 1
-		if pag.SortOrder == "desc" {
-			sortOrder = -1
-		}
-		opts = opts.SetSort(bson.D{{Key: pag.SortField, Value: sortOrder}})
-	} else {
-		opts = opts.SetSort(bson.D{{Key: "timestamps.created_at", Value: -1}})
+	// Build query with pagination and sorting
+	query, opts, err := paginateArticles(bson.M{"author_id": authorID, "status": domain.StatusPublished}, pag, domain.SortByCreatedAt)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	cursor, err := ar.Collection.Find(ctx, query, opts)
@@ -302,6 +383,11 @@ func (ar *ArticleRepository) ListByAuthor(ctx context.Context, authorID string,
 		return nil, 0, err
 	}
 
+	// Cursor mode skips the count; next_cursor tells clients whether to continue
+	if pag.Cursor != "" {
+		return articles, 0, nil
+	}
+
 	total, err := ar.Collection.CountDocuments(ctx, query)
 	if err != nil {
 		return nil, 0, err
@@ -315,26 +401,16 @@ func (ar *ArticleRepository) ListByAuthor(ctx context.Context, authorID string,
 }
 
 // ======================= List Trending articles ==================================
-func (ar *ArticleRepository) FindTrending(ctx context.Context, windowDays int, pag domain.Pagination) ([]domain.Article, int, error) {
+func (ar *ArticleRepository) FindTrending(ctx context.Context, window time.Duration, pag domain.Pagination) ([]domain.Article, int, error) {
 	var articles []domain.Article
-	windowAgo := time.Now().AddDate(0, 0, -windowDays)
-	query := bson.M{
+	windowAgo := time.Now().Add(-window)
+	// Build query with pagination and sorting
+	query, opts, err := paginateArticles(bson.M{
 		"status":                  string(domain.StatusPublished),
 		"timestamps.published_at": bson.M{"$gte": windowAgo},
-	}
-
-	// Build options with pagination and sorting
-	opts := options.Find().
-		SetSkip(int64((pag.Page - 1) * pag.PageSize)).
-		SetLimit(int64(pag.PageSize)).
-		SetSort(bson.D{{Key: "stats.view_count", Value: -1}})
-
-	if pag.SortField != "" {
-		sortOrder := 1 // ascending by default
-		if pag.SortOrder == "desc" {
-			sortOrder = -1
-		}
-		opts = opts.SetSort(bson.D{{Key: pag.SortField, Value: sortOrder}})
+	}, pag, domain.SortByTrending)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	cursor, err := ar.Collection.Find(ctx, query, opts)
@@ -356,6 +432,11 @@ func (ar *ArticleRepository) FindTrending(ctx context.Context, windowDays int, p
 		return nil, 0, err
 	}
 
+	// Cursor mode skips the count; next_cursor tells clients whether to continue
+	if pag.Cursor != "" {
+		return articles, 0, nil
+	}
+
 	total, err := ar.Collection.CountDocuments(ctx, query)
 	if err != nil {
 		return nil, 0, err
@@ -371,21 +452,11 @@ func (ar *ArticleRepository) FindTrending(ctx context.Context, windowDays int, p
 func (ar *ArticleRepository) FindNewArticles(ctx context.Context, pag domain.Pagination) ([]domain.Article, int, error) {
 	var articles []domain.Article
 
-	filter := bson.M{
+	filter, opts, err := paginateArticles(bson.M{
 		"status": domain.StatusPublished,
-	}
-
-	opts := options.Find().
-		SetSkip(int64((pag.Page - 1) * pag.PageSize)).
-		SetLimit(int64(pag.PageSize)).
-		SetSort(bson.D{{Key: "timestamps.published_at", Value: -1}})
-
-	if pag.SortField != "" {
-		sortOrder := 1
-		if pag.SortOrder == "desc" {
-			sortOrder = -1
-		}
-		opts = opts.SetSort(bson.D{{Key: pag.SortField, Value: sortOrder}})
+	}, pag, domain.SortByPublishedAt)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	cursor, err := ar.Collection.Find(ctx, filter, opts)
@@ -403,6 +474,11 @@ func (ar *ArticleRepository) FindNewArticles(ctx context.Context, pag domain.Pag
 		articles = append(articles, *article)
 	}
 
+	// Cursor mode skips the count; next_cursor tells clients whether to continue
+	if pag.Cursor != "" {
+		return articles, 0, nil
+	}
+
 	total, err := ar.Collection.CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, 0, err
@@ -419,21 +495,11 @@ func (ar *ArticleRepository) FindNewArticles(ctx context.Context, pag domain.Pag
 func (ar *ArticleRepository) FindPopularArticles(ctx context.Context, pag domain.Pagination) ([]domain.Article, int, error) {
 	var articles []domain.Article
 
-	filter := bson.M{
+	filter, opts, err := paginateArticles(bson.M{
 		"status": domain.StatusPublished,
-	}
-
-	opts := options.Find().
-		SetSkip(int64((pag.Page - 1) * pag.PageSize)).
-		SetLimit(int64(pag.PageSize)).
-		SetSort(bson.D{{Key: "stats.view_count", Value: -1}})
-
-	if pag.SortField != "" {
-		sortOrder := 1 // ascending by default
-		if pag.SortOrder == "desc" {
-			sortOrder = -1
-		}
-		opts = opts.SetSort(bson.D{{Key: pag.SortField, Value: sortOrder}})
+	}, pag, domain.SortByViewCount)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	cursor, err := ar.Collection.Find(ctx, filter, opts)
@@ -451,6 +517,11 @@ func (ar *ArticleRepository) FindPopularArticles(ctx context.Context, pag domain
 		articles = append(articles, *article)
 	}
 
+	// Cursor mode skips the count; next_cursor tells clients whether to continue
+	if pag.Cursor != "" {
+		return articles, 0, nil
+	}
+
 	total, err := ar.Collection.CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, 0, err
@@ -536,23 +607,9 @@ func (ar *ArticleRepository) Filter(ctx context.Context, filter domain.ArticleFi
 	}
 
 	// build query; empty author ID means global filter
-	query := buildArticleFilterQuery("", filter)
-
-	// Build options with pagination and sorting
-	opts := options.Find().
-		SetSkip(int64((pag.Page - 1) * pag.PageSize)).
-		SetLimit(int64(pag.PageSize))
-
-	// Add sorting if specified
-	if pag.SortField != "" {
-		sortOrder := 1 // ascending by default
-		if pag.SortOrder == "desc" {
-			sortOrder = -1
-		}
-		opts = opts.SetSort(bson.D{{Key: pag.SortField, Value: sortOrder}})
-	} else {
-		// Default sorting by created_at descending
-		opts = opts.SetSort(bson.D{{Key: "timestamps.created_at", Value: -1}})
+	query, opts, err := paginateArticles(buildArticleFilterQuery("", filter), pag, domain.SortByCreatedAt)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	cursor, err := ar.Collection.Find(ctx, query, opts)
@@ -577,6 +634,11 @@ func (ar *ArticleRepository) Filter(ctx context.Context, filter domain.ArticleFi
 		return nil, 0, err
 	}
 
+	// Cursor mode skips the count; next_cursor tells clients whether to continue
+	if pag.Cursor != "" {
+		return articles, 0, nil
+	}
+
 	total, err := ar.Collection.CountDocuments(ctx, query)
 	if err != nil {
 		return nil, 0, err
@@ -590,28 +652,40 @@ func (ar *ArticleRepository) Filter(ctx context.Context, filter domain.ArticleFi
 }
 
 // =========================== Search ==============================================
-func (ar *ArticleRepository) Search(ctx context.Context, query string, pag domain.Pagination) ([]domain.Article, int, error) {
-	// Use MongoDB native text search. Requires a text index on title/excerpt.
+// buildSearchQuery matches published articles with MongoDB native text search.
+// Requires the text index over title, excerpt and content block text (see
+// ensureArticleIndexes).
+func buildSearchQuery(query domain.SearchQuery) bson.M {
 	filter := bson.M{
-		"$text":  bson.M{"$search": query},
+		"$text":  bson.M{"$search": query.Text},
 		"status": string(domain.StatusPublished),
 	}
+	if query.AuthorID != "" {
+		filter["author_id"] = query.AuthorID
+	}
+	if len(query.Tags) > 0 {
+		filter["tags"] = bson.M{"$all": query.Tags}
+	}
+	return filter
+}
 
-	opts := options.Find().
-		SetSkip(int64((pag.Page - 1) * pag.PageSize)).
-		SetLimit(int64(pag.PageSize)).
-		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+func (ar *ArticleRepository) Search(ctx context.Context, query domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error) {
+	filter := buildSearchQuery(query)
 
-	if pag.SortField == "" {
-		// sort by text score by default
-		opts = opts.SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}})
+	var opts *options.FindOptions
+	if pag.SortField == "" && pag.Cursor == "" {
+		// sort by text score by default; relevance cannot be used as a cursor
+		opts = options.Find().
+			SetSkip(int64((pag.Page - 1) * pag.PageSize)).
+			SetLimit(int64(pag.PageSize)).
+			SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}})
 	} else {
-		sortOrder := 1
-		if pag.SortOrder == "desc" {
-			sortOrder = -1
+		var err error
+		if filter, opts, err = paginateArticles(filter, pag, domain.SortByPublishedAt); err != nil {
+			return nil, 0, err
 		}
-		opts = opts.SetSort(bson.D{{Key: pag.SortField, Value: sortOrder}})
 	}
+	opts = opts.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
 
 	cursor, err := ar.Collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -619,18 +693,27 @@ func (ar *ArticleRepository) Search(ctx context.Context, query string, pag domai
 	}
 	defer cursor.Close(ctx)
 
-	var articles []domain.Article
+	// Full documents are decoded so snippets can be cut from the content blocks
+	var hits []domain.SearchHit
 	for cursor.Next(ctx) {
-		var articleDTO ArticleListDTO
-		if err := cursor.Decode(&articleDTO); err != nil {
+		var doc struct {
+			ArticleDTO `bson:",inline"`
+			Score      float64 `bson:"score"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
 			return nil, 0, err
 		}
-		articles = append(articles, *FromArticleListDTO(&articleDTO))
+		hits = append(hits, domain.SearchHit{Article: *FromArticleDTO(&doc.ArticleDTO), Score: doc.Score})
 	}
 	if err := cursor.Err(); err != nil {
 		return nil, 0, err
 	}
 
+	// Cursor mode skips the count; next_cursor tells clients whether to continue
+	if pag.Cursor != "" {
+		return hits, 0, nil
+	}
+
 	total, err := ar.Collection.CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, 0, err
@@ -639,21 +722,123 @@ func (ar *ArticleRepository) Search(ctx context.Context, query string, pag domai
 		return nil, 0, domain.ErrArticleNotFound
 	}
 
-	return articles, int(total), nil
+	return hits, int(total), nil
+}
+
+// ======================== Facets =======================================
+func (ar *ArticleRepository) FilterFacets(ctx context.Context, filter domain.ArticleFilter) (*domain.ArticleFacets, error) {
+	return ar.facets(ctx, buildArticleFilterQuery("", filter))
+}
+
+func (ar *ArticleRepository) SearchFacets(ctx context.Context, query domain.SearchQuery) (*domain.ArticleFacets, error) {
+	return ar.facets(ctx, buildSearchQuery(query))
+}
+
+// facets counts the articles matching query per tag, author, language,
+// publish month and reading-time bucket in a single aggregation.
+func (ar *ArticleRepository) facets(ctx context.Context, query bson.M) (*domain.ArticleFacets, error) {
+	boundaries := make(bson.A, 0, len(domain.ReadingTimeBuckets)+1)
+	for _, b := range domain.ReadingTimeBuckets {
+		boundaries = append(boundaries, b)
+	}
+	boundaries = append(boundaries, math.MaxInt32)
+
+	pipeline := mongo.Pipeline{
+		// $text, when present, must stay in the first stage
+		{{Key: "$match", Value: query}},
+		{{Key: "$facet", Value: bson.M{
+			"tags": bson.A{
+				bson.M{"$unwind": "$tags"},
+				bson.M{"$sortByCount": "$tags"},
+				bson.M{"$limit": domain.MaxFacetValues},
+			},
+			"authors": bson.A{
+				bson.M{"$sortByCount": "$author_id"},
+				bson.M{"$limit": domain.MaxFacetValues},
+			},
+			"languages": bson.A{
+				bson.M{"$match": bson.M{"language": bson.M{"$nin": bson.A{nil, ""}}}},
+				bson.M{"$sortByCount": "$language"},
+				bson.M{"$limit": domain.MaxFacetValues},
+			},
+			"publish_months": bson.A{
+				bson.M{"$match": bson.M{"timestamps.published_at": bson.M{"$type": "date"}}},
+				bson.M{"$group": bson.M{
+					"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$timestamps.published_at"}},
+					"count": bson.M{"$sum": 1},
+				}},
+				bson.M{"$sort": bson.M{"_id": -1}},
+			},
+			"reading_times": bson.A{
+				bson.M{"$bucket": bson.M{
+					"groupBy":    "$reading_time",
+					"boundaries": boundaries,
+					"default":    domain.ReadingTimeUnknown,
+					"output":     bson.M{"count": bson.M{"$sum": 1}},
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := ar.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	type bucket struct {
+		Value interface{} `bson:"_id"`
+		Count int         `bson:"count"`
+	}
+	var out []struct {
+		Tags          []bucket `bson:"tags"`
+		Authors       []bucket `bson:"authors"`
+		Languages     []bucket `bson:"languages"`
+		PublishMonths []bucket `bson:"publish_months"`
+		ReadingTimes  []bucket `bson:"reading_times"`
+	}
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	facets := &domain.ArticleFacets{}
+	if len(out) == 0 {
+		return facets, nil
+	}
+	counts := func(buckets []bucket, label func(interface{}) string) []domain.FacetCount {
+		fc := make([]domain.FacetCount, 0, len(buckets))
+		for _, b := range buckets {
+			fc = append(fc, domain.FacetCount{Value: label(b.Value), Count: b.Count})
+		}
+		return fc
+	}
+	asString := func(v interface{}) string { s, _ := v.(string); return s }
+	facets.Tags = counts(out[0].Tags, asString)
+	facets.Authors = counts(out[0].Authors, asString)
+	facets.Languages = counts(out[0].Languages, asString)
+	facets.PublishMonths = counts(out[0].PublishMonths, asString)
+	// $bucket names each bucket by its lower bound
+	facets.ReadingTimes = counts(out[0].ReadingTimes, func(v interface{}) string {
+		switch n := v.(type) {
+		case int32:
+			return domain.ReadingTimeBucket(int(n))
+		case int64:
+			return domain.ReadingTimeBucket(int(n))
+		}
+		return domain.ReadingTimeUnknown
+	})
+	return facets, nil
 }
 
 // ======================== List By Tags =======================================
 func (r *ArticleRepository) ListByTags(ctx context.Context, tags []string, pag domain.Pagination) ([]domain.Article, int, error) {
-	query := bson.M{
+	query, opts, err := paginateArticles(bson.M{
 		"tags":   bson.M{"$in": tags},
 		"status": string(domain.StatusPublished),
+	}, pag, domain.SortByCreatedAt)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	opts := options.Find().
-		SetSkip(int64((pag.Page - 1) * pag.PageSize)).
-		SetLimit(int64(pag.PageSize)).
-		SetSort(bson.D{{Key: "timestamps.created_at", Value: -1}})
-
 	cursor, err := r.Collection.Find(ctx, query, opts)
 	if err != nil {
 		return nil, 0, err
@@ -669,6 +854,11 @@ func (r *ArticleRepository) ListByTags(ctx context.Context, tags []string, pag d
 		articles = append(articles, *FromArticleListDTO(&dto))
 	}
 
+	// Cursor mode skips the count; next_cursor tells clients whether to continue
+	if pag.Cursor != "" {
+		return articles, 0, nil
+	}
+
 	total, err := r.Collection.CountDocuments(ctx, query)
 	if err != nil {
 		return nil, 0, err
@@ -677,6 +867,38 @@ func (r *ArticleRepository) ListByTags(ctx context.Context, tags []string, pag d
 	return articles, int(total), nil
 }
 
+// ReplaceTag swaps the tag in place where the article does not already carry
+// the target, and drops it where it does, so tag order is kept without duplicates.
+func (r *ArticleRepository) ReplaceTag(ctx context.Context, from, to string) ([]string, error) {
+	raw, err := r.Collection.Distinct(ctx, "_id", bson.M{"tags": from})
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(raw))
+	for _, id := range raw {
+		if s, ok := id.(string); ok {
+			ids = append(ids, s)
+		}
+	}
+
+	if _, err := r.Collection.UpdateMany(ctx,
+		bson.M{"$and": bson.A{bson.M{"tags": from}, bson.M{"tags": bson.M{"$ne": to}}}},
+		bson.M{"$set": bson.M{"tags.$": to}},
+	); err != nil {
+		return nil, err
+	}
+	if _, err := r.Collection.UpdateMany(ctx,
+		bson.M{"tags": from},
+		bson.M{"$pull": bson.M{"tags": from}},
+	); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // ===========================================================================//
 //
 //	Trash Management                               //
@@ -710,6 +932,38 @@ func (r *ArticleRepository) DeleteFromTrash(ctx context.Context, articleID, user
 //	Admin Operations                                //
 //
 // ===========================================================================//
+// ForEachPublished walks every published article in _id order, handing them to
+// fn in batches. It stops at the first error fn returns.
+func (r *ArticleRepository) ForEachPublished(ctx context.Context, batchSize int, fn func([]domain.Article) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetBatchSize(int32(batchSize))
+	cursor, err := r.Collection.Find(ctx, bson.M{"status": string(domain.StatusPublished)}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	batch := make([]domain.Article, 0, batchSize)
+	for cursor.Next(ctx) {
+		var articleDTO ArticleDTO
+		if err := cursor.Decode(&articleDTO); err != nil {
+			return err
+		}
+		batch = append(batch, *FromArticleDTO(&articleDTO))
+		if len(batch) == batchSize {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = make([]domain.Article, 0, batchSize)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+	return nil
+}
+
 func (r *ArticleRepository) AdminListAllArticles(ctx context.Context, pag domain.Pagination) ([]domain.Article, int, error) {
 	articles := []domain.Article{}
 
@@ -770,10 +1024,26 @@ func (r *ArticleRepository) IncrementView(ctx context.Context, articleID string)
 	return err
 }
 
-// Add this method to ArticleRepository
-func (r *ArticleRepository) UpdateClapCount(ctx context.Context, articleID string, count int) error {
-	_, err := r.Collection.UpdateOne(ctx,
+// ================================ Increment Stat ===========================================
+func (r *ArticleRepository) IncrementStat(ctx context.Context, articleID string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+	if !counter.Valid() {
+		return nil, domain.ErrInvalidArticlePayload
+	}
+	opts := options.FindOneAndUpdate().
+		SetProjection(bson.M{"stats": 1}).
+		SetReturnDocument(options.After)
+	var wrapped struct {
+		Stats ArticleStatsDTO `bson:"stats"`
+	}
+	err := r.Collection.FindOneAndUpdate(ctx,
 		bson.M{"_id": articleID},
-		bson.M{"$set": bson.M{"stats.clap_count": count}})
-	return err
+		bson.M{"$inc": bson.M{"stats." + string(counter): delta}}, opts).Decode(&wrapped)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrArticleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	stats := FromArticleStatsDTO(wrapped.Stats)
+	return &stats, nil
 }
@@ -225,7 +225,7 @@ func BenchmarkRepo_Search_TitleRegex(b *testing.B) {
 	b.ResetTimer()
 	var totalDocs int
 	for i := 0; i < b.N; i++ {
-		res, _, err := env.repo.Search(context.Background(), "Golang", pag)
+		res, _, err := env.repo.Search(context.Background(), domain.SearchQuery{Text: "Golang"}, pag)
 		if err != nil {
 			b.Fatalf("Search error: %v", err)
 		}
@@ -246,7 +246,7 @@ func BenchmarkRepo_FindTrending(b *testing.B) {
 	b.ResetTimer()
 	var totalDocs int
 	for i := 0; i < b.N; i++ {
-		res, _, err := env.repo.FindTrending(context.Background(), 7, pag)
+		res, _, err := env.repo.FindTrending(context.Background(), domain.DefaultTrendingWindow, pag)
 		if err != nil {
 			b.Fatalf("FindTrending error: %v", err)
 		}
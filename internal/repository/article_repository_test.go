@@ -119,6 +119,33 @@ func TestArticleRepo_Update(t *testing.T) {
 	assert.Equal(t, "New", got.Title)
 }
 
+func TestArticleRepo_Update_KeepsStatsAndStatus(t *testing.T) {
+	s := &ArticleRepoTestSuite{}
+	s.SetupSuite(t)
+	defer s.TearDownSuite(t)
+	s.resetCollection(t)
+
+	a := &domain.Article{ID: "a2p", Title: "Old", Slug: "old-p", AuthorID: "u1", Status: domain.StatusDraft}
+	s.mustCreateArticle(t, a)
+	s.mustPublish(t, a.ID, time.Now())
+	_, err := s.repo.IncrementStat(s.ctx, a.ID, domain.StatClaps, 5)
+	require.NoError(t, err)
+	_, err = s.repo.IncrementStat(s.ctx, a.ID, domain.StatComments, 2)
+	require.NoError(t, err)
+	before, err := s.repo.GetByID(s.ctx, a.ID)
+	require.NoError(t, err)
+
+	// The edit payload carries no status, stats or timestamps
+	require.NoError(t, s.repo.Update(s.ctx, &domain.Article{ID: a.ID, Title: "New", Slug: "new-p", AuthorID: "u1"}))
+	got, err := s.repo.GetByID(s.ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "New", got.Title)
+	assert.Equal(t, domain.StatusPublished, got.Status)
+	assert.Equal(t, before.Stats, got.Stats)
+	assert.Equal(t, before.Timestamps.CreatedAt.Unix(), got.Timestamps.CreatedAt.Unix())
+	assert.NotNil(t, got.Timestamps.PublishedAt)
+}
+
 // ========================= Delete & Restore =========================
 func TestArticleRepo_DeleteRestore(t *testing.T) {
 	s := &ArticleRepoTestSuite{}
@@ -197,7 +224,7 @@ func TestArticleRepo_FindTrending(t *testing.T) {
 	s.mustCreateArticle(t, a)
 	s.mustPublish(t, a.ID, time.Now())
 
-	arts, total, err := s.repo.FindTrending(s.ctx, 7, domain.Pagination{Page: 1, PageSize: 10})
+	arts, total, err := s.repo.FindTrending(s.ctx, domain.DefaultTrendingWindow, domain.Pagination{Page: 1, PageSize: 10})
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, total, 1)
 	assert.GreaterOrEqual(t, len(arts), 1)
@@ -298,7 +325,7 @@ func TestArticleRepo_Search(t *testing.T) {
 	s.mustCreateArticle(t, a)
 	s.mustPublish(t, a.ID, time.Now())
 
-	arts, total, err := s.repo.Search(s.ctx, "Golang", domain.Pagination{Page: 1, PageSize: 10})
+	arts, total, err := s.repo.Search(s.ctx, domain.SearchQuery{Text: "Golang"}, domain.Pagination{Page: 1, PageSize: 10})
 	require.NoError(t, err)
 	assert.Equal(t, 1, total)
 	assert.Len(t, arts, 1)
@@ -380,15 +407,25 @@ func TestArticleRepo_StatsAndCounters(t *testing.T) {
 	s.mustCreateArticle(t, a)
 	s.mustPublish(t, a.ID, time.Now())
 
-	// increment view and update clap
+	// increment view and counters
 	require.NoError(t, s.repo.IncrementView(s.ctx, a.ID))
-	require.NoError(t, s.repo.UpdateClapCount(s.ctx, a.ID, 5))
+	stats, err := s.repo.IncrementStat(s.ctx, a.ID, domain.StatClaps, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.ClapCount)
+	_, err = s.repo.IncrementStat(s.ctx, a.ID, domain.StatComments, 2)
+	require.NoError(t, err)
+	stats, err = s.repo.IncrementStat(s.ctx, a.ID, domain.StatComments, -1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.CommentCount)
+	_, err = s.repo.IncrementStat(s.ctx, "missing", domain.StatClaps, 1)
+	assert.ErrorIs(t, err, domain.ErrArticleNotFound)
 
 	// verify via GetByID (since GetStats projects differently)
 	got, err := s.repo.GetByID(s.ctx, a.ID)
 	require.NoError(t, err)
 	assert.Equal(t, 1, got.Stats.ViewCount)
 	assert.Equal(t, 5, got.Stats.ClapCount)
+	assert.Equal(t, 1, got.Stats.CommentCount)
 }
 
 // ========================= GetBySlug =========================
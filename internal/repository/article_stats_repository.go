@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"write_base/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// counterSource describes which collection an article counter is derived from.
+type counterSource struct {
+	counter    domain.StatCounter
+	collection string
+	articleKey string
+	// countExpr is summed per article; nil counts documents
+	countExpr interface{}
+	match     bson.M
+}
+
+// view_count is not listed: the views log expires, so it cannot be
+// recomputed. Unique views come from the reader records, which are kept.
+var counterSources = []counterSource{
+	{counter: domain.StatUniqueViews, collection: readersCollection, articleKey: readerArticleField},
+	{counter: domain.StatClaps, collection: "claps", articleKey: "article_id", countExpr: "$count"},
+	{counter: domain.StatComments, collection: "comments", articleKey: "post_id", match: bson.M{"deleted": bson.M{"$ne": true}}},
+	{counter: domain.StatReactions, collection: "reactions", articleKey: "post_id", match: bson.M{"comment_id": nil}},
+}
+
+type ArticleStatsRepository struct {
+	db       *mongo.Database
+	articles *mongo.Collection
+}
+
+func NewArticleStatsRepository(db *mongo.Database) domain.IArticleStatsRepository {
+	return &ArticleStatsRepository{db: db, articles: db.Collection("articles")}
+}
+
+func (r *ArticleStatsRepository) StoredStats(ctx context.Context) (map[string]domain.ArticleStats, error) {
+	cursor, err := r.articles.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"stats": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	stored := make(map[string]domain.ArticleStats)
+	for cursor.Next(ctx) {
+		var row struct {
+			ID    string          `bson:"_id"`
+			Stats ArticleStatsDTO `bson:"stats"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		stored[row.ID] = FromArticleStatsDTO(row.Stats)
+	}
+	return stored, cursor.Err()
+}
+
+func (r *ArticleStatsRepository) CountEngagement(ctx context.Context) (map[string]domain.ArticleStats, error) {
+	actual := make(map[string]domain.ArticleStats)
+	for _, src := range counterSources {
+		counts, err := r.countSource(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+		for id, n := range counts {
+			stats := actual[id]
+			switch src.counter {
+			case domain.StatUniqueViews:
+				stats.UniqueViewCount = n
+			case domain.StatClaps:
+				stats.ClapCount = n
+			case domain.StatComments:
+				stats.CommentCount = n
+			case domain.StatReactions:
+				stats.ReactionCount = n
+			}
+			actual[id] = stats
+		}
+	}
+	return actual, nil
+}
+
+// countSource groups one collection by article.
+func (r *ArticleStatsRepository) countSource(ctx context.Context, src counterSource) (map[string]int, error) {
+	match := bson.M{}
+	for k, v := range src.match {
+		match[k] = v
+	}
+	var count interface{} = 1
+	if src.countExpr != nil {
+		count = src.countExpr
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + src.articleKey, "count": bson.M{"$sum": count}}}},
+	}
+	cursor, err := r.db.Collection(src.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := make(map[string]int)
+	for cursor.Next(ctx) {
+		var row struct {
+			ID    string `bson:"_id"`
+			Count int    `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		if row.ID != "" {
+			counts[row.ID] = row.Count
+		}
+	}
+	return counts, cursor.Err()
+}
+
+func (r *ArticleStatsRepository) FixStats(ctx context.Context, drifts []domain.StatsDrift) (int, error) {
+	if len(drifts) == 0 {
+		return 0, nil
+	}
+	models := make([]mongo.WriteModel, 0, len(drifts))
+	for _, d := range drifts {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(storedCountersFilter(d.ArticleID, d.Stored)).
+			SetUpdate(bson.M{"$set": bson.M{
+				"stats.unique_view_count": d.Actual.UniqueViewCount,
+				"stats.clap_count":        d.Actual.ClapCount,
+				"stats.comment_count":     d.Actual.CommentCount,
+				"stats.reaction_count":    d.Actual.ReactionCount,
+			}}))
+	}
+	res, err := r.articles.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, err
+	}
+	return int(res.ModifiedCount), nil
+}
+
+// storedCountersFilter matches the article only while its counters still hold
+// the stored values. A missing counter reads as zero, as it did when stored.
+func storedCountersFilter(articleID string, stored domain.ArticleStats) bson.M {
+	filter := bson.M{"_id": articleID}
+	for key, n := range map[string]int{
+		"stats.unique_view_count": stored.UniqueViewCount,
+		"stats.clap_count":        stored.ClapCount,
+		"stats.comment_count":     stored.CommentCount,
+		"stats.reaction_count":    stored.ReactionCount,
+	} {
+		if n == 0 {
+			filter[key] = bson.M{"$in": bson.A{0, nil}}
+		} else {
+			filter[key] = n
+		}
+	}
+	return filter
+}
@@ -2,15 +2,29 @@ package repository
 
 import (
 	"context"
+	"log"
 	"time"
 	"write_base/internal/domain"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The clap events log holds one document per accepted clap, so trending can
+// weigh each clap by its own age.
+const (
+	clapEventsCollection    = "clap_events"
+	clapEventArticleField   = "article_id"
+	clapEventUserField      = "user_id"
+	clapEventTimeField      = "created_at"
+	clapEventRetractedField = "retracted_at"
 )
 
 type ClapRepositoryImpl struct {
 	collection *mongo.Collection
+	// events logs every clap; claps the user takes back are marked retracted
+	events *mongo.Collection
 }
 
 type ClapDTO struct {
@@ -22,20 +36,33 @@ type ClapDTO struct {
 	UpdatedAt time.Time `bson:"updated_at"`
 }
 
-func NewClapRepository(db *mongo.Database) domain.ClapRepository {
-	return &ClapRepositoryImpl{
-		collection: db.Collection("claps"),
-	}
+// clapEventDTO is one clap in the events log.
+type clapEventDTO struct {
+	UserID      string     `bson:"user_id"`
+	ArticleID   string     `bson:"article_id"`
+	CreatedAt   time.Time  `bson:"created_at"`
+	RetractedAt *time.Time `bson:"retracted_at,omitempty"`
 }
 
-func toClapDTO(clap *domain.Clap) *ClapDTO {
-	return &ClapDTO{
-		ID:        clap.ID,
-		UserID:    clap.UserID,
-		ArticleID: clap.ArticleID,
-		Count:     clap.Count,
-		CreatedAt: clap.CreatedAt,
-		UpdatedAt: clap.UpdatedAt,
+// NewClapRepository keeps the clap events log for retention, long enough for
+// the trending window.
+func NewClapRepository(db *mongo.Database, retention time.Duration) domain.ClapRepository {
+	collection := db.Collection("claps")
+	// One record per user and article; increments rely on it to stay capped
+	collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "article_id", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("uniq_user_article"),
+	})
+
+	events := db.Collection(clapEventsCollection)
+	ensureTTLIndex(events, clapEventTimeField, clapEventTimeField+"_ttl", retention)
+	events.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: clapEventUserField, Value: 1}, {Key: clapEventArticleField, Value: 1}},
+		Options: options.Index().SetName("user_article"),
+	})
+	return &ClapRepositoryImpl{
+		collection: collection,
+		events:     events,
 	}
 }
 
@@ -50,26 +77,61 @@ func toDomainClap(dto *ClapDTO) *domain.Clap {
 	}
 }
 
-func (r *ClapRepositoryImpl) Create(ctx context.Context, clap *domain.Clap) error {
-	clap.CreatedAt = time.Now()
-	clap.UpdatedAt = time.Now()
-	
-	dto := toClapDTO(clap)
-	_, err := r.collection.InsertOne(ctx, dto)
-	return err
+func (r *ClapRepositoryImpl) Increment(ctx context.Context, userID, articleID string, max int) (*domain.Clap, error) {
+	now := time.Now()
+	filter := bson.M{
+		"user_id":    userID,
+		"article_id": articleID,
+		"count":      bson.M{"$lt": max},
+	}
+	update := bson.M{
+		"$inc":         bson.M{"count": 1},
+		"$set":         bson.M{"updated_at": now},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+
+	// Below the cap the filter matches and the clap is counted. Otherwise the
+	// upsert inserts a first record, which the unique index rejects if the
+	// user already has one
+	var dto ClapDTO
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After).SetUpsert(true)).Decode(&dto)
+	if mongo.IsDuplicateKeyError(err) {
+		// Either the user is at the cap, or a concurrent first clap created
+		// the record and this clap still fits
+		err = r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&dto)
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrClapLimitExceeded
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The clap is already counted; a missing event only leaves it out of trending
+	if _, err := r.events.InsertOne(ctx, clapEventDTO{UserID: userID, ArticleID: articleID, CreatedAt: now}); err != nil {
+		log.Printf("clap event for article %s not logged: %v", articleID, err)
+	}
+	return toDomainClap(&dto), nil
 }
 
-func (r *ClapRepositoryImpl) Update(ctx context.Context, clap *domain.Clap) error {
-	clap.UpdatedAt = time.Now()
-	
-	filter := bson.M{"_id": clap.ID}
-	update := bson.M{"$set": bson.M{
-		"count":      clap.Count,
-		"updated_at": clap.UpdatedAt,
-	}}
-	
-	_, err := r.collection.UpdateOne(ctx, filter, update)
-	return err
+func (r *ClapRepositoryImpl) Remove(ctx context.Context, userID, articleID string) (int, error) {
+	var dto ClapDTO
+	err := r.collection.FindOneAndDelete(ctx, bson.M{"user_id": userID, "article_id": articleID}).Decode(&dto)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// Retracted claps stop counting towards trending
+	_, err = r.events.UpdateMany(ctx,
+		bson.M{clapEventUserField: userID, clapEventArticleField: articleID, clapEventRetractedField: nil},
+		bson.M{"$set": bson.M{clapEventRetractedField: time.Now()}})
+	if err != nil {
+		log.Printf("clap events for article %s not retracted: %v", articleID, err)
+	}
+	return dto.Count, nil
 }
 
 func (r *ClapRepositoryImpl) GetByUserAndArticle(ctx context.Context, userID, articleID string) (*domain.Clap, error) {
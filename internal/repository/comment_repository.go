@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"log"
+	"time"
 	"write_base/internal/domain"
 	dtodbrep "write_base/internal/repository/dto"
 
@@ -10,6 +11,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoCommentRepository struct {
@@ -17,9 +19,56 @@ type MongoCommentRepository struct {
 }
 
 func NewMongoCommentRepository(collection *mongo.Collection) *MongoCommentRepository {
+	// Thread levels are listed by post for top-level comments and by parent for replies
+	collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("post_parent_created")},
+		{Keys: bson.D{{Key: "parent_id", Value: 1}}, Options: options.Index().SetName("parent_id")},
+	})
 	return &MongoCommentRepository{collection: collection}
 }
 
+// idFilter matches documents whose _id was generated by MongoDB on insert as
+// well as ones stored with a caller-supplied string ID.
+func idFilter(id string) bson.M {
+	if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+		return bson.M{"_id": bson.M{"$in": bson.A{oid, id}}}
+	}
+	return bson.M{"_id": id}
+}
+
+func commentFromDTO(dto dtodbrep.CommentResponse) domain.Comment {
+	return domain.Comment{
+		ID:        dto.ID,
+		PostID:    dto.PostID,
+		UserID:    dto.UserID,
+		ParentID:  dto.ParentID,
+		Content:   dto.Content,
+		Mentions:  mentionsFromDTO(dto.Mentions),
+		CreatedAt: dto.CreatedAt,
+		UpdatedAt: dto.UpdatedAt,
+		EditedAt:  dto.EditedAt,
+		Deleted:   dto.Deleted,
+		Hidden:    dto.Hidden,
+		Pinned:    dto.Pinned,
+	}
+}
+
+func mentionsFromDTO(dtos []dtodbrep.CommentMention) []domain.Mention {
+	var out []domain.Mention
+	for _, m := range dtos {
+		out = append(out, domain.Mention{UserID: m.UserID, Username: m.Username})
+	}
+	return out
+}
+
+func mentionsToDTO(mentions []domain.Mention) []dtodbrep.CommentMention {
+	var out []dtodbrep.CommentMention
+	for _, m := range mentions {
+		out = append(out, dtodbrep.CommentMention{UserID: m.UserID, Username: m.Username})
+	}
+	return out
+}
+
 func (r *MongoCommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
 	dto := dtodbrep.CommentResponse{
 		ID:        comment.ID,
@@ -27,19 +76,34 @@ func (r *MongoCommentRepository) Create(ctx context.Context, comment *domain.Com
 		UserID:    comment.UserID,
 		ParentID:  comment.ParentID,
 		Content:   comment.Content,
+		Mentions:  mentionsToDTO(comment.Mentions),
 		CreatedAt: comment.CreatedAt,
 		UpdatedAt: comment.UpdatedAt,
 	}
-	_, err := r.collection.InsertOne(ctx, dto)
-	return err
+	res, err := r.collection.InsertOne(ctx, dto)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok && comment.ID == "" {
+		comment.ID = oid.Hex()
+	}
+	return nil
 }
 
 func (r *MongoCommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
-	filter := bson.M{"_id": comment.ID}
-	update := bson.M{"$set": bson.M{
-		"content":    comment.Content,
+	filter := idFilter(comment.ID)
+	filter["deleted"] = bson.M{"$ne": true}
+	// A pipeline update reads the old content and writes the new one atomically
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"history": bson.M{"$concatArrays": bson.A{
+			bson.M{"$ifNull": bson.A{"$history", bson.A{}}},
+			bson.A{bson.M{"content": "$content", "created_at": "$updated_at"}},
+		}},
+		"content":    bson.M{"$literal": comment.Content},
+		"mentions":   bson.M{"$literal": mentionsToDTO(comment.Mentions)},
 		"updated_at": comment.UpdatedAt,
-	}}
+		"edited_at":  comment.EditedAt,
+	}}}}
 	res, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -51,12 +115,17 @@ func (r *MongoCommentRepository) Update(ctx context.Context, comment *domain.Com
 }
 
 func (r *MongoCommentRepository) Delete(ctx context.Context, commentID string) error {
-	filter := bson.M{"_id": commentID}
-	res, err := r.collection.DeleteOne(ctx, filter)
+	filter := idFilter(commentID)
+	filter["deleted"] = bson.M{"$ne": true}
+	update := bson.M{
+		"$set":   bson.M{"deleted": true, "content": "", "updated_at": time.Now().Unix()},
+		"$unset": bson.M{"history": "", "pinned": "", "mentions": ""},
+	}
+	res, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
-	if res.DeletedCount == 0 {
+	if res.MatchedCount == 0 {
 		return domain.ErrCommentNotFound
 	}
 	return nil
@@ -77,15 +146,8 @@ func (r *MongoCommentRepository) GetByID(ctx context.Context, commentID string)
 	if err != nil {
 		return nil, err
 	}
-	return &domain.Comment{
-		ID:        dto.ID,
-		PostID:    dto.PostID,
-		UserID:    dto.UserID,
-		ParentID:  dto.ParentID,
-		Content:   dto.Content,
-		CreatedAt: dto.CreatedAt,
-		UpdatedAt: dto.UpdatedAt,
-	}, nil
+	comment := commentFromDTO(dto)
+	return &comment, nil
 }
 
 func (r *MongoCommentRepository) GetByPostID(ctx context.Context, postID string) ([]*domain.Comment, error) {
@@ -101,15 +163,8 @@ func (r *MongoCommentRepository) GetByPostID(ctx context.Context, postID string)
 		if err := cur.Decode(&dto); err != nil {
 			return nil, err
 		}
-		results = append(results, &domain.Comment{
-			ID:        dto.ID,
-			PostID:    dto.PostID,
-			UserID:    dto.UserID,
-			ParentID:  dto.ParentID,
-			Content:   dto.Content,
-			CreatedAt: dto.CreatedAt,
-			UpdatedAt: dto.UpdatedAt,
-		})
+		comment := commentFromDTO(dto)
+		results = append(results, &comment)
 	}
 	if err := cur.Err(); err != nil {
 		return nil, err
@@ -130,15 +185,8 @@ func (r *MongoCommentRepository) GetByUserID(ctx context.Context, userID string)
 		if err := cur.Decode(&dto); err != nil {
 			return nil, err
 		}
-		results = append(results, &domain.Comment{
-			ID:        dto.ID,
-			PostID:    dto.PostID,
-			UserID:    dto.UserID,
-			ParentID:  dto.ParentID,
-			Content:   dto.Content,
-			CreatedAt: dto.CreatedAt,
-			UpdatedAt: dto.UpdatedAt,
-		})
+		comment := commentFromDTO(dto)
+		results = append(results, &comment)
 	}
 	if err := cur.Err(); err != nil {
 		return nil, err
@@ -159,18 +207,66 @@ func (r *MongoCommentRepository) GetReplies(ctx context.Context, parentID string
 		if err := cur.Decode(&dto); err != nil {
 			return nil, err
 		}
-		results = append(results, &domain.Comment{
-			ID:        dto.ID,
-			PostID:    dto.PostID,
-			UserID:    dto.UserID,
-			ParentID:  dto.ParentID,
-			Content:   dto.Content,
-			CreatedAt: dto.CreatedAt,
-			UpdatedAt: dto.UpdatedAt,
-		})
+		comment := commentFromDTO(dto)
+		results = append(results, &comment)
 	}
 	if err := cur.Err(); err != nil {
 		return nil, err
 	}
 	return results, nil
 }
+
+func (r *MongoCommentRepository) GetHistory(ctx context.Context, commentID string) ([]domain.CommentRevision, error) {
+	var doc struct {
+		History []struct {
+			Content   string `bson:"content"`
+			CreatedAt int64  `bson:"created_at"`
+		} `bson:"history"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"history": 1})
+	err := r.collection.FindOne(ctx, idFilter(commentID), opts).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	history := make([]domain.CommentRevision, 0, len(doc.History))
+	for _, h := range doc.History {
+		history = append(history, domain.CommentRevision{Content: h.Content, CreatedAt: h.CreatedAt})
+	}
+	return history, nil
+}
+
+// SetHidden also unpins a comment that is being hidden.
+func (r *MongoCommentRepository) SetHidden(ctx context.Context, commentID string, hidden bool) error {
+	set := bson.M{"hidden": hidden}
+	if hidden {
+		set["pinned"] = false
+	}
+	res, err := r.collection.UpdateOne(ctx, idFilter(commentID), bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+func (r *MongoCommentRepository) SetPinned(ctx context.Context, postID, commentID string) error {
+	if _, err := r.collection.UpdateMany(ctx, bson.M{"post_id": postID, "pinned": true}, bson.M{"$set": bson.M{"pinned": false}}); err != nil {
+		return err
+	}
+	if commentID == "" {
+		return nil
+	}
+	res, err := r.collection.UpdateOne(ctx, idFilter(commentID), bson.M{"$set": bson.M{"pinned": true}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
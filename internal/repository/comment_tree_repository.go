@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"write_base/internal/domain"
+	dtodbrep "write_base/internal/repository/dto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// commentNodeDTO is a comment decoded from the thread pipelines, which add the
+// string form of the ID and the number of "like" reactions.
+type commentNodeDTO struct {
+	dtodbrep.CommentResponse `bson:",inline"`
+	IDStr                    string `bson:"id_str"`
+	LikeCount                int    `bson:"like_count"`
+}
+
+func (d commentNodeDTO) toDomain() domain.CommentNode {
+	comment := commentFromDTO(d.CommentResponse)
+	comment.ID = d.IDStr
+	return domain.CommentNode{Comment: comment, Likes: d.LikeCount}
+}
+
+// withLikes adds id_str and like_count. Reactions reference comments by the
+// hex string of their ID.
+func withLikes() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$addFields", Value: bson.M{"id_str": bson.M{"$toString": "$_id"}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "reactions",
+			"let":  bson.M{"cid": "$id_str"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$comment_id", "$$cid"}},
+					bson.M{"$eq": bson.A{"$type", string(domain.ReactionLike)}},
+				}}}},
+				bson.M{"$count": "n"},
+			},
+			"as": "likes",
+		}}},
+		{{Key: "$addFields", Value: bson.M{"like_count": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$likes.n", 0}}, 0}}}}},
+		{{Key: "$project", Value: bson.M{"likes": 0, "history": 0}}},
+	}
+}
+
+// commentSortSpec returns the sort key field and direction for a thread sort;
+// id_str breaks ties in the same direction.
+func commentSortSpec(sort domain.CommentSort) (string, int) {
+	switch sort {
+	case domain.CommentSortOldest:
+		return "created_at", 1
+	case domain.CommentSortMostLiked:
+		return "like_count", -1
+	}
+	return "created_at", -1
+}
+
+func commentSortStage(sort domain.CommentSort) bson.D {
+	field, dir := commentSortSpec(sort)
+	return bson.D{{Key: "$sort", Value: bson.D{{Key: field, Value: dir}, {Key: "id_str", Value: dir}}}}
+}
+
+func (r *MongoCommentRepository) ListThread(ctx context.Context, q domain.CommentListQuery) ([]domain.CommentNode, error) {
+	// The pinned comment is served separately at the top of the first page
+	match := bson.M{"post_id": q.PostID, "parent_id": nil, "pinned": bson.M{"$ne": true}}
+	if q.ParentID != "" {
+		match = bson.M{"parent_id": q.ParentID}
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	pipeline = append(pipeline, withLikes()...)
+	if q.After != nil {
+		field, dir := commentSortSpec(q.Sort)
+		op := "$lt"
+		if dir > 0 {
+			op = "$gt"
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{field: bson.M{op: q.After.Key}},
+			bson.M{field: q.After.Key, "id_str": bson.M{op: q.After.ID}},
+		}}}})
+	}
+	pipeline = append(pipeline, commentSortStage(q.Sort), bson.D{{Key: "$limit", Value: q.Limit}})
+	return r.aggregateNodes(ctx, pipeline)
+}
+
+func (r *MongoCommentRepository) GetPinned(ctx context.Context, postID string) (*domain.CommentNode, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"post_id": postID, "parent_id": nil, "pinned": true}}},
+		{{Key: "$limit", Value: 1}},
+	}
+	nodes, err := r.aggregateNodes(ctx, append(pipeline, withLikes()...))
+	if err != nil || len(nodes) == 0 {
+		return nil, err
+	}
+	return &nodes[0], nil
+}
+
+func (r *MongoCommentRepository) aggregateNodes(ctx context.Context, pipeline mongo.Pipeline) ([]domain.CommentNode, error) {
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var nodes []domain.CommentNode
+	for cursor.Next(ctx) {
+		var dto commentNodeDTO
+		if err := cursor.Decode(&dto); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, dto.toDomain())
+	}
+	return nodes, cursor.Err()
+}
+
+// ListReplies fetches the previews of a whole tree level in one query.
+func (r *MongoCommentRepository) ListReplies(ctx context.Context, parentIDs []string, sort domain.CommentSort, perParent int) (map[string][]domain.CommentNode, error) {
+	out := map[string][]domain.CommentNode{}
+	if len(parentIDs) == 0 || perParent <= 0 {
+		return out, nil
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"parent_id": bson.M{"$in": parentIDs}}}}}
+	pipeline = append(pipeline, withLikes()...)
+	pipeline = append(pipeline,
+		commentSortStage(sort),
+		bson.D{{Key: "$group", Value: bson.M{"_id": "$parent_id", "replies": bson.M{"$push": "$$ROOT"}}}},
+		bson.D{{Key: "$project", Value: bson.M{"replies": bson.M{"$slice": bson.A{"$replies", perParent}}}}},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var row struct {
+			ParentID string           `bson:"_id"`
+			Replies  []commentNodeDTO `bson:"replies"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		for _, dto := range row.Replies {
+			out[row.ParentID] = append(out[row.ParentID], dto.toDomain())
+		}
+	}
+	return out, cursor.Err()
+}
+
+func (r *MongoCommentRepository) CountReplies(ctx context.Context, parentIDs []string) (map[string]int, error) {
+	counts := map[string]int{}
+	if len(parentIDs) == 0 {
+		return counts, nil
+	}
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"parent_id": bson.M{"$in": parentIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$parent_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var row struct {
+			ID    string `bson:"_id"`
+			Count int    `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		counts[row.ID] = row.Count
+	}
+	return counts, cursor.Err()
+}
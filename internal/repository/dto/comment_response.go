@@ -6,6 +6,16 @@ type CommentResponse struct {
  UserID    string  `json:"user_id" bson:"user_id"`
  ParentID  *string `json:"parent_id,omitempty" bson:"parent_id,omitempty"`
  Content   string  `json:"content" bson:"content"`
+ Mentions  []CommentMention `json:"mentions,omitempty" bson:"mentions,omitempty"`
  CreatedAt int64   `json:"created_at" bson:"created_at"`
  UpdatedAt int64   `json:"updated_at" bson:"updated_at"`
+ EditedAt  int64   `json:"edited_at,omitempty" bson:"edited_at,omitempty"`
+ Deleted   bool    `json:"deleted,omitempty" bson:"deleted,omitempty"`
+ Hidden    bool    `json:"hidden,omitempty" bson:"hidden,omitempty"`
+ Pinned    bool    `json:"pinned,omitempty" bson:"pinned,omitempty"`
+}
+
+type CommentMention struct {
+ UserID   string `json:"user_id" bson:"user_id"`
+ Username string `json:"username" bson:"username"`
 }
@@ -3,6 +3,11 @@ package dto
 type NotificationResponse struct {
  ID        string `json:"id" bson:"_id,omitempty"`
  UserID    string `json:"user_id" bson:"user_id"`
+ Type      string `json:"type" bson:"type"`
+ ActorID   string `json:"actor_id,omitempty" bson:"actor_id,omitempty"`
+ ArticleID string `json:"article_id,omitempty" bson:"article_id,omitempty"`
+ CommentID string `json:"comment_id,omitempty" bson:"comment_id,omitempty"`
+ ReportID  string `json:"report_id,omitempty" bson:"report_id,omitempty"`
  Message   string `json:"message" bson:"message"`
  Read      bool   `json:"read" bson:"read"`
  CreatedAt int64  `json:"created_at" bson:"created_at"`
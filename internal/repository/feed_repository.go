@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"write_base/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FeedRepository struct {
+	articles *mongo.Collection
+	claps    *mongo.Collection
+	readers  *mongo.Collection
+}
+
+func NewFeedRepository(db *mongo.Database) domain.IFeedRepository {
+	return &FeedRepository{
+		articles: db.Collection("articles"),
+		claps:    db.Collection("claps"),
+		readers:  db.Collection(readersCollection),
+	}
+}
+
+func (r *FeedRepository) RecentByAuthors(ctx context.Context, authorIDs []string, since time.Time, limit int) ([]domain.Article, error) {
+	if len(authorIDs) == 0 {
+		return nil, nil
+	}
+	return r.findPublished(ctx, bson.M{"author_id": bson.M{"$in": authorIDs}}, since, domain.SortByPublishedAt, limit)
+}
+
+func (r *FeedRepository) RecentByTags(ctx context.Context, tags []string, since time.Time, limit int) ([]domain.Article, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return r.findPublished(ctx, bson.M{"tags": bson.M{"$in": tags}}, since, domain.SortByPublishedAt, limit)
+}
+
+func (r *FeedRepository) TopTrending(ctx context.Context, since time.Time, limit int) ([]domain.Article, error) {
+	return r.findPublished(ctx, bson.M{"stats.trending_score": bson.M{"$gt": 0}}, since, domain.SortByTrending, limit)
+}
+
+func (r *FeedRepository) findPublished(ctx context.Context, query bson.M, since time.Time, sortField string, limit int) ([]domain.Article, error) {
+	query["status"] = string(domain.StatusPublished)
+	query["timestamps.published_at"] = bson.M{"$gte": since}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: sortField, Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.articles.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []domain.Article
+	for cursor.Next(ctx) {
+		var dto ArticleListDTO
+		if err := cursor.Decode(&dto); err != nil {
+			return nil, err
+		}
+		articles = append(articles, *FromArticleListDTO(&dto))
+	}
+	return articles, cursor.Err()
+}
+
+func (r *FeedRepository) TagInterests(ctx context.Context, userID string, limit int) ([]string, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "articles",
+			"localField":   "article_id",
+			"foreignField": "_id",
+			"as":           "article",
+		}}},
+		{{Key: "$unwind", Value: "$article"}},
+		{{Key: "$unwind", Value: "$article.tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$article.tags", "claps": bson.M{"$sum": "$count"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "claps", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+	cursor, err := r.claps.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var tags []string
+	for cursor.Next(ctx) {
+		var row struct {
+			Tag string `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		tags = append(tags, row.Tag)
+	}
+	return tags, cursor.Err()
+}
+
+func (r *FeedRepository) ReadArticleIDs(ctx context.Context, userID string, articleIDs []string) (map[string]bool, error) {
+	read := map[string]bool{}
+	if len(articleIDs) == 0 {
+		return read, nil
+	}
+	// Reader records are kept for good, unlike the views log
+	readerKey := domain.ViewReader{UserID: userID}.Key()
+	ids, err := r.readers.Distinct(ctx, readerArticleField, bson.M{readerKeyField: readerKey, readerArticleField: bson.M{"$in": articleIDs}})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if s, ok := id.(string); ok {
+			read[s] = true
+		}
+	}
+	return read, nil
+}
@@ -0,0 +1,33 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+	"write_base/internal/repository"
+	"write_base/tests/test_utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Reads recorded by the view repository are the ones the feed treats as read.
+func TestFeedRepository_ReadArticleIDsSeesTrackedReads(t *testing.T) {
+	ctx := context.Background()
+	db, cleanup := test_utils.SetupTestDatabase(t)
+	defer cleanup()
+
+	views := repository.NewViewRepository(db, time.Hour)
+	feed := repository.NewFeedRepository(db)
+
+	_, _, err := views.TrackRead(ctx, "a1", domain.ViewReader{UserID: "u1"}.Key(), time.Minute)
+	require.NoError(t, err)
+	_, _, err = views.TrackRead(ctx, "a2", domain.ViewReader{UserID: "u2"}.Key(), time.Minute)
+	require.NoError(t, err)
+	_, _, err = views.TrackRead(ctx, "a3", domain.ViewReader{ClientIP: "203.0.113.7", UserAgent: "Mozilla/5.0"}.Key(), time.Minute)
+	require.NoError(t, err)
+
+	read, err := feed.ReadArticleIDs(ctx, "u1", []string{"a1", "a2", "a3"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"a1": true}, read)
+}
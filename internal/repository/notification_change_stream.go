@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"write_base/internal/domain"
+	dtodbrep "write_base/internal/repository/dto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NotificationChangeStream is a broker backed by a change stream on the
+// notifications collection: storing a notification publishes it, and every
+// instance watching the collection sees it. Change streams need MongoDB to run
+// as a replica set.
+type NotificationChangeStream struct {
+	collection *mongo.Collection
+
+	mu          sync.Mutex
+	resumeToken bson.Raw
+}
+
+func NewNotificationChangeStream(db *mongo.Database) *NotificationChangeStream {
+	return &NotificationChangeStream{collection: db.Collection("notifications")}
+}
+
+var _ domain.INotificationBroker = (*NotificationChangeStream)(nil)
+
+// Publish has nothing to do: the insert already reached the change stream.
+func (s *NotificationChangeStream) Publish(ctx context.Context, n domain.Notification) error {
+	return nil
+}
+
+// Run resumes after the last event it saw when it is started again after an error.
+func (s *NotificationChangeStream) Run(ctx context.Context, deliver func(domain.Notification)) error {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"operationType": "insert"}}}}
+	opts := options.ChangeStream()
+	s.mu.Lock()
+	if s.resumeToken != nil {
+		opts.SetResumeAfter(s.resumeToken)
+	}
+	s.mu.Unlock()
+
+	stream, err := s.collection.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var event struct {
+			FullDocument dtodbrep.NotificationResponse `bson:"fullDocument"`
+		}
+		if err := stream.Decode(&event); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.resumeToken = stream.ResumeToken()
+		s.mu.Unlock()
+		deliver(notificationFromDTO(event.FullDocument))
+	}
+	return stream.Err()
+}
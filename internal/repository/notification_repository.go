@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"write_base/internal/domain"
+	dtodbrep "write_base/internal/repository/dto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoNotificationRepository struct {
+	collection  *mongo.Collection
+	preferences *mongo.Collection
+}
+
+func NewMongoNotificationRepository(db *mongo.Database) *MongoNotificationRepository {
+	collection := db.Collection("notifications")
+	// Pages are read newest first per user; unread counts use the second index
+	collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("user_created")},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "read", Value: 1}}, Options: options.Index().SetName("user_read")},
+	})
+	return &MongoNotificationRepository{collection: collection, preferences: db.Collection("notification_preferences")}
+}
+
+func notificationFromDTO(dto dtodbrep.NotificationResponse) domain.Notification {
+	return domain.Notification{
+		ID:        dto.ID,
+		UserID:    dto.UserID,
+		Type:      domain.NotificationType(dto.Type),
+		ActorID:   dto.ActorID,
+		ArticleID: dto.ArticleID,
+		CommentID: dto.CommentID,
+		ReportID:  dto.ReportID,
+		Message:   dto.Message,
+		Read:      dto.Read,
+		CreatedAt: dto.CreatedAt,
+	}
+}
+
+func (r *MongoNotificationRepository) Create(ctx context.Context, n *domain.Notification) error {
+	dto := dtodbrep.NotificationResponse{
+		ID:        n.ID,
+		UserID:    n.UserID,
+		Type:      string(n.Type),
+		ActorID:   n.ActorID,
+		ArticleID: n.ArticleID,
+		CommentID: n.CommentID,
+		ReportID:  n.ReportID,
+		Message:   n.Message,
+		Read:      n.Read,
+		CreatedAt: n.CreatedAt,
+	}
+	res, err := r.collection.InsertOne(ctx, dto)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok && n.ID == "" {
+		n.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *MongoNotificationRepository) List(ctx context.Context, userID string, q domain.NotificationQuery) ([]domain.Notification, error) {
+	filter := bson.M{"user_id": userID}
+	if q.UnreadOnly {
+		filter["read"] = false
+	}
+	if q.After != nil {
+		oid, err := primitive.ObjectIDFromHex(q.After.ID)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": q.After.CreatedAt}},
+			bson.M{"created_at": q.After.CreatedAt, "_id": bson.M{"$lt": oid}},
+		}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(q.Limit))
+	cur, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var out []domain.Notification
+	for cur.Next(ctx) {
+		var dto dtodbrep.NotificationResponse
+		if err := cur.Decode(&dto); err != nil {
+			return nil, err
+		}
+		out = append(out, notificationFromDTO(dto))
+	}
+	return out, cur.Err()
+}
+
+// ListAfter relies on ObjectIDs growing with insertion time, which holds
+// across instances to the second.
+func (r *MongoNotificationRepository) ListAfter(ctx context.Context, userID, lastID string, limit int) ([]domain.Notification, error) {
+	oid, err := primitive.ObjectIDFromHex(lastID)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit))
+	cur, err := r.collection.Find(ctx, bson.M{"user_id": userID, "_id": bson.M{"$gt": oid}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var out []domain.Notification
+	for cur.Next(ctx) {
+		var dto dtodbrep.NotificationResponse
+		if err := cur.Decode(&dto); err != nil {
+			return nil, err
+		}
+		out = append(out, notificationFromDTO(dto))
+	}
+	return out, cur.Err()
+}
+
+func (r *MongoNotificationRepository) CountUnread(ctx context.Context, userID string) (int, error) {
+	n, err := r.collection.CountDocuments(ctx, bson.M{"user_id": userID, "read": false})
+	return int(n), err
+}
+
+func (r *MongoNotificationRepository) MarkRead(ctx context.Context, userID, notificationID string) error {
+	filter := idFilter(notificationID)
+	filter["user_id"] = userID
+	res, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"read": true}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return domain.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *MongoNotificationRepository) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	res, err := r.collection.UpdateMany(ctx, bson.M{"user_id": userID, "read": false}, bson.M{"$set": bson.M{"read": true}})
+	if err != nil {
+		return 0, err
+	}
+	return int(res.ModifiedCount), nil
+}
+
+// Preferences are stored as the types a user turned off, so types added
+// later are on by default.
+func (r *MongoNotificationRepository) GetDisabledTypes(ctx context.Context, userID string) ([]domain.NotificationType, error) {
+	var doc struct {
+		Disabled []string `bson:"disabled"`
+	}
+	err := r.preferences.FindOne(ctx, bson.M{"_id": userID}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	types := make([]domain.NotificationType, 0, len(doc.Disabled))
+	for _, t := range doc.Disabled {
+		types = append(types, domain.NotificationType(t))
+	}
+	return types, nil
+}
+
+func (r *MongoNotificationRepository) SetDisabledTypes(ctx context.Context, userID string, types []domain.NotificationType) error {
+	disabled := make([]string, 0, len(types))
+	for _, t := range types {
+		disabled = append(disabled, string(t))
+	}
+	_, err := r.preferences.UpdateOne(ctx,
+		bson.M{"_id": userID},
+		bson.M{"$set": bson.M{"disabled": disabled}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"testing"
+	"write_base/internal/domain"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Counter cursors resume after the last (value, _id), compared as numbers.
+func TestPaginateArticles_CounterCursorKeyset(t *testing.T) {
+	cursor := domain.ArticleCursor{SortField: domain.SortByViewCount, SortOrder: "desc", Value: "42", ID: "a7"}.Encode()
+	query, opts, err := paginateArticles(bson.M{"status": "published"}, domain.Pagination{PageSize: 10, Cursor: cursor}, domain.SortByTrending)
+	require.NoError(t, err)
+	require.Equal(t, bson.M{"$and": []bson.M{
+		{"status": "published"},
+		{"$or": []bson.M{
+			{domain.SortByViewCount: bson.M{"$lt": 42}},
+			{domain.SortByViewCount: 42, "_id": bson.M{"$lt": "a7"}},
+		}},
+	}}, query)
+	require.Equal(t, bson.D{{Key: domain.SortByViewCount, Value: -1}, {Key: "_id", Value: -1}}, opts.Sort)
+	require.Nil(t, opts.Skip)
+}
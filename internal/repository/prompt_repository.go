@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"write_base/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PromptTemplateRepositoryImpl struct {
+	collection *mongo.Collection
+}
+
+type PromptTemplateDTO struct {
+	ID          string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	Version     int       `bson:"version"`
+	Body        string    `bson:"body"`
+	Description string    `bson:"description"`
+	UpdatedBy   string    `bson:"updated_by"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
+
+func NewPromptTemplateRepository(db *mongo.Database) domain.IPromptTemplateRepository {
+	collection := db.Collection("prompt_templates")
+
+	// One document per (name, version); the latest version is the active one
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}, {Key: "version", Value: -1}},
+		Options: options.Index().SetUnique(true).SetName("uniq_name_version"),
+	}
+	collection.Indexes().CreateOne(context.Background(), indexModel)
+
+	return &PromptTemplateRepositoryImpl{collection: collection}
+}
+
+func toPromptTemplateDTO(tpl *domain.PromptTemplate) *PromptTemplateDTO {
+	return &PromptTemplateDTO{
+		ID:          tpl.ID,
+		Name:        tpl.Name,
+		Version:     tpl.Version,
+		Body:        tpl.Body,
+		Description: tpl.Description,
+		UpdatedBy:   tpl.UpdatedBy,
+		CreatedAt:   tpl.CreatedAt,
+	}
+}
+
+func toDomainPromptTemplate(dto *PromptTemplateDTO) *domain.PromptTemplate {
+	return &domain.PromptTemplate{
+		ID:          dto.ID,
+		Name:        dto.Name,
+		Version:     dto.Version,
+		Body:        dto.Body,
+		Description: dto.Description,
+		UpdatedBy:   dto.UpdatedBy,
+		CreatedAt:   dto.CreatedAt,
+	}
+}
+
+func (r *PromptTemplateRepositoryImpl) Create(ctx context.Context, tpl *domain.PromptTemplate) error {
+	tpl.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, toPromptTemplateDTO(tpl))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrConflict
+		}
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *PromptTemplateRepositoryImpl) GetLatest(ctx context.Context, name string) (*domain.PromptTemplate, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	var dto PromptTemplateDTO
+	err := r.collection.FindOne(ctx, bson.M{"name": name}, opts).Decode(&dto)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrPromptTemplateNotFound
+		}
+		return nil, domain.ErrInternalServer
+	}
+	return toDomainPromptTemplate(&dto), nil
+}
+
+func (r *PromptTemplateRepositoryImpl) ListVersions(ctx context.Context, name string) ([]domain.PromptTemplate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"name": name}, opts)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	defer cursor.Close(ctx)
+
+	var templates []domain.PromptTemplate
+	for cursor.Next(ctx) {
+		var dto PromptTemplateDTO
+		if err := cursor.Decode(&dto); err != nil {
+			return nil, domain.ErrInternalServer
+		}
+		templates = append(templates, *toDomainPromptTemplate(&dto))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	return templates, nil
+}
@@ -6,7 +6,9 @@ import (
 	dtodbrep "write_base/internal/repository/dto"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoReactionRepository struct {
@@ -14,24 +16,83 @@ type MongoReactionRepository struct {
 }
 
 func NewMongoReactionRepository(collection *mongo.Collection) *MongoReactionRepository {
+	collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		// Comment threads count likes per comment
+		{Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "type", Value: 1}}, Options: options.Index().SetName("comment_type")},
+		// Article summaries count by type among the reactions without a comment
+		{Keys: bson.D{{Key: "post_id", Value: 1}, {Key: "comment_id", Value: 1}, {Key: "type", Value: 1}}, Options: options.Index().SetName("post_comment_type")},
+		// One reaction of each type per user and target
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "post_id", Value: 1}, {Key: "comment_id", Value: 1}, {Key: "type", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("uniq_user_target_type"),
+		},
+	})
 	return &MongoReactionRepository{collection: collection}
 }
 
-func (r *MongoReactionRepository) AddReaction(ctx context.Context, reaction *domain.Reaction) error {
-	dto := dtodbrep.ReactionResponse{
-		ID:        reaction.ID,
-		PostID:    reaction.PostID,
-		UserID:    reaction.UserID,
-		CommentID: reaction.CommentID,
-		Type:      string(reaction.Type),
-		CreatedAt: reaction.CreatedAt,
-	}
-	_, err := r.collection.InsertOne(ctx, dto)
-	return err
+// targetFilter matches the reactions on a comment, or on the article itself
+// when no comment is given.
+func targetFilter(t domain.ReactionTarget) bson.M {
+	if t.CommentID != "" {
+		return bson.M{"comment_id": t.CommentID}
+	}
+	return bson.M{"post_id": t.PostID, "comment_id": nil}
+}
+
+// userReactionFilter matches the one reaction a user may have of a type on a target.
+func userReactionFilter(reaction *domain.Reaction) bson.M {
+	filter := targetFilter(reaction.Target())
+	filter["post_id"] = reaction.PostID
+	filter["user_id"] = reaction.UserID
+	filter["type"] = string(reaction.Type)
+	return filter
+}
+
+func (r *MongoReactionRepository) AddReaction(ctx context.Context, reaction *domain.Reaction) (bool, error) {
+	// The filter's fields make up the inserted document
+	filter := userReactionFilter(reaction)
+	update := bson.M{"$setOnInsert": bson.M{"created_at": reaction.CreatedAt}}
+	res, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent request added it first
+		res, err = &mongo.UpdateResult{}, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if oid, ok := res.UpsertedID.(primitive.ObjectID); ok {
+		reaction.ID = oid.Hex()
+		return true, nil
+	}
+	var dto dtodbrep.ReactionResponse
+	if err := r.collection.FindOne(ctx, filter).Decode(&dto); err != nil {
+		return false, err
+	}
+	reaction.ID, reaction.CreatedAt = dto.ID, dto.CreatedAt
+	return false, nil
+}
+
+func (r *MongoReactionRepository) ToggleReaction(ctx context.Context, reaction *domain.Reaction) (bool, error) {
+	// Each pass either removes or inserts the reaction itself, so every call
+	// changes the count by exactly one; a concurrent toggle forces another pass
+	for attempt := 0; attempt < 3; attempt++ {
+		res, err := r.collection.DeleteOne(ctx, userReactionFilter(reaction))
+		if err != nil {
+			return false, err
+		}
+		if res.DeletedCount > 0 {
+			return false, nil
+		}
+		added, err := r.AddReaction(ctx, reaction)
+		if err != nil || added {
+			return added, err
+		}
+	}
+	return false, domain.ErrInternalServer
 }
 
 func (r *MongoReactionRepository) RemoveReaction(ctx context.Context, reactionID string) error {
-	filter := bson.M{"_id": reactionID}
+	filter := idFilter(reactionID)
 	res, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
 		return err
@@ -42,6 +103,25 @@ func (r *MongoReactionRepository) RemoveReaction(ctx context.Context, reactionID
 	return nil
 }
 
+func (r *MongoReactionRepository) GetByID(ctx context.Context, reactionID string) (*domain.Reaction, error) {
+	var dto dtodbrep.ReactionResponse
+	err := r.collection.FindOne(ctx, idFilter(reactionID)).Decode(&dto)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrReactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Reaction{
+		ID:        dto.ID,
+		PostID:    dto.PostID,
+		UserID:    dto.UserID,
+		CommentID: dto.CommentID,
+		Type:      domain.ReactionType(dto.Type),
+		CreatedAt: dto.CreatedAt,
+	}, nil
+}
+
 func (r *MongoReactionRepository) GetReactionsByPost(ctx context.Context, postID string) ([]*domain.Reaction, error) {
 	filter := bson.M{"post_id": postID}
 	cur, err := r.collection.Find(ctx, filter)
@@ -103,3 +183,44 @@ func (r *MongoReactionRepository) CountReactions(ctx context.Context, postID str
 	count, err := r.collection.CountDocuments(ctx, filter)
 	return int(count), err
 }
+
+func (r *MongoReactionRepository) CountByType(ctx context.Context, target domain.ReactionTarget) (map[domain.ReactionType]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: targetFilter(target)}},
+		{{Key: "$group", Value: bson.M{"_id": "$type", "count": bson.M{"$sum": 1}}}},
+	}
+	cur, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	counts := map[domain.ReactionType]int{}
+	for cur.Next(ctx) {
+		var row struct {
+			Type  string `bson:"_id"`
+			Count int    `bson:"count"`
+		}
+		if err := cur.Decode(&row); err != nil {
+			return nil, err
+		}
+		counts[domain.ReactionType(row.Type)] = row.Count
+	}
+	return counts, cur.Err()
+}
+
+func (r *MongoReactionRepository) GetUserReactionTypes(ctx context.Context, userID string, target domain.ReactionTarget) ([]domain.ReactionType, error) {
+	filter := targetFilter(target)
+	filter["user_id"] = userID
+	values, err := r.collection.Distinct(ctx, "type", filter)
+	if err != nil {
+		return nil, err
+	}
+	types := make([]domain.ReactionType, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			types = append(types, domain.ReactionType(s))
+		}
+	}
+	return types, nil
+}
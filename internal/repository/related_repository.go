@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"write_base/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// relatedReaderSample bounds how many of an article's most recent readers are
+// followed to the other articles they engaged with.
+const relatedReaderSample = 500
+
+// readerSource describes where readers of an article are recorded.
+type readerSource struct {
+	collection string
+	articleKey string
+	userKey    string
+	timeKey    string
+}
+
+var readerSources = []readerSource{
+	{collection: "claps", articleKey: "article_id", userKey: "user_id", timeKey: "updated_at"},
+	// Only signed-in views carry a user; the log is kept for the trending window
+	{collection: viewsCollection, articleKey: viewArticleField, userKey: viewUserField, timeKey: viewTimeField},
+}
+
+type RelatedRepository struct {
+	db       *mongo.Database
+	articles *mongo.Collection
+}
+
+func NewRelatedRepository(db *mongo.Database) domain.IRelatedRepository {
+	return &RelatedRepository{db: db, articles: db.Collection("articles")}
+}
+
+// TagAuthorCandidates ranks by shared tags in the database so the pool keeps the
+// closest matches, newest first among equals.
+func (r *RelatedRepository) TagAuthorCandidates(ctx context.Context, article domain.Article, limit int) ([]domain.Article, error) {
+	or := bson.A{bson.M{"author_id": article.AuthorID}}
+	tags := article.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	if len(tags) > 0 {
+		or = append(or, bson.M{"tags": bson.M{"$in": tags}})
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"status": string(domain.StatusPublished),
+			"_id":    bson.M{"$ne": article.ID},
+			"$or":    or,
+		}}},
+		{{Key: "$addFields", Value: bson.M{"shared_tags": bson.M{"$size": bson.M{
+			"$setIntersection": bson.A{bson.M{"$ifNull": bson.A{"$tags", bson.A{}}}, tags},
+		}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "shared_tags", Value: -1}, {Key: "timestamps.published_at", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.M{"content_blocks": 0, "shared_tags": 0}}},
+	}
+	cursor, err := r.articles.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var out []domain.Article
+	for cursor.Next(ctx) {
+		var dto ArticleDTO
+		if err := cursor.Decode(&dto); err != nil {
+			return nil, err
+		}
+		out = append(out, *FromArticleDTO(&dto))
+	}
+	return out, cursor.Err()
+}
+
+func (r *RelatedRepository) CoEngaged(ctx context.Context, articleID string, limit int) (map[string]int, error) {
+	readers := map[string]bool{}
+	for _, src := range readerSources {
+		ids, err := r.recentReaders(ctx, src, articleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			readers[id] = true
+		}
+	}
+	if len(readers) == 0 {
+		return map[string]int{}, nil
+	}
+	readerIDs := make([]string, 0, len(readers))
+	for id := range readers {
+		readerIDs = append(readerIDs, id)
+	}
+
+	// A reader who both clapped and viewed an article counts once
+	coReaders := map[string]map[string]bool{}
+	for _, src := range readerSources {
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{
+				src.userKey:    bson.M{"$in": readerIDs},
+				src.articleKey: bson.M{"$ne": articleID},
+			}}},
+			{{Key: "$group", Value: bson.M{"_id": "$" + src.articleKey, "users": bson.M{"$addToSet": "$" + src.userKey}}}},
+		}
+		cursor, err := r.db.Collection(src.collection).Aggregate(ctx, pipeline)
+		if err != nil {
+			return nil, err
+		}
+		for cursor.Next(ctx) {
+			var row struct {
+				ID    string   `bson:"_id"`
+				Users []string `bson:"users"`
+			}
+			if err := cursor.Decode(&row); err != nil {
+				cursor.Close(ctx)
+				return nil, err
+			}
+			if coReaders[row.ID] == nil {
+				coReaders[row.ID] = map[string]bool{}
+			}
+			for _, u := range row.Users {
+				coReaders[row.ID][u] = true
+			}
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]string, 0, len(coReaders))
+	for id := range coReaders {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if len(coReaders[ids[i]]) != len(coReaders[ids[j]]) {
+			return len(coReaders[ids[i]]) > len(coReaders[ids[j]])
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	counts := make(map[string]int, len(ids))
+	for _, id := range ids {
+		counts[id] = len(coReaders[id])
+	}
+	return counts, nil
+}
+
+// recentReaders returns the signed-in users who most recently engaged with the article.
+func (r *RelatedRepository) recentReaders(ctx context.Context, src readerSource, articleID string) ([]string, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{src.articleKey: articleID, src.userKey: bson.M{"$nin": bson.A{"", nil}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + src.userKey, "last": bson.M{"$max": "$" + src.timeKey}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "last", Value: -1}}}},
+		{{Key: "$limit", Value: relatedReaderSample}},
+	}
+	cursor, err := r.db.Collection(src.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ids []string
+	for cursor.Next(ctx) {
+		var row struct {
+			ID string `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		ids = append(ids, row.ID)
+	}
+	return ids, cursor.Err()
+}
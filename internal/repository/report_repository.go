@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"write_base/internal/domain"
 	dtodbrep "write_base/internal/repository/dto"
 
@@ -44,16 +45,7 @@ func (r *MongoReportRepository) GetReports(ctx context.Context, filter map[strin
 		if err := cur.Decode(&dto); err != nil {
 			return nil, err
 		}
-		results = append(results, &domain.Report{
-			ID:         dto.ID,
-			ReporterID: dto.ReporterID,
-			TargetID:   dto.TargetID,
-			TargetType: dto.TargetType,
-			Reason:     dto.Reason,
-			Status:     domain.ReportStatus(dto.Status),
-			CreatedAt:  dto.CreatedAt,
-			ResolvedAt: dto.ResolvedAt,
-		})
+		results = append(results, reportFromDTO(dto))
 	}
 	if err := cur.Err(); err != nil {
 		return nil, err
@@ -61,9 +53,38 @@ func (r *MongoReportRepository) GetReports(ctx context.Context, filter map[strin
 	return results, nil
 }
 
+func reportFromDTO(dto dtodbrep.ReportResponse) *domain.Report {
+	return &domain.Report{
+		ID:         dto.ID,
+		ReporterID: dto.ReporterID,
+		TargetID:   dto.TargetID,
+		TargetType: dto.TargetType,
+		Reason:     dto.Reason,
+		Status:     domain.ReportStatus(dto.Status),
+		CreatedAt:  dto.CreatedAt,
+		ResolvedAt: dto.ResolvedAt,
+	}
+}
+
+func (r *MongoReportRepository) GetReportByID(ctx context.Context, reportID string) (*domain.Report, error) {
+	var dto dtodbrep.ReportResponse
+	err := r.collection.FindOne(ctx, bson.M{"_id": reportID}).Decode(&dto)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrReportNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reportFromDTO(dto), nil
+}
+
 func (r *MongoReportRepository) UpdateReportStatus(ctx context.Context, reportID string, status domain.ReportStatus) error {
 	filter := bson.M{"_id": reportID}
-	update := bson.M{"$set": bson.M{"status": string(status)}}
+	set := bson.M{"status": string(status)}
+	if status == domain.ReportResolved {
+		set["resolved_at"] = time.Now().Unix()
+	}
+	update := bson.M{"$set": set}
 	res, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
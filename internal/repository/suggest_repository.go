@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"write_base/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SuggestRepository struct {
+	articles *mongo.Collection
+	tags     *mongo.Collection
+	users    *mongo.Collection
+}
+
+func NewSuggestRepository(db *mongo.Database) domain.ISuggestRepository {
+	return &SuggestRepository{
+		articles: db.Collection("articles"),
+		tags:     db.Collection("tags"),
+		users:    db.Collection("users"),
+	}
+}
+
+// SuggestionCandidates loads published article titles weighted by engagement,
+// approved tags weighted by how many published articles use them, and
+// verified users weighted by how many articles they have published.
+func (r *SuggestRepository) SuggestionCandidates(ctx context.Context) ([]domain.Suggestion, error) {
+	out, err := r.articleCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagCounts, err := r.publishedCounts(ctx, "$tags", true)
+	if err != nil {
+		return nil, err
+	}
+	authorCounts, err := r.publishedCounts(ctx, "$author_id", false)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := r.tags.Find(ctx, bson.M{"status": string(domain.TagStatusApproved)},
+		options.Find().SetProjection(bson.M{"name": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer tags.Close(ctx)
+	for tags.Next(ctx) {
+		var t struct {
+			ID   string `bson:"_id"`
+			Name string `bson:"name"`
+		}
+		if err := tags.Decode(&t); err != nil {
+			return nil, err
+		}
+		out = append(out, domain.Suggestion{Kind: domain.SuggestTag, ID: t.ID, Text: t.Name, Weight: float64(tagCounts[t.Name])})
+	}
+	if err := tags.Err(); err != nil {
+		return nil, err
+	}
+
+	users, err := r.users.Find(ctx, bson.M{"verified": true, "username": bson.M{"$ne": ""}},
+		options.Find().SetProjection(bson.M{"username": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer users.Close(ctx)
+	for users.Next(ctx) {
+		var u struct {
+			ID       string `bson:"_id"`
+			Username string `bson:"username"`
+		}
+		if err := users.Decode(&u); err != nil {
+			return nil, err
+		}
+		out = append(out, domain.Suggestion{Kind: domain.SuggestUser, ID: u.ID, Text: u.Username, Weight: float64(authorCounts[u.ID])})
+	}
+	return out, users.Err()
+}
+
+func (r *SuggestRepository) articleCandidates(ctx context.Context) ([]domain.Suggestion, error) {
+	cursor, err := r.articles.Find(ctx, bson.M{"status": string(domain.StatusPublished)},
+		options.Find().SetProjection(bson.M{"title": 1, "slug": 1, "stats": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var out []domain.Suggestion
+	for cursor.Next(ctx) {
+		var a struct {
+			ID    string          `bson:"_id"`
+			Title string          `bson:"title"`
+			Slug  string          `bson:"slug"`
+			Stats ArticleStatsDTO `bson:"stats"`
+		}
+		if err := cursor.Decode(&a); err != nil {
+			return nil, err
+		}
+		out = append(out, domain.Suggestion{
+			Kind:   domain.SuggestArticle,
+			ID:     a.ID,
+			Text:   a.Title,
+			Slug:   a.Slug,
+			Weight: a.Stats.TrendingScore + math.Log1p(float64(a.Stats.ViewsCount)),
+		})
+	}
+	return out, cursor.Err()
+}
+
+// publishedCounts counts published articles per value of field.
+func (r *SuggestRepository) publishedCounts(ctx context.Context, field string, unwind bool) (map[string]int, error) {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"status": string(domain.StatusPublished)}}}}
+	if unwind {
+		pipeline = append(pipeline, bson.D{{Key: "$unwind", Value: field}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$group", Value: bson.M{"_id": field, "count": bson.M{"$sum": 1}}}})
+
+	cursor, err := r.articles.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := map[string]int{}
+	for cursor.Next(ctx) {
+		var row struct {
+			ID    string `bson:"_id"`
+			Count int    `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		counts[row.ID] = row.Count
+	}
+	return counts, cursor.Err()
+}
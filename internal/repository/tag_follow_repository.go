@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"write_base/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TagFollowDTO struct {
+	UserID    string    `bson:"user_id"`
+	TagID     string    `bson:"tag_id"`
+	TagName   string    `bson:"tag_name"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type TagFollowRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTagFollowRepository(db *mongo.Database) domain.ITagFollowRepository {
+	collection := db.Collection("tag_follows")
+
+	// One follow per user and tag; tag_name serves follower lookups by article tags
+	collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "tag_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("uniq_user_tag"),
+		},
+		{
+			Keys:    bson.D{{Key: "tag_name", Value: 1}},
+			Options: options.Index().SetName("tag_name"),
+		},
+	})
+
+	return &TagFollowRepository{collection: collection}
+}
+
+func (r *TagFollowRepository) Follow(ctx context.Context, follow *domain.TagFollow) error {
+	_, err := r.collection.InsertOne(ctx, TagFollowDTO{
+		UserID:    follow.UserID,
+		TagID:     follow.TagID,
+		TagName:   follow.TagName,
+		CreatedAt: follow.CreatedAt,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrAlreadyFollowingTag
+	}
+	return err
+}
+
+func (r *TagFollowRepository) Unfollow(ctx context.Context, userID, tagID string) error {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "tag_id": tagID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return domain.ErrTagFollowNotFound
+	}
+	return nil
+}
+
+func (r *TagFollowRepository) ListByUser(ctx context.Context, userID string) ([]domain.TagFollow, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "tag_name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var follows []domain.TagFollow
+	for cursor.Next(ctx) {
+		var dto TagFollowDTO
+		if err := cursor.Decode(&dto); err != nil {
+			return nil, err
+		}
+		follows = append(follows, domain.TagFollow{
+			UserID:    dto.UserID,
+			TagID:     dto.TagID,
+			TagName:   dto.TagName,
+			CreatedAt: dto.CreatedAt,
+		})
+	}
+	return follows, cursor.Err()
+}
+
+func (r *TagFollowRepository) FollowerCounts(ctx context.Context, tagIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(tagIDs))
+	if len(tagIDs) == 0 {
+		return counts, nil
+	}
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"tag_id": bson.M{"$in": tagIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$tag_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var row struct {
+			ID    string `bson:"_id"`
+			Count int    `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		counts[row.ID] = row.Count
+	}
+	return counts, cursor.Err()
+}
+
+func (r *TagFollowRepository) FollowerIDs(ctx context.Context, tagNames []string) ([]string, error) {
+	if len(tagNames) == 0 {
+		return nil, nil
+	}
+	ids, err := r.collection.Distinct(ctx, "user_id", bson.M{"tag_name": bson.M{"$in": tagNames}})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if s, ok := id.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// MoveFollows re-points follows at the target tag. Users already following the
+// target keep their existing follow, matched on the uniq_user_tag index.
+func (r *TagFollowRepository) MoveFollows(ctx context.Context, fromTagID, toTagID, toTagName string) error {
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"tag_id": fromTagID}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":        0,
+			"user_id":    1,
+			"created_at": 1,
+			"tag_id":     bson.M{"$literal": toTagID},
+			"tag_name":   bson.M{"$literal": toTagName},
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           r.collection.Name(),
+			"on":             bson.A{"user_id", "tag_id"},
+			"whenMatched":    "keepExisting",
+			"whenNotMatched": "insert",
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+	cursor.Close(ctx)
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"tag_id": fromTagID})
+	return err
+}
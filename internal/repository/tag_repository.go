@@ -8,6 +8,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TagRepositoryImpl struct {
@@ -20,11 +21,21 @@ type TagDTO struct {
 	Status    string    `bson:"status"`
 	CreatedBy string    `bson:"created_by"`
 	CreatedAt time.Time `bson:"created_at"`
+	Synonyms  []string  `bson:"synonyms,omitempty"`
+	ParentID  string    `bson:"parent_id,omitempty"`
 }
 
 func NewTagRepository(db *mongo.Database) domain.TagRepository {
+	collection := db.Collection("tags")
+
+	// Synonym resolution runs on every article write; parent_id serves hierarchy walks
+	collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "synonyms", Value: 1}}, Options: options.Index().SetName("synonyms")},
+		{Keys: bson.D{{Key: "parent_id", Value: 1}}, Options: options.Index().SetName("parent_id")},
+	})
+
 	return &TagRepositoryImpl{
-		collection: db.Collection("tags"),
+		collection: collection,
 	}
 }
 
@@ -36,6 +47,8 @@ func toTagDTO(tag *domain.Tag) *TagDTO {
 		Status:    string(tag.Status),
 		CreatedBy: tag.CreatedBy,
 		CreatedAt: tag.CreatedAt,
+		Synonyms:  tag.Synonyms,
+		ParentID:  tag.ParentID,
 	}
 }
 
@@ -47,6 +60,8 @@ func toDomainTag(dto *TagDTO) *domain.Tag {
 		Status:    domain.TagStatus(dto.Status),
 		CreatedBy: dto.CreatedBy,
 		CreatedAt: dto.CreatedAt,
+		Synonyms:  dto.Synonyms,
+		ParentID:  dto.ParentID,
 	}
 }
 
@@ -69,8 +84,14 @@ func (r *TagRepositoryImpl) Create(ctx context.Context, tag *domain.Tag) (*domai
 
 func (r *TagRepositoryImpl) Update(ctx context.Context, tag *domain.Tag) (*domain.Tag, error) {
 	filter := bson.M{"_id": tag.ID}
+	synonyms := tag.Synonyms
+	if synonyms == nil {
+		synonyms = []string{}
+	}
 	update := bson.M{"$set": bson.M{
-		"status": string(tag.Status),
+		"status":    string(tag.Status),
+		"synonyms":  synonyms,
+		"parent_id": tag.ParentID,
 	}}
 	
 	_, err := r.collection.UpdateOne(ctx, filter, update)
@@ -92,6 +113,18 @@ func (r *TagRepositoryImpl) GetByName(ctx context.Context, name string) (*domain
 	return toDomainTag(&dto), nil
 }
 
+func (r *TagRepositoryImpl) GetBySynonym(ctx context.Context, synonym string) (*domain.Tag, error) {
+	var dto TagDTO
+	err := r.collection.FindOne(ctx, bson.M{"synonyms": domain.NormalizeSynonym(synonym)}).Decode(&dto)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrTagNotFound
+		}
+		return nil, domain.ErrInternalServer
+	}
+	return toDomainTag(&dto), nil
+}
+
 func (r *TagRepositoryImpl) GetByID(ctx context.Context, ID string) (*domain.Tag, error) {
 	var dto TagDTO
 	err := r.collection.FindOne(ctx, bson.M{"_id": ID}).Decode(&dto)
@@ -109,6 +142,9 @@ func (r *TagRepositoryImpl) List(ctx context.Context, filter domain.TagFilter) (
 	if filter.Status != "" {
 		query["status"] = string(filter.Status)
 	}
+	if filter.ParentID != "" {
+		query["parent_id"] = filter.ParentID
+	}
 
 	cursor, err := r.collection.Find(ctx, query)
 	if err != nil {
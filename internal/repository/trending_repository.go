@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"write_base/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// engagementSource describes where one kind of engagement is recorded.
+type engagementSource struct {
+	kind       domain.EngagementKind
+	collection string
+	articleKey string
+	timeKey    string
+	// unixTime is set when timeKey holds unix seconds rather than a date
+	unixTime bool
+	match    bson.M
+}
+
+var engagementSources = []engagementSource{
+	// Only counted views are logged, so refreshes and bots do not trend
+	{kind: domain.EngagementView, collection: viewsCollection, articleKey: viewArticleField, timeKey: viewTimeField},
+	// One event per clap, so each decays from when it was given
+	{kind: domain.EngagementClap, collection: clapEventsCollection, articleKey: clapEventArticleField, timeKey: clapEventTimeField,
+		match: bson.M{clapEventRetractedField: nil}},
+	{kind: domain.EngagementComment, collection: "comments", articleKey: "post_id", timeKey: "created_at", unixTime: true,
+		match: bson.M{"deleted": bson.M{"$ne": true}}},
+	// Only reactions on the article itself, not on its comments
+	{kind: domain.EngagementReaction, collection: "reactions", articleKey: "post_id", timeKey: "created_at", unixTime: true,
+		match: bson.M{"comment_id": nil}},
+}
+
+type TrendingRepository struct {
+	db       *mongo.Database
+	articles *mongo.Collection
+}
+
+func NewTrendingRepository(db *mongo.Database) domain.ITrendingRepository {
+	return &TrendingRepository{db: db, articles: db.Collection("articles")}
+}
+
+func (r *TrendingRepository) EngagementSince(ctx context.Context, since time.Time) ([]domain.EngagementBucket, error) {
+	var buckets []domain.EngagementBucket
+	for _, src := range engagementSources {
+		b, err := r.aggregateSource(ctx, src, since)
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, b...)
+	}
+	return buckets, nil
+}
+
+// aggregateSource groups one collection by article and hour.
+func (r *TrendingRepository) aggregateSource(ctx context.Context, src engagementSource, since time.Time) ([]domain.EngagementBucket, error) {
+	match := bson.M{}
+	for k, v := range src.match {
+		match[k] = v
+	}
+	// seconds is the event time in unix seconds, whichever way it is stored
+	var seconds interface{}
+	if src.unixTime {
+		match[src.timeKey] = bson.M{"$gte": since.Unix()}
+		seconds = "$" + src.timeKey
+	} else {
+		match[src.timeKey] = bson.M{"$gte": since}
+		seconds = bson.M{"$toLong": bson.M{"$divide": bson.A{bson.M{"$toLong": "$" + src.timeKey}, 1000}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{"seconds": seconds}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"article": "$" + src.articleKey,
+				"hour":    bson.M{"$subtract": bson.A{"$seconds", bson.M{"$mod": bson.A{"$seconds", 3600}}}},
+			},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := r.db.Collection(src.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var buckets []domain.EngagementBucket
+	for cursor.Next(ctx) {
+		var row struct {
+			ID struct {
+				Article string `bson:"article"`
+				Hour    int64  `bson:"hour"`
+			} `bson:"_id"`
+			Count int `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, domain.EngagementBucket{
+			ArticleID: row.ID.Article,
+			Kind:      src.kind,
+			Hour:      time.Unix(row.ID.Hour, 0),
+			Count:     row.Count,
+		})
+	}
+	return buckets, cursor.Err()
+}
+
+func (r *TrendingRepository) SaveTrendingScores(ctx context.Context, scores map[string]float64) error {
+	ids := make([]string, 0, len(scores))
+	models := make([]mongo.WriteModel, 0, len(scores))
+	for id, score := range scores {
+		ids = append(ids, id)
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{"stats.trending_score": score}}))
+	}
+
+	// Articles that dropped out of the window no longer trend
+	if _, err := r.articles.UpdateMany(ctx,
+		bson.M{"stats.trending_score": bson.M{"$gt": 0}, "_id": bson.M{"$nin": ids}},
+		bson.M{"$set": bson.M{"stats.trending_score": 0}},
+	); err != nil {
+		return err
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	_, err := r.articles.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"write_base/internal/domain"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func bsonKey(t *testing.T, v interface{}, field string) string {
+	t.Helper()
+	f, ok := reflect.TypeOf(v).FieldByName(field)
+	require.True(t, ok, "missing field %s", field)
+	return strings.Split(f.Tag.Get("bson"), ",")[0]
+}
+
+// The views log and reader records are written through their DTOs and read by
+// key elsewhere; the keys used by readers must be the ones actually stored.
+func TestViewFieldKeys_MatchStoredDocuments(t *testing.T) {
+	require.Equal(t, viewArticleField, bsonKey(t, viewDTO{}, "ArticleID"))
+	require.Equal(t, viewUserField, bsonKey(t, viewDTO{}, "UserID"))
+	require.Equal(t, viewTimeField, bsonKey(t, viewDTO{}, "CreatedAt"))
+
+	require.Equal(t, readerArticleField, bsonKey(t, readerDTO{}, "ArticleID"))
+	require.Equal(t, readerKeyField, bsonKey(t, readerDTO{}, "ReaderKey"))
+	require.Equal(t, readerCountedField, bsonKey(t, readerDTO{}, "LastCountedAt"))
+
+	var related, trending, stats bool
+	for _, src := range readerSources {
+		if src.collection == viewsCollection {
+			related = true
+			require.Equal(t, []string{viewArticleField, viewUserField, viewTimeField}, []string{src.articleKey, src.userKey, src.timeKey})
+		}
+	}
+	for _, src := range engagementSources {
+		if src.collection == viewsCollection {
+			trending = true
+			require.Equal(t, []string{viewArticleField, viewTimeField}, []string{src.articleKey, src.timeKey})
+		}
+	}
+	for _, src := range counterSources {
+		if src.collection == readersCollection {
+			stats = true
+			require.Equal(t, readerArticleField, src.articleKey)
+		}
+	}
+	require.True(t, related && trending && stats, "views or readers source missing")
+}
+
+// Trending reads claps from the events log, one document per clap, and skips
+// retracted claps and deleted comments.
+func TestTrendingSources_ClapEventsAndLiveComments(t *testing.T) {
+	require.Equal(t, clapEventArticleField, bsonKey(t, clapEventDTO{}, "ArticleID"))
+	require.Equal(t, clapEventUserField, bsonKey(t, clapEventDTO{}, "UserID"))
+	require.Equal(t, clapEventTimeField, bsonKey(t, clapEventDTO{}, "CreatedAt"))
+	require.Equal(t, clapEventRetractedField, bsonKey(t, clapEventDTO{}, "RetractedAt"))
+
+	sources := map[domain.EngagementKind]engagementSource{}
+	for _, src := range engagementSources {
+		sources[src.kind] = src
+	}
+	claps := sources[domain.EngagementClap]
+	require.Equal(t, []string{clapEventsCollection, clapEventArticleField, clapEventTimeField}, []string{claps.collection, claps.articleKey, claps.timeKey})
+	require.Equal(t, bson.M{clapEventRetractedField: nil}, claps.match)
+	require.Equal(t, bson.M{"deleted": bson.M{"$ne": true}}, sources[domain.EngagementComment].match)
+}
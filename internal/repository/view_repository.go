@@ -10,26 +10,116 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// Collections and field names of the views log and the reader records. The
+// trending, related, feed and stats queries read them through these.
+const (
+	viewsCollection   = "views"
+	readersCollection = "article_readers"
+
+	viewArticleField = "article_id"
+	viewUserField    = "user_id"
+	viewTimeField    = "created_at"
+
+	readerArticleField = "article_id"
+	readerKeyField     = "reader_key"
+	readerCountedField = "last_counted_at"
+)
+
+type viewDTO struct {
+	ID        string    `bson:"_id"`
+	UserID    string    `bson:"user_id,omitempty"`
+	ArticleID string    `bson:"article_id"`
+	ReaderKey string    `bson:"reader_key"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// readerDTO is one reader of one article, keyed by both.
+type readerDTO struct {
+	ID            string    `bson:"_id"`
+	ArticleID     string    `bson:"article_id"`
+	ReaderKey     string    `bson:"reader_key"`
+	FirstReadAt   time.Time `bson:"first_read_at"`
+	LastCountedAt time.Time `bson:"last_counted_at"`
+}
+
 type ViewRepositoryImpl struct {
 	collection *mongo.Collection
+	// readers holds one document per reader and article, keyed by both
+	readers *mongo.Collection
+	now     func() time.Time
+}
+
+// NewViewRepository keeps the views log for retention, long enough for the
+// trending window; reader records are kept for good to count unique readers.
+func NewViewRepository(db *mongo.Database, retention time.Duration) domain.ViewRepository {
+	collection := db.Collection(viewsCollection)
+	ensureTTLIndex(collection, viewTimeField, viewTimeField+"_ttl", retention)
+
+	readers := db.Collection(readersCollection)
+	readers.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: readerArticleField, Value: 1}},
+		Options: options.Index().SetName(readerArticleField),
+	})
+	return &ViewRepositoryImpl{collection: collection, readers: readers, now: time.Now}
 }
 
-func NewViewRepository(db *mongo.Database) domain.ViewRepository {
-	collection := db.Collection("views")
-	
-	// Create TTL index for auto-expiration after 1 day
-	indexModel := mongo.IndexModel{
-		Keys:    bson.M{"created_at": 1},
-		Options: options.Index().SetExpireAfterSeconds(24 * 60 * 60),
+// ensureTTLIndex expires documents retention after field, replacing an older
+// index on the same field whose expiry differs.
+func ensureTTLIndex(collection *mongo.Collection, field, name string, retention time.Duration) {
+	ctx := context.Background()
+	model := mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: 1}},
+		Options: options.Index().SetName(name).SetExpireAfterSeconds(int32(retention.Seconds())),
 	}
-	collection.Indexes().CreateOne(context.Background(), indexModel)
-	
-	return &ViewRepositoryImpl{collection: collection}
+	if _, err := collection.Indexes().CreateOne(ctx, model); err == nil {
+		return
+	}
+	collection.Indexes().DropOne(ctx, field+"_1")
+	collection.Indexes().DropOne(ctx, name)
+	collection.Indexes().CreateOne(ctx, model)
+}
+
+func (r *ViewRepositoryImpl) TrackRead(ctx context.Context, articleID, readerKey string, window time.Duration) (bool, bool, error) {
+	now := r.now()
+	id := articleID + "|" + readerKey
+
+	// A returning reader counts again once their window has passed
+	res, err := r.readers.UpdateOne(ctx,
+		bson.M{"_id": id, readerCountedField: bson.M{"$lte": now.Add(-window)}},
+		bson.M{"$set": bson.M{readerCountedField: now}})
+	if err != nil {
+		return false, false, err
+	}
+	if res.MatchedCount > 0 {
+		return true, false, nil
+	}
+
+	_, err = r.readers.InsertOne(ctx, readerDTO{
+		ID:            id,
+		ArticleID:     articleID,
+		ReaderKey:     readerKey,
+		FirstReadAt:   now,
+		LastCountedAt: now,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		// Still within the window, or a concurrent read counted first
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return true, true, nil
 }
 
 func (r *ViewRepositoryImpl) Create(ctx context.Context, view *domain.View) error {
-	view.CreatedAt = time.Now()
-	
-	_, err := r.collection.InsertOne(ctx, view)
+	view.CreatedAt = r.now()
+
+	_, err := r.collection.InsertOne(ctx, viewDTO{
+		ID:        view.ID,
+		UserID:    view.UserID,
+		ArticleID: view.ArticleID,
+		ReaderKey: view.ReaderKey,
+		CreatedAt: view.CreatedAt,
+	})
 	return err
-}
\ No newline at end of file
+}
@@ -9,8 +9,11 @@ import (
 	"google.golang.org/genai"
 
 	"write_base/internal/domain"
+	"write_base/internal/infrastructure/ai"
 )
 
+const suggestionModel = "gemini-2.5-flash"
+
 func stripCodeFences(s string) string {
     s = strings.TrimSpace(s)
     if strings.HasPrefix(s, "```") {
@@ -31,30 +34,42 @@ func stripCodeFences(s string) string {
 }
 
 type GeminiClient struct {
-    APIKey string
+    APIKey  string
+    Prompts domain.IPromptTemplateUsecase
+    Cache   domain.IAICache // optional; suggestions are served from it when set
 }
 
-func NewGeminiClient(apiKey string) *GeminiClient {
-    return &GeminiClient{APIKey: apiKey}
+func NewGeminiClient(apiKey string, prompts domain.IPromptTemplateUsecase, cache domain.IAICache) *GeminiClient {
+    return &GeminiClient{APIKey: apiKey, Prompts: prompts, Cache: cache}
 }
 
 func (g *GeminiClient) GetSuggestions(ctx context.Context, req *domain.SuggestionRequest) (*domain.SuggestionResponse, error) {
 
+    prompt, err := g.Prompts.Render(ctx, domain.PromptAISuggestions, map[string]interface{}{"Prompt": req.Prompt})
+    if err != nil {
+        return nil, err
+    }
+
+    cacheKey := ai.CacheKey("gemini", suggestionModel, domain.PromptAISuggestions, prompt.Version, req.Prompt)
+    if g.Cache != nil {
+        if cached, ok, err := g.Cache.Get(ctx, cacheKey); err == nil && ok {
+            var resp domain.SuggestionResponse
+            if json.Unmarshal([]byte(cached), &resp) == nil {
+                return &resp, nil
+            }
+        }
+    }
+
     client, err := genai.NewClient(ctx, nil)
     if err != nil {
         return nil, fmt.Errorf("failed to create genai client: %w", err)
     }
     // defer client.Close()
 
-    prompt := fmt.Sprintf(`Given the topic or keywords: "%s", respond ONLY with a valid JSON object with two fields: "suggestions" (an array of 3 creative blog post ideas) and "improvements" (an array of 3 ways to improve a draft blog post on this topic).
-Do NOT include markdown, code blocks, or any text before or after the JSON.
-Do NOT generate or suggest any content that is hateful, abusive, harassing, violent, or otherwise inappropriate.
-If the prompt asks for such content, respond with: {"suggestions": [], "improvements": []}`, req.Prompt)
-
     result, err := client.Models.GenerateContent(
         ctx,
-        "gemini-2.5-flash", // or "gemini-pro"
-        genai.Text(prompt),
+        suggestionModel, // or "gemini-pro"
+        genai.Text(prompt.Text),
         nil,
     )
     if err != nil {
@@ -71,10 +86,16 @@ If the prompt asks for such content, respond with: {"suggestions": [], "improvem
         return nil, fmt.Errorf("Failed to parse Gemini JSON: %w", err)
     }
 
-    return &domain.SuggestionResponse{
+    resp := &domain.SuggestionResponse{
         Suggestions:  parsed.Suggestions,
         Improvements: parsed.Improvements,
-    }, nil
+    }
+    if g.Cache != nil {
+        if encoded, err := json.Marshal(resp); err == nil {
+            _ = g.Cache.Set(ctx, cacheKey, string(encoded))
+        }
+    }
+    return resp, nil
 }
 
 func (g *GeminiClient) GenerateContent(ctx context.Context, req *domain.GenerateContentRequest) (*domain.GenerateContentResponse, error) {
@@ -85,15 +106,15 @@ func (g *GeminiClient) GenerateContent(ctx context.Context, req *domain.Generate
     }
     // defer client.Close()
 
-    prompt := fmt.Sprintf(  `Write a detailed, engaging blog post about: "%s".
-Do NOT generate or suggest any content that is hateful, abusive, harassing, violent, or otherwise inappropriate.
-If the prompt asks for such content, respond with: "Content not allowed."
-Respond ONLY with the blog content, with no introduction or ending fluff just the content.`, req.Prompt)
+    prompt, err := g.Prompts.Render(ctx, domain.PromptAIGenerateContent, map[string]interface{}{"Prompt": req.Prompt})
+    if err != nil {
+        return nil, err
+    }
 
     result, err := client.Models.GenerateContent(
         ctx,
         "gemini-2.5-flash", // or "gemini-pro"
-        genai.Text(prompt),
+        genai.Text(prompt.Text),
         nil,
     )
     if err != nil {
@@ -103,3 +124,10 @@ Respond ONLY with the blog content, with no introduction or ending fluff just th
     return &domain.GenerateContentResponse{Content: result.Text()}, nil
 }
 
+// CacheStats reports hit/miss counts of the shared AI response cache.
+func (g *GeminiClient) CacheStats(ctx context.Context) (*domain.AICacheStats, error) {
+    if g.Cache == nil {
+        return &domain.AICacheStats{Backend: "disabled"}, nil
+    }
+    return g.Cache.Stats(ctx)
+}
@@ -3,7 +3,6 @@ package usecase
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -12,7 +11,8 @@ import (
 	"write_base/internal/domain"
 )
 
-var ErrContentPolicyViolation = errors.New("generated content violates policy")
+// ErrContentPolicyViolation is returned when generated content is not allowed by moderation.
+var ErrContentPolicyViolation = domain.ErrContentPolicyViolation
 
 // --- small AI-side structs (JSON-friendly) ---
 type aiParagraph struct {
@@ -81,23 +81,20 @@ func extractJSON(s string) (string, bool) {
 	return s[firstObj : last+1], true
 }
 
-// basic policy filtering — extend with a better/moderation service for production
-var bannedPatterns = []*regexp.Regexp{
-	regexp.MustCompile(`(?i)\bsex\b`),
-	regexp.MustCompile(`(?i)\bporn\b`),
-	regexp.MustCompile(`(?i)\bxxx\b`),
-	regexp.MustCompile(`(?i)\b18\+\b`),
-	// add more patterns/slurs as needed
-}
-
-func violatesPolicyText(s string) bool {
-	low := strings.ToLower(s)
-	for _, re := range bannedPatterns {
-		if re.MatchString(low) {
-			return true
-		}
+// moderateAIOutput rejects generated text unless the moderation pipeline allows it.
+// Flagged output is rejected too: there is no author to review it yet.
+func (u *ArticleUsecase) moderateAIOutput(ctx context.Context, texts ...string) error {
+	if u.Moderation == nil {
+		return nil
 	}
-	return false
+	verdict, err := u.Moderation.Moderate(ctx, domain.ContentKindAIOutput, strings.Join(texts, "\n"))
+	if err != nil {
+		return err
+	}
+	if verdict.Action != domain.ModerationAllow {
+		return ErrContentPolicyViolation
+	}
+	return nil
 }
 
 // normalize block type allowed set (make consistent with domain.BlockType)
@@ -119,19 +116,6 @@ func aiBlocksToDomainStrict(aiBlocks []aiBlock) ([]domain.ContentBlock, error) {
 		if !allowedBlockTypes[tt] {
 			return nil, fmt.Errorf("unsupported block type: %s", b.Type)
 		}
-		// sanitize text fields & detect policy violation
-		if b.Content.Paragraph != nil && violatesPolicyText(b.Content.Paragraph.Text) {
-			return nil, ErrContentPolicyViolation
-		}
-		if b.Content.Heading != nil && violatesPolicyText(b.Content.Heading.Text) {
-			return nil, ErrContentPolicyViolation
-		}
-		// image caption / alt check
-		if b.Content.Image != nil {
-			if violatesPolicyText(b.Content.Image.Caption) || violatesPolicyText(b.Content.Image.Alt) {
-				return nil, ErrContentPolicyViolation
-			}
-		}
 		// convert
 		cb := domain.ContentBlock{
 			Type:  domain.BlockType(tt),
@@ -222,30 +206,20 @@ func (u *ArticleUsecase) GenerateContentForArticle(ctx context.Context, article
 	}
 	promptContext, _ := json.MarshalIndent(promptBlocks, "", "  ")
 
-	// Build a strict prompt
-	prompt := fmt.Sprintf(`You are an article assistant. Below is the article metadata and current content blocks.
-Instructions: %s
-
-Article metadata (may be empty):
-title: %q
-excerpt: %q
-language: %q
-tags: %v
-
-Context blocks (JSON):
-%s
-
-OUTPUT: Reply with **ONLY** a single JSON object (no explanation) that may include:
-- "title": string (optional)
-- "excerpt": string (optional)
-- "tags": [string] (optional)
-- "content_blocks": [ { "type": "...", "order": 1, "content": { "paragraph": {"text":"..."}, ... } }, ... ]
-
-IMPORTANT POLICY: DO NOT produce sexual content, pornography, explicit adult material, hate slurs, or otherwise offensive content. If any content would violate this policy, either refuse by returning an empty content_blocks array or replace offending text with "[filtered]". Output must be valid JSON only.`, strings.TrimSpace(instructions),
-		article.Title, article.Excerpt, article.Language, article.Tags, string(promptContext),
-	)
+	// Render the prompt from the active template version
+	prompt, err := u.Prompts.Render(c, domain.PromptArticleEditContent, map[string]interface{}{
+		"Instructions":  strings.TrimSpace(instructions),
+		"Title":         article.Title,
+		"Excerpt":       article.Excerpt,
+		"Language":      article.Language,
+		"Tags":          article.Tags,
+		"ContextBlocks": string(promptContext),
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	aiResp, err := u.AIClient.GenerateContent(c, prompt)
+	aiResp, err := u.AIClient.GenerateContent(c, prompt.Text)
 	if err != nil {
 		return nil, err
 	}
@@ -264,8 +238,8 @@ IMPORTANT POLICY: DO NOT produce sexual content, pornography, explicit adult mat
 		if plain == "" {
 			return nil, domain.ErrInternalServer
 		}
-		if violatesPolicyText(plain) {
-			return nil, ErrContentPolicyViolation
+		if err := u.moderateAIOutput(c, plain); err != nil {
+			return nil, err
 		}
 		// replace first paragraph or append
 		found := false
@@ -298,27 +272,24 @@ IMPORTANT POLICY: DO NOT produce sexual content, pornography, explicit adult mat
 		return nil, ErrContentPolicyViolation
 	}
 
-	// Convert and validate each aiBlock to domain.ContentBlock with policy checks
+	// Convert and validate each aiBlock to domain.ContentBlock
 	converted, convErr := aiBlocksToDomainStrict(parsed.ContentBlocks)
 	if convErr != nil {
-		// If it's a policy violation, bubble up
-		if convErr == ErrContentPolicyViolation {
-			return nil, ErrContentPolicyViolation
-		}
 		return nil, convErr
 	}
 
-	// Attach parsed metadata (if present) after basic policy checks
+	// Moderate everything the model produced in one pass
+	generated := &domain.Article{ContentBlocks: converted}
+	texts := append([]string{parsed.Title, parsed.Excerpt, domain.ArticleBodyText(*generated)}, parsed.Tags...)
+	if err := u.moderateAIOutput(c, texts...); err != nil {
+		return nil, err
+	}
+
+	// Attach parsed metadata (if present)
 	if parsed.Title != "" {
-		if violatesPolicyText(parsed.Title) {
-			return nil, ErrContentPolicyViolation
-		}
 		article.Title = parsed.Title
 	}
 	if parsed.Excerpt != "" {
-		if violatesPolicyText(parsed.Excerpt) {
-			return nil, ErrContentPolicyViolation
-		}
 		article.Excerpt = parsed.Excerpt
 	}
 	if len(parsed.Tags) > 0 {
@@ -329,9 +300,6 @@ IMPORTANT POLICY: DO NOT produce sexual content, pornography, explicit adult mat
 			if t == "" {
 				continue
 			}
-			if violatesPolicyText(t) {
-				return nil, ErrContentPolicyViolation
-			}
 			cleanTags = append(cleanTags, t)
 			if len(cleanTags) >= 5 { // domain had max 5 tags
 				break
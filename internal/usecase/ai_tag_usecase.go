@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode"
+
+	"write_base/internal/domain"
+)
+
+const (
+	maxSuggestedTags    = 5
+	maxTagPromptContent = 2000
+)
+
+// SuggestTagsForArticle asks the AI for candidate tags and maps them onto the
+// approved tag set, so writers can pick tags that will not block publishing.
+// Candidates without a reasonable approved match are returned as proposals.
+func (u *ArticleUsecase) SuggestTagsForArticle(ctx context.Context, articleID, userID string) (*domain.TagSuggestions, error) {
+	c, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if articleID == "" {
+		return nil, domain.ErrArticleInvalidID
+	}
+	article, err := u.Repo.GetByID(c, articleID)
+	if err != nil {
+		if err == domain.ErrArticleNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternalServer
+	}
+	if !u.Policy.UserOwnsArticle(userID, article) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	approved, err := u.TagUsecase.ListTags(c, domain.TagStatusApproved)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	approvedNames := make([]string, 0, len(approved))
+	for _, t := range approved {
+		approvedNames = append(approvedNames, t.Name)
+	}
+
+	prompt, err := u.Prompts.Render(c, domain.PromptArticleSuggestTags, map[string]interface{}{
+		"Limit":        maxSuggestedTags,
+		"ApprovedTags": approvedNames,
+		"Title":        article.Title,
+		"Excerpt":      article.Excerpt,
+		"Content":      articlePlainText(article, maxTagPromptContent),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	aiResp, err := u.AIClient.GenerateContent(c, prompt.Text)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := parseTagCandidates(aiResp)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make([]string, 0, len(candidates))
+	for _, t := range candidates {
+		if u.moderateAIOutput(c, t) == nil {
+			allowed = append(allowed, t)
+		}
+	}
+
+	return matchApprovedTags(allowed, approved), nil
+}
+
+// parseTagCandidates accepts either {"tags": [...]} or a bare JSON array.
+func parseTagCandidates(aiResp string) ([]string, error) {
+	jsonCandidate, ok := extractJSON(aiResp)
+	if !ok {
+		return nil, domain.ErrInternalServer
+	}
+	var parsed struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(jsonCandidate), &parsed); err != nil {
+		if err := json.Unmarshal([]byte(jsonCandidate), &parsed.Tags); err != nil {
+			return nil, domain.ErrInternalServer
+		}
+	}
+
+	out := make([]string, 0, len(parsed.Tags))
+	for _, t := range parsed.Tags {
+		t = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(t), "#"))
+		if t == "" {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// matchApprovedTags maps each candidate onto an approved tag, trying an exact
+// match first, then a case-insensitive one and finally a fuzzy one.
+func matchApprovedTags(candidates []string, approved []domain.Tag) *domain.TagSuggestions {
+	result := &domain.TagSuggestions{Approved: []domain.TagMatch{}, Proposed: []string{}}
+	usedTags := map[string]bool{}
+	proposed := map[string]bool{}
+
+	for _, candidate := range candidates {
+		match, found := findApprovedTag(candidate, approved)
+		if found {
+			if usedTags[match.Tag.ID] || len(result.Approved) >= maxSuggestedTags {
+				continue
+			}
+			usedTags[match.Tag.ID] = true
+			result.Approved = append(result.Approved, match)
+			continue
+		}
+		key := normalizeTagName(candidate)
+		if proposed[key] || len(result.Proposed) >= maxSuggestedTags {
+			continue
+		}
+		proposed[key] = true
+		result.Proposed = append(result.Proposed, strings.ToLower(candidate))
+	}
+	return result
+}
+
+func findApprovedTag(candidate string, approved []domain.Tag) (domain.TagMatch, bool) {
+	for _, t := range approved {
+		if t.Name == candidate {
+			return domain.TagMatch{Suggested: candidate, Tag: t, MatchType: domain.TagMatchExact}, true
+		}
+	}
+	for _, t := range approved {
+		if strings.EqualFold(t.Name, candidate) {
+			return domain.TagMatch{Suggested: candidate, Tag: t, MatchType: domain.TagMatchCaseInsensitive}, true
+		}
+	}
+
+	// Fuzzy: ignore punctuation/spacing and allow a small edit distance
+	normCandidate := normalizeTagName(candidate)
+	best, bestDistance := -1, 0
+	for i, t := range approved {
+		normTag := normalizeTagName(t.Name)
+		d := levenshtein(normCandidate, normTag)
+		if d <= fuzzyTagThreshold(normCandidate, normTag) && (best == -1 || d < bestDistance) {
+			best, bestDistance = i, d
+		}
+	}
+	if best == -1 {
+		return domain.TagMatch{}, false
+	}
+	return domain.TagMatch{Suggested: candidate, Tag: approved[best], MatchType: domain.TagMatchFuzzy}, true
+}
+
+// normalizeTagName lower-cases a tag and strips everything but letters and digits,
+// so "Go-Lang", "go lang" and "golang" compare equal.
+func normalizeTagName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// fuzzyTagThreshold allows one edit for short tags and two for longer ones;
+// very short tags must match exactly after normalization.
+func fuzzyTagThreshold(a, b string) int {
+	n := len([]rune(a))
+	if m := len([]rune(b)); m < n {
+		n = m
+	}
+	switch {
+	case n <= 3:
+		return 0
+	case n <= 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// articlePlainText joins the textual blocks of an article, capped at maxLen bytes.
+func articlePlainText(article *domain.Article, maxLen int) string {
+	var b strings.Builder
+	for _, block := range article.ContentBlocks {
+		var text string
+		switch {
+		case block.Content.Heading != nil:
+			text = block.Content.Heading.Text
+		case block.Content.Paragraph != nil:
+			text = block.Content.Paragraph.Text
+		case block.Content.List != nil:
+			text = strings.Join(block.Content.List.Items, "\n")
+		}
+		if text == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(text)
+		if b.Len() >= maxLen {
+			break
+		}
+	}
+	out := b.String()
+	if len(out) > maxLen {
+		out = strings.ToValidUTF8(out[:maxLen], "")
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTagSuggestionUsecase(aiResp string, approved []domain.Tag, owns bool) domain.IArticleUsecase {
+	repo := &mocks.ArticleRepositoryMock{GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) {
+		return &domain.Article{ID: id, AuthorID: "u1", Title: "Intro to Golang", ContentBlocks: []domain.ContentBlock{
+			{Type: domain.BlockParagraph, Content: domain.BlockContent{Paragraph: &domain.ParagraphContent{Text: "Goroutines and channels"}}},
+		}}, nil
+	}}
+	policy := &mocks.PolicyMock{UserOwnsArticleFn: func(uid string, a *domain.Article) bool { return owns }}
+	tagUC := &mocks.TagUsecaseMock{ListTagsFn: func(ctx context.Context, status domain.TagStatus) ([]domain.Tag, error) {
+		return approved, nil
+	}}
+	aiClient := &mocks.AIMock{GenerateContentFn: func(ctx context.Context, prompt string) (string, error) { return aiResp, nil }}
+	prompts := usecase.NewPromptTemplateUsecase(&mocks.PromptTemplateRepositoryMock{}, &mocks.UtilsMock{})
+
+	return usecase.NewArticleUsecase(repo, policy, &mocks.UtilsMock{}, tagUC, &mocks.ViewUsecaseMock{}, &mocks.ClapUsecaseMock{}, aiClient, prompts, nil, nil, nil, nil)
+}
+
+func TestArticleUsecase_SuggestTags_SplitsApprovedAndProposed(t *testing.T) {
+	approved := []domain.Tag{
+		{ID: "t1", Name: "go", Status: domain.TagStatusApproved},
+		{ID: "t2", Name: "Concurrency", Status: domain.TagStatusApproved},
+		{ID: "t3", Name: "programming", Status: domain.TagStatusApproved},
+	}
+	aiResp := "```json\n{\"tags\": [\"go\", \"concurrency\", \"programing\", \"#channels\", \"Channels\"]}\n```"
+	uc := newTagSuggestionUsecase(aiResp, approved, true)
+
+	out, err := uc.SuggestTagsForArticle(context.Background(), "a1", "u1")
+	require.NoError(t, err)
+	require.Len(t, out.Approved, 3)
+	require.Equal(t, domain.TagMatchExact, out.Approved[0].MatchType)
+	require.Equal(t, "t2", out.Approved[1].Tag.ID)
+	require.Equal(t, domain.TagMatchCaseInsensitive, out.Approved[1].MatchType)
+	require.Equal(t, "t3", out.Approved[2].Tag.ID)
+	require.Equal(t, domain.TagMatchFuzzy, out.Approved[2].MatchType)
+	require.Equal(t, []string{"channels"}, out.Proposed)
+}
+
+func TestArticleUsecase_SuggestTags_ShortTagsNeedExactMatch(t *testing.T) {
+	approved := []domain.Tag{{ID: "t1", Name: "go", Status: domain.TagStatusApproved}}
+	uc := newTagSuggestionUsecase(`["js"]`, approved, true)
+
+	out, err := uc.SuggestTagsForArticle(context.Background(), "a1", "u1")
+	require.NoError(t, err)
+	require.Empty(t, out.Approved)
+	require.Equal(t, []string{"js"}, out.Proposed)
+}
+
+func TestArticleUsecase_SuggestTags_RequiresOwnership(t *testing.T) {
+	uc := newTagSuggestionUsecase(`{"tags": ["go"]}`, nil, false)
+
+	_, err := uc.SuggestTagsForArticle(context.Background(), "a1", "u2")
+	require.Equal(t, domain.ErrUnauthorized, err)
+}
+
+func TestArticleUsecase_SuggestTags_UnparseableResponse(t *testing.T) {
+	uc := newTagSuggestionUsecase("I cannot help with that", nil, true)
+
+	_, err := uc.SuggestTagsForArticle(context.Background(), "a1", "u1")
+	require.Equal(t, domain.ErrInternalServer, err)
+}
@@ -0,0 +1,59 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+
+	"github.com/stretchr/testify/require"
+)
+
+func paragraphMentioning(ms ...domain.Mention) []domain.ContentBlock {
+	return []domain.ContentBlock{{Type: domain.BlockParagraph, Content: domain.BlockContent{Paragraph: &domain.ParagraphContent{Text: "hi", Mentions: ms}}}}
+}
+
+func TestArticleMentions_PublishedOnPublishAndUpdate(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	alice := domain.Mention{UserID: "u2", Username: "alice"}
+	bob := domain.Mention{UserID: "u3", Username: "bob"}
+	resolved := 0
+	var events []domain.MentionEvent
+	var previous [][]domain.Mention
+	uc.Mentions = &mocks.MentionServiceMock{
+		ResolveArticleFn: func(ctx context.Context, a *domain.Article) {
+			resolved++
+			a.ContentBlocks = paragraphMentioning(alice, bob)
+		},
+		PublishFn: func(ctx context.Context, e domain.MentionEvent, mentions, prev []domain.Mention) {
+			require.Equal(t, domain.MentionInArticle, e.Source)
+			require.Equal(t, "u1", e.ActorID)
+			events = append(events, e)
+			previous = append(previous, prev)
+		},
+	}
+	stored := &domain.Article{ID: "a1", AuthorID: "u1", Title: "T", Status: domain.StatusDraft, Tags: []string{"go"}, ContentBlocks: paragraphMentioning(alice)}
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) { a := *stored; return &a, nil }
+	repo.GetBySlugFn = func(ctx context.Context, slug string) (*domain.Article, error) { return nil, domain.ErrArticleNotFound }
+	repo.UpdateFn = func(ctx context.Context, a *domain.Article) error { return nil }
+	repo.PublishFn = func(ctx context.Context, id string, at time.Time) error { return nil }
+
+	// Drafts store their mentions without announcing them
+	draft := &domain.Article{ID: "a1", AuthorID: "u1", Title: "T", Tags: []string{"go"}}
+	require.NoError(t, uc.UpdateArticle(context.Background(), "u1", draft))
+	require.Equal(t, 1, resolved)
+	require.Empty(t, events)
+
+	_, err := uc.PublishArticle(context.Background(), "a1", "u1")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Nil(t, previous[0])
+
+	stored.Status = domain.StatusPublished
+	edit := &domain.Article{ID: "a1", AuthorID: "u1", Title: "T", Tags: []string{"go"}}
+	require.NoError(t, uc.UpdateArticle(context.Background(), "u1", edit))
+	require.Len(t, events, 2)
+	require.Equal(t, []domain.Mention{alice}, previous[1])
+	require.Equal(t, []domain.Mention{alice, bob}, edit.Mentions())
+}
@@ -0,0 +1,77 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+	"write_base/internal/infrastructure/moderation"
+	"write_base/internal/mocks"
+
+	"github.com/stretchr/testify/require"
+)
+
+func moderationReturning(action domain.ModerationAction, flagged *[]string) *mocks.ModerationServiceMock {
+	return &mocks.ModerationServiceMock{
+		ModerateFn: func(ctx context.Context, kind domain.ContentKind, text string) (*domain.ModerationVerdict, error) {
+			return &domain.ModerationVerdict{Action: action}, nil
+		},
+		FlagForReviewFn: func(ctx context.Context, kind domain.ContentKind, targetID string, v *domain.ModerationVerdict) error {
+			*flagged = append(*flagged, targetID)
+			return nil
+		},
+	}
+}
+
+func TestPublishArticle_ModerationBlock(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	var flagged []string
+	uc.Moderation = moderationReturning(domain.ModerationBlock, &flagged)
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
+		return &domain.Article{ID: id, AuthorID: "u1", Title: "T", Status: domain.StatusDraft, Tags: []string{"go"}}, nil
+	}
+	published := false
+	repo.PublishFn = func(ctx context.Context, id string, at time.Time) error { published = true; return nil }
+
+	_, err := uc.PublishArticle(context.Background(), "a1", "u1")
+	require.ErrorIs(t, err, domain.ErrContentBlocked)
+	require.False(t, published)
+	require.Empty(t, flagged)
+}
+
+// Blocked words are found in every block that carries text, not just prose.
+func TestPublishArticle_ModerationChecksCodeAndImageBlocks(t *testing.T) {
+	words := moderation.NewWordListCheck([]moderation.WordListEntry{{Term: "forbidden", Severity: moderation.SeverityHigh}})
+	for name, block := range map[string]domain.BlockContent{
+		"code":  {Code: &domain.CodeContent{Code: "// forbidden\nfmt.Println()"}},
+		"image": {Image: &domain.ImageContent{URL: "https://img.example/x.png", Caption: "a forbidden sight"}},
+	} {
+		uc, repo, _, _, _, _, _ := newArticleUC()
+		uc.Moderation = moderation.NewService(nil, words)
+		repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
+			return &domain.Article{ID: id, AuthorID: "u1", Title: "T", Status: domain.StatusDraft, Tags: []string{"go"},
+				ContentBlocks: []domain.ContentBlock{{Order: 1, Content: block}}}, nil
+		}
+		published := false
+		repo.PublishFn = func(ctx context.Context, id string, at time.Time) error { published = true; return nil }
+
+		_, err := uc.PublishArticle(context.Background(), "a1", "u1")
+		require.ErrorIs(t, err, domain.ErrContentBlocked, name)
+		require.False(t, published, name)
+	}
+}
+
+func TestPublishArticle_ModerationFlagPublishesAndReports(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	var flagged []string
+	uc.Moderation = moderationReturning(domain.ModerationFlag, &flagged)
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
+		return &domain.Article{ID: id, AuthorID: "u1", Title: "T", Status: domain.StatusDraft, Tags: []string{"go"}}, nil
+	}
+	repo.PublishFn = func(ctx context.Context, id string, at time.Time) error { return nil }
+
+	a, err := uc.PublishArticle(context.Background(), "a1", "u1")
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusPublished, a.Status)
+	require.Equal(t, []string{"a1"}, flagged)
+}
@@ -3,24 +3,31 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 	"write_base/internal/domain"
-	"write_base/internal/infrastructure/ai"
 )
 type ArticleUsecase struct {
     Repo        domain.IArticleRepository
     Policy      domain.IPolicy 
     Utils       domain.IUtils
-    AIClient    domain.IAI  // <-- Use interface instead of *ai.GeminiClient
+    AIClient    domain.IAI
     TagUsecase  domain.TagUsecase
     ViewUsecase domain.ViewUsecase
     ClapUsecase domain.ClapUsecase
-
+    Prompts     domain.IPromptTemplateUsecase
+    Moderation  domain.IModerationService
+    SearchIndex domain.ISearchIndex
+    Mentions    domain.IMentionService
+    Notifications domain.INotificationUsecase
+    // TrendingWindow is how recently an article must have been published to
+    // trend; zero uses domain.DefaultTrendingWindow
+    TrendingWindow time.Duration
 }
 
-func NewArticleUsecase(repo domain.IArticleRepository, policy domain.IPolicy, util domain.IUtils,tagusecase domain.TagUsecase, vuc domain.ViewUsecase, clap domain.ClapUsecase, aiClient *ai.GeminiClient) domain.IArticleUsecase{
-	return &ArticleUsecase{Repo: repo, Policy: policy, Utils: util, TagUsecase: tagusecase, ViewUsecase: vuc, ClapUsecase: clap, AIClient: aiClient,}
+func NewArticleUsecase(repo domain.IArticleRepository, policy domain.IPolicy, util domain.IUtils,tagusecase domain.TagUsecase, vuc domain.ViewUsecase, clap domain.ClapUsecase, aiClient domain.IAI, prompts domain.IPromptTemplateUsecase, moderation domain.IModerationService, searchIndex domain.ISearchIndex, mentions domain.IMentionService, notifications domain.INotificationUsecase) domain.IArticleUsecase{
+	return &ArticleUsecase{Repo: repo, Policy: policy, Utils: util, TagUsecase: tagusecase, ViewUsecase: vuc, ClapUsecase: clap, AIClient: aiClient, Prompts: prompts, Moderation: moderation, SearchIndex: searchIndex, Mentions: mentions, Notifications: notifications,}
 }
 //===============================================================================//
 //                                CRUD                                           //
@@ -61,13 +68,19 @@ func (au *ArticleUsecase) CreateArticle(ctx context.Context, userID string, inpu
         if len(input.Excerpt) > domain.MaxExcerptLength {
             input.Excerpt = input.Excerpt[:domain.MaxExcerptLength]
         }
+    }
+    if err := validateSEO(&input.SEO); err != nil {
+        return "", err
     }
 	if err := au.TagUsecase.ValidateTags(input.Tags); err != nil {
 		return "", domain.ErrInvalidTagName
 	}
+	input.Tags = au.TagUsecase.CanonicalTags(input.Tags)
+    au.resolveMentions(c, input)
     if err := au.Repo.Create(c, input); err != nil {
         return "", fmt.Errorf("repository error: %w", err)
     }
+    au.indexArticle(c, *input)
     return input.ID, nil
 }
 // =============================== Article Update ================================
@@ -95,14 +108,30 @@ func (au *ArticleUsecase) UpdateArticle(ctx context.Context, userID string, inpu
     if err := au.TagUsecase.ValidateTags(input.Tags); err != nil {
 		return domain.ErrInvalidTagName
 	}
+    input.Tags = au.TagUsecase.CanonicalTags(input.Tags)
+    if err := validateSEO(&input.SEO); err != nil {
+        return err
+    }
     old,err:= au.GetArticleByID(c, input.ID, userID)
     if err!=nil || old.ID!=input.ID {
         return domain.ErrArticleNotFound
     }
+    // Edits never move the article's status, counters or lifecycle dates
+    input.Status, input.Stats = old.Status, old.Stats
+    input.Timestamps = old.Timestamps
+    input.Timestamps.UpdatedAt = time.Now()
+    if input.KeepSEO {
+        input.SEO = old.SEO
+    }
+    au.resolveMentions(c, input)
 
     if err:=au.Repo.Update(c,input); err!=nil{
         return domain.ErrInternalServer
     }
+    if old.Status == domain.StatusPublished {
+        au.reindexArticle(c, input.ID)
+        au.publishMentions(c, userID, input, old.Mentions())
+    }
     return nil
 }
 // =============================== Article Delete ================================
@@ -129,6 +158,7 @@ func (au *ArticleUsecase) DeleteArticle(ctx context.Context, articleID,userID st
 		}
 		return domain.ErrInternalServer
 	}
+	au.removeFromIndex(c, articleID)
 	return nil
 }
 // =============================== Article Restore ================================
@@ -155,6 +185,7 @@ func (au *ArticleUsecase) RestoreArticle(ctx context.Context,  articleID, userID
 		}
 		return domain.ErrInternalServer
 	}
+	au.reindexArticle(c, articleID)
 	return nil
 }
 //===============================================================================//
@@ -187,12 +218,25 @@ func (au *ArticleUsecase) PublishArticle(ctx context.Context, articleID, userID
 			return nil, domain.ErrUnapprovedTags
 		}
 	}
+	verdict, err := au.moderateArticle(c, article)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	if verdict.Action == domain.ModerationBlock {
+		return nil, domain.ErrContentBlocked
+	}
 	article.Status = domain.StatusPublished
 	now := time.Now()
 	article.Timestamps.PublishedAt = &now
     if err := au.Repo.Publish(c,articleID,now);err!=nil {
         return nil,domain.ErrInternalServer
     }
+    au.indexArticle(c, *article)
+    au.publishMentions(c, userID, article, nil)
+	// Flagged articles stay published but are queued for admin review
+	if verdict.Action == domain.ModerationFlag {
+		_ = au.Moderation.FlagForReview(c, domain.ContentKindArticle, article.ID, verdict)
+	}
 	return article, nil
 }
 // ======================== Article Unpublish =====================================
@@ -221,6 +265,7 @@ func (au *ArticleUsecase) UnpublishArticle(ctx context.Context, articleID, userI
     if err := au.Repo.Unpublish(c,articleID);err!=nil {
         return nil,domain.ErrInternalServer
     }
+    au.removeFromIndex(c, articleID)
 	return article, nil
 }
 // ======================== Article Archive =======================================
@@ -250,6 +295,7 @@ func (au *ArticleUsecase) ArchiveArticle(ctx context.Context, articleID, userID
     if err := au.Repo.Archive(c,articleID,now);err!=nil {
         return nil,domain.ErrInternalServer
     }
+    au.removeFromIndex(c, articleID)
 	return article, nil
 }
 // ======================== Article Unarchive =====================================
@@ -305,13 +351,7 @@ func (au *ArticleUsecase) GetArticleByID(ctx context.Context, articleID, userID
 		return article, nil
 	}
 	if article.Status == domain.StatusPublished {
-		// Record view for authenticated users
-		if userID != "" {
-			au.ViewUsecase.RecordView(c, userID, articleID, "")
-		}
-		
-		// Increment article view count
-		au.Repo.IncrementView(c, article.ID)
+		au.recordView(c, article, domain.ViewReader{UserID: userID})
 		return article, nil
 	}
     return nil,domain.ErrUnauthorized
@@ -355,7 +395,7 @@ func (au *ArticleUsecase) GetAllArticleStats(ctx context.Context, userID string)
     return articlesStats, total, nil
 }
 // =================== Article GetBySlug ==============================
-func (au *ArticleUsecase) GetArticleBySlug(ctx context.Context, slug string, clientIP string) (*domain.Article, error) {
+func (au *ArticleUsecase) GetArticleBySlug(ctx context.Context, slug string, reader domain.ViewReader) (*domain.Article, error) {
     c, cancel := context.WithTimeout(ctx, domain.DefaultTimeout)
     defer cancel()
 
@@ -366,16 +406,19 @@ func (au *ArticleUsecase) GetArticleBySlug(ctx context.Context, slug string, cli
         }
         return nil,domain.ErrInternalServer
     }
-	// Record view with client IP
-	if clientIP != "" {
-		au.ViewUsecase.RecordView(c, "", article.ID, clientIP)
+	if article.Status == domain.StatusPublished {
+		au.recordView(c, article, reader)
 	}
-	
-	// Increment article view count
-	au.Repo.IncrementView(c, article.ID)
     return article,nil
 }
 
+// recordView counts the read; a failure to count never fails the read itself.
+func (au *ArticleUsecase) recordView(ctx context.Context, article *domain.Article, reader domain.ViewReader) {
+	if err := au.ViewUsecase.RecordView(ctx, article, reader); err != nil {
+		log.Printf("view of article %s not counted: %v", article.ID, err)
+	}
+}
+
 //===========================================================================//
 //                           Article Lists                                   //
 //===========================================================================//
@@ -389,6 +432,9 @@ func (au *ArticleUsecase) ListArticlesByAuthor(ctx context.Context, userID, auth
 	}
 	articles, length, err := au.Repo.ListByAuthor(c, authorID, pag)
 	if err != nil {
+		if err == domain.ErrInvalidCursor {
+			return nil, 0, err
+		}
 		if err == domain.ErrArticleNotFound {
 			return nil, 0, domain.ErrArticleNotFound
 		}
@@ -405,10 +451,15 @@ func (au *ArticleUsecase) GetTrendingArticles(ctx context.Context, userID string
         return nil, 0, domain.ErrUnauthorized
     }
 
-    // Pass time window for trending calculation => 7 days
-    trendingWindow := 7
-    articles, total, err := au.Repo.FindTrending(c, trendingWindow, pag)
+    window := au.TrendingWindow
+    if window <= 0 {
+        window = domain.DefaultTrendingWindow
+    }
+    articles, total, err := au.Repo.FindTrending(c, window, pag)
     if err != nil {
+        if err == domain.ErrInvalidCursor {
+            return nil, 0, err
+        }
         if err == domain.ErrArticleNotFound{
             return nil,0,domain.ErrArticleNotFound
         }
@@ -430,6 +481,9 @@ func (au *ArticleUsecase) GetNewArticles(ctx context.Context, userID string, pag
 
     articles, total, err := au.Repo.FindNewArticles(c,pag)
     if err != nil {
+        if err == domain.ErrInvalidCursor {
+            return nil, 0, err
+        }
         if err == domain.ErrArticleNotFound{
             return nil,0,domain.ErrArticleNotFound
         }
@@ -448,6 +502,9 @@ func (au *ArticleUsecase) GetPopularArticles(ctx context.Context, userID string,
 
     articles, total, err := au.Repo.FindPopularArticles(c, pag)
     if err != nil {
+        if err == domain.ErrInvalidCursor {
+            return nil, 0, err
+        }
         if err == domain.ErrArticleNotFound{
             return nil,0,domain.ErrArticleNotFound
         }
@@ -498,10 +555,7 @@ func (au *ArticleUsecase) FilterArticles(ctx context.Context, filter domain.Arti
     c, cancel := context.WithTimeout(ctx, domain.DefaultTimeout)
     defer cancel()
 
-    // If statuses not provided, default to published (public view)
-    if len(filter.Statuses) == 0 {
-        filter.Statuses = []domain.ArticleStatus{domain.StatusPublished}
-    }
+    filter = withPublicStatuses(filter)
 
     // Defensive pagination defaults
     if pag.Page < 1 {
@@ -513,6 +567,9 @@ func (au *ArticleUsecase) FilterArticles(ctx context.Context, filter domain.Arti
 
     articles, total, err := au.Repo.Filter(c, filter, pag)
     if err != nil {
+        if err == domain.ErrInvalidCursor {
+            return nil, 0, err
+        }
         if err == domain.ErrArticleNotFound {
             return nil, 0, domain.ErrArticleNotFound
         }
@@ -521,21 +578,82 @@ func (au *ArticleUsecase) FilterArticles(ctx context.Context, filter domain.Arti
     return articles, total, nil
 }
 
+// withPublicStatuses defaults a filter without statuses to published articles (public view).
+func withPublicStatuses(filter domain.ArticleFilter) domain.ArticleFilter {
+    if len(filter.Statuses) == 0 {
+        filter.Statuses = []domain.ArticleStatus{domain.StatusPublished}
+    }
+    return filter
+}
+
+// GetFilterFacets counts every article FilterArticles would return for filter.
+func (au *ArticleUsecase) GetFilterFacets(ctx context.Context, filter domain.ArticleFilter) (*domain.ArticleFacets, error) {
+    c, cancel := context.WithTimeout(ctx, domain.DefaultTimeout)
+    defer cancel()
+
+    facets, err := au.Repo.FilterFacets(c, withPublicStatuses(filter))
+    if err != nil {
+        return nil, domain.ErrInternalServer
+    }
+    return facets, nil
+}
 //=========================== Search ==============================================
-func (u *ArticleUsecase) SearchArticles(ctx context.Context, userID, query string, pag domain.Pagination) ([]domain.Article, int, error) {
+func (u *ArticleUsecase) SearchArticles(ctx context.Context, userID string, query domain.SearchQuery, pag domain.Pagination) ([]domain.SearchHit, int, error) {
 	c, close := context.WithTimeout(ctx, domain.DefaultTimeout)
 	defer close()
 	if !u.Policy.UserExists(userID) {
 		return nil, 0, domain.ErrUnauthorized
 	}
-	articles, length, err := u.Repo.Search(c, query, pag)
+	parsed, err := domain.ParseSearchText(query.Text)
 	if err != nil {
+		return nil, 0, err
+	}
+	query.Text = parsed.String()
+
+	search := u.Repo.Search
+	if u.SearchIndex != nil {
+		search = u.SearchIndex.Search
+	}
+	hits, length, err := search(c, query, pag)
+	if err != nil {
+		if err == domain.ErrInvalidCursor || err == domain.ErrUnsupportedSearchSort {
+			return nil, 0, err
+		}
 		if err == domain.ErrArticleNotFound {
 			return nil, 0, domain.ErrArticleNotFound
 		}
 		return nil, 0, domain.ErrInternalServer
 	}
-	return articles, length, nil
+	for i := range hits {
+		hits[i].Snippets = domain.BuildSnippets(hits[i].Article, parsed)
+		// The body was only needed for snippets; results are listed like other listings
+		hits[i].Article.ContentBlocks = nil
+	}
+	return hits, length, nil
+}
+
+// GetSearchFacets counts every article SearchArticles matches for query.
+func (u *ArticleUsecase) GetSearchFacets(ctx context.Context, userID string, query domain.SearchQuery) (*domain.ArticleFacets, error) {
+	c, close := context.WithTimeout(ctx, domain.DefaultTimeout)
+	defer close()
+	if !u.Policy.UserExists(userID) {
+		return nil, domain.ErrUnauthorized
+	}
+	parsed, err := domain.ParseSearchText(query.Text)
+	if err != nil {
+		return nil, err
+	}
+	query.Text = parsed.String()
+
+	facets := u.Repo.SearchFacets
+	if u.SearchIndex != nil {
+		facets = u.SearchIndex.Facets
+	}
+	result, err := facets(c, query)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	return result, nil
 }
 //======================== List By Tags =======================================
 func (u *ArticleUsecase) ListArticlesByTags(ctx context.Context, userID string, tags []string, pag domain.Pagination) ([]domain.Article, int, error) {
@@ -553,7 +671,8 @@ func (u *ArticleUsecase) ListArticlesByTags(ctx context.Context, userID string,
 		}
 	}
 	
-	return u.Repo.ListByTags(c, tags, pag)
+	// Synonyms resolve to their tag and a parent tag also lists its children
+	return u.Repo.ListByTags(c, u.TagUsecase.ExpandTags(tags), pag)
 }
 //===========================================================================//
 //                 Trash Management (Author only)                            //
@@ -637,6 +756,7 @@ func (u *ArticleUsecase) AdminHardDeleteArticle(ctx context.Context, userID, use
 	if err:= u.Repo.HardDelete(c, articleID); err != nil {
 		return domain.ErrInternalServer
 	}
+	u.removeFromIndex(c, articleID)
 	return nil
 }
 //============================ Unpublish Article (Admin) ================================
@@ -660,9 +780,116 @@ func (au *ArticleUsecase) AdminUnpublishArticle(ctx context.Context, userID, use
 		}
 		return nil, domain.ErrInternalServer
 	}
+	au.removeFromIndex(c, articleID)
+	au.notify(c, domain.Notification{
+		UserID:    article.AuthorID,
+		Type:      domain.NotificationArticleRejected,
+		ActorID:   userID,
+		ArticleID: articleID,
+	})
 	return article, nil
 }
+//============================ Rebuild Search Index (Admin) ==============================
+// searchRebuildBatch is how many articles are read and indexed at a time.
+const searchRebuildBatch = 500
 
+func (au *ArticleUsecase) AdminRebuildSearchIndex(ctx context.Context, userID, userRole string) (int, error) {
+	if !au.Policy.IsAdmin(userID, userRole) {
+		return 0, domain.ErrUnauthorized
+	}
+	if au.SearchIndex == nil {
+		return 0, nil
+	}
+	// No DefaultTimeout: a rebuild walks every published article
+	if err := au.SearchIndex.Reset(ctx); err != nil {
+		return 0, domain.ErrInternalServer
+	}
+	indexed := 0
+	err := au.Repo.ForEachPublished(ctx, searchRebuildBatch, func(batch []domain.Article) error {
+		if err := au.SearchIndex.Index(ctx, batch...); err != nil {
+			return err
+		}
+		indexed += len(batch)
+		return nil
+	})
+	if err != nil {
+		log.Printf("search index rebuild stopped after %d articles: %v", indexed, err)
+		return indexed, domain.ErrInternalServer
+	}
+	return indexed, nil
+}
+//===============================================================================//
+//                         Search Index Upkeep                                   //
+//===============================================================================//
+// Index failures never fail the write: MongoDB stays the source of truth and
+// the index can be rebuilt from it.
+func (au *ArticleUsecase) indexArticle(ctx context.Context, article domain.Article) {
+	if au.SearchIndex == nil {
+		return
+	}
+	if err := au.SearchIndex.Index(ctx, article); err != nil {
+		log.Printf("search index update for article %s failed: %v", article.ID, err)
+	}
+}
+
+// reindexArticle indexes the article as it is now stored.
+func (au *ArticleUsecase) reindexArticle(ctx context.Context, articleID string) {
+	if au.SearchIndex == nil {
+		return
+	}
+	article, err := au.Repo.GetByID(ctx, articleID)
+	if err != nil {
+		log.Printf("search index update for article %s failed: %v", articleID, err)
+		return
+	}
+	au.indexArticle(ctx, *article)
+}
+
+func (au *ArticleUsecase) removeFromIndex(ctx context.Context, articleID string) {
+	if au.SearchIndex == nil {
+		return
+	}
+	if err := au.SearchIndex.Remove(ctx, articleID); err != nil {
+		log.Printf("search index removal of article %s failed: %v", articleID, err)
+	}
+}
+
+//===============================================================================//
+//                                Mentions                                       //
+//===============================================================================//
+func (au *ArticleUsecase) resolveMentions(ctx context.Context, article *domain.Article) {
+	if au.Mentions != nil {
+		au.Mentions.ResolveArticle(ctx, article)
+	}
+}
+
+// publishMentions announces users mentioned in a published article who were
+// not in previous; drafts mention nobody until they are published.
+func (au *ArticleUsecase) publishMentions(ctx context.Context, actorID string, article *domain.Article, previous []domain.Mention) {
+	if au.Mentions == nil {
+		return
+	}
+	event := domain.MentionEvent{
+		Source:    domain.MentionInArticle,
+		SourceID:  article.ID,
+		ArticleID: article.ID,
+		ActorID:   actorID,
+	}
+	au.Mentions.Publish(ctx, event, article.Mentions(), previous)
+}
+
+//===============================================================================//
+//                                Notifications                                  //
+//===============================================================================//
+// notify sends n; a failed notification never fails the article operation.
+func (au *ArticleUsecase) notify(ctx context.Context, n domain.Notification) {
+	if au.Notifications == nil {
+		return
+	}
+	if err := au.Notifications.Notify(ctx, n); err != nil {
+		log.Printf("%s notification for article %s failed: %v", n.Type, n.ArticleID, err)
+	}
+}
 
 //================================== CLAPPING ===========================================
 // Add new method
@@ -675,22 +902,97 @@ func (u *ArticleUsecase) AddClap(ctx context.Context, userID, articleID string)
 		return domain.ArticleStats{}, domain.ErrUnauthorized
 	}
 	
-	// Add clap
-	totalClaps, err := u.ClapUsecase.AddClap(c, userID, articleID)
+	// The per-user cap is enforced first; only an accepted clap moves the counter
+	if _, err := u.ClapUsecase.AddClap(c, userID, articleID); err != nil {
+		return domain.ArticleStats{}, err
+	}
+	stats, err := u.bumpStat(c, articleID, domain.StatClaps, 1)
 	if err != nil {
 		return domain.ArticleStats{}, err
 	}
-	
-	// Update article clap count
-	if err := u.Repo.UpdateClapCount(c, articleID, totalClaps); err != nil {
+
+	// Only the first clap to land on a milestone announces it
+	if domain.IsClapMilestone(stats.ClapCount) && u.firstClapMilestone(c, articleID, stats.ClapCount) {
+		if article, err := u.Repo.GetByID(c, articleID); err == nil {
+			u.notify(c, domain.Notification{
+				UserID:    article.AuthorID,
+				Type:      domain.NotificationClapMilestone,
+				ArticleID: articleID,
+				Message:   fmt.Sprintf("Your article reached %d claps", stats.ClapCount),
+			})
+		}
+	}
+	return *stats, nil
+}
+
+// firstClapMilestone claims the milestone's notification. When the claim
+// cannot be recorded the notification is skipped rather than risk repeating it.
+func (u *ArticleUsecase) firstClapMilestone(ctx context.Context, articleID string, milestone int) bool {
+	first, err := u.Repo.MarkClapMilestone(ctx, articleID, milestone)
+	if err != nil {
+		log.Printf("clap milestone %d for article %s not recorded: %v", milestone, articleID, err)
+		return false
+	}
+	return first
+}
+
+// RemoveClaps retracts all of the user's claps on the article.
+func (u *ArticleUsecase) RemoveClaps(ctx context.Context, userID, articleID string) (domain.ArticleStats, error) {
+	c, cancel := context.WithTimeout(ctx, domain.DefaultTimeout)
+	defer cancel()
+
+	if !u.Policy.UserExists(userID) {
+		return domain.ArticleStats{}, domain.ErrUnauthorized
+	}
+	removed, err := u.ClapUsecase.RemoveClaps(c, userID, articleID)
+	if err != nil {
 		return domain.ArticleStats{}, err
 	}
-	
-	// Get updated article stats
-	article, err := u.Repo.GetByID(c, articleID)
+	stats, err := u.bumpStat(c, articleID, domain.StatClaps, -removed)
 	if err != nil {
 		return domain.ArticleStats{}, err
 	}
-	
-	return article.Stats, nil
-}
\ No newline at end of file
+	return *stats, nil
+}
+
+// bumpStat moves an article counter after its source record was written. If
+// the increment fails the record stands and the reconcile job repairs the
+// counter, so the current stats are returned instead of an error.
+func (u *ArticleUsecase) bumpStat(ctx context.Context, articleID string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+	if delta != 0 {
+		stats, err := u.Repo.IncrementStat(ctx, articleID, counter, delta)
+		if err == nil || err == domain.ErrArticleNotFound {
+			return stats, err
+		}
+		log.Printf("%s update for article %s failed: %v", counter, articleID, err)
+	}
+	article, err := u.Repo.GetByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	return &article.Stats, nil
+}
+
+// GetUserClaps returns how many times the user clapped the article so far.
+func (u *ArticleUsecase) GetUserClaps(ctx context.Context, userID, articleID string) (domain.UserClapState, error) {
+	c, cancel := context.WithTimeout(ctx, domain.DefaultTimeout)
+	defer cancel()
+
+	if !u.Policy.UserExists(userID) {
+		return domain.UserClapState{}, domain.ErrUnauthorized
+	}
+	if _, err := u.Repo.GetByID(c, articleID); err != nil {
+		return domain.UserClapState{}, err
+	}
+	return u.ClapUsecase.GetUserClaps(c, userID, articleID)
+}
+
+// moderateArticle runs the publish-time moderation over the article's text.
+func (au *ArticleUsecase) moderateArticle(ctx context.Context, article *domain.Article) (*domain.ModerationVerdict, error) {
+	if au.Moderation == nil {
+		return &domain.ModerationVerdict{Action: domain.ModerationAllow}, nil
+	}
+	// Every block that carries text is checked, code and image captions included
+	text := strings.Join([]string{article.Title, article.Excerpt, domain.ArticleBodyText(*article)}, "\n")
+	return au.Moderation.Moderate(ctx, domain.ContentKindArticle, text)
+}
@@ -23,20 +23,22 @@ func TestGetArticleByID_AsAuthorSuccess(t *testing.T) {
 
 func TestGetArticleByID_AsOtherPublished_RecordsView(t *testing.T) {
 	viewed := false
-	incremented := false
 	repo := &mocks.ArticleRepositoryMock{GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) {
 		return &domain.Article{ID: id, AuthorID: "author", Status: domain.StatusPublished}, nil
-	}, IncrementViewFn: func(ctx context.Context, id string) error { incremented = true; return nil }}
+	}}
 	pol := &mocks.PolicyMock{UserExistsFn: func(string) bool { return true }}
-	view := &mocks.ViewUsecaseMock{RecordViewFn: func(ctx context.Context, uid, aid, ip string) error { viewed = true; return nil }}
+	view := &mocks.ViewUsecaseMock{RecordViewFn: func(ctx context.Context, article *domain.Article, reader domain.ViewReader) error {
+		viewed = article.ID == "a2" && reader.UserID == "someone"
+		return nil
+	}}
 	uc := &ArticleUsecase{Repo: repo, Policy: pol, Utils: &mocks.UtilsMock{}, TagUsecase: &mocks.TagUsecaseMock{}, ViewUsecase: view, ClapUsecase: &mocks.ClapUsecaseMock{}}
 
 	art, err := uc.GetArticleByID(context.Background(), "a2", "someone")
 	if err != nil || art == nil {
 		t.Fatalf("expected success, got %v", err)
 	}
-	if !viewed || !incremented {
-		t.Fatalf("expected view recorded and incremented, got viewed=%v incremented=%v", viewed, incremented)
+	if !viewed {
+		t.Fatalf("expected the view to be recorded for the reader")
 	}
 }
 
@@ -2,9 +2,11 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 	"write_base/internal/domain"
+	"write_base/internal/mocks"
 
 	"github.com/stretchr/testify/require"
 )
@@ -39,6 +41,23 @@ func TestTrending_Unauthorized(t *testing.T) {
 	require.ErrorIs(t, err, domain.ErrUnauthorized)
 }
 
+func TestTrending_UsesConfiguredWindow(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	var got time.Duration
+	repo.FindTrendingFn = func(ctx context.Context, window time.Duration, pag domain.Pagination) ([]domain.Article, int, error) {
+		got = window
+		return nil, 0, nil
+	}
+	_, _, err := uc.GetTrendingArticles(context.Background(), "u1", domain.Pagination{})
+	require.NoError(t, err)
+	require.Equal(t, domain.DefaultTrendingWindow, got)
+
+	uc.TrendingWindow = 36 * time.Hour
+	_, _, err = uc.GetTrendingArticles(context.Background(), "u1", domain.Pagination{})
+	require.NoError(t, err)
+	require.Equal(t, 36*time.Hour, got)
+}
+
 func TestFilterAuthorArticles_Unauthorized(t *testing.T) {
 	uc, _, policy, _, _, _, _ := newArticleUC()
 	policy.UserExistsFn = func(uid string) bool { return true }
@@ -75,12 +94,141 @@ func TestDeleteFromTrash_NotDeleted(t *testing.T) {
 func TestAddClap_Success(t *testing.T) {
 	uc, repo, policy, _, _, _, clap := newArticleUC()
 	policy.UserExistsFn = func(string) bool { return true }
-	clap.AddClapFn = func(ctx context.Context, uid, aid string) (int, error) { return 5, nil }
-	repo.UpdateClapCountFn = func(ctx context.Context, id string, count int) error { return nil }
-	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
-		return &domain.Article{ID: id, Stats: domain.ArticleStats{ClapCount: 5}}, nil
+	clap.AddClapFn = func(ctx context.Context, uid, aid string) (int, error) { return 1, nil }
+	repo.IncrementStatFn = func(ctx context.Context, id string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+		require.Equal(t, domain.StatClaps, counter)
+		require.Equal(t, 1, delta)
+		return &domain.ArticleStats{ClapCount: 5}, nil
 	}
 	stats, err := uc.AddClap(context.Background(), "u1", "a1")
 	require.NoError(t, err)
 	require.Equal(t, 5, stats.ClapCount)
 }
+
+func TestRemoveClaps_Success(t *testing.T) {
+	uc, repo, policy, _, _, _, clap := newArticleUC()
+	policy.UserExistsFn = func(string) bool { return true }
+	clap.RemoveClapsFn = func(ctx context.Context, uid, aid string) (int, error) { return 3, nil }
+	stored := 5
+	repo.IncrementStatFn = func(ctx context.Context, id string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+		stored += delta
+		return &domain.ArticleStats{ClapCount: stored}, nil
+	}
+	stats, err := uc.RemoveClaps(context.Background(), "u1", "a1")
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.ClapCount)
+}
+
+func TestAddClap_CounterFailureKeepsClap(t *testing.T) {
+	uc, repo, policy, _, _, _, clap := newArticleUC()
+	policy.UserExistsFn = func(string) bool { return true }
+	clap.AddClapFn = func(ctx context.Context, uid, aid string) (int, error) { return 1, nil }
+	repo.IncrementStatFn = func(ctx context.Context, id string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+		return nil, errors.New("write conflict")
+	}
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
+		return &domain.Article{ID: id, Stats: domain.ArticleStats{ClapCount: 4}}, nil
+	}
+	stats, err := uc.AddClap(context.Background(), "u1", "a1")
+	require.NoError(t, err)
+	require.Equal(t, 4, stats.ClapCount)
+}
+
+// The clap usecase reports the clapper's own count and how many claps were
+// removed; neither may be written as the article's total.
+func TestClaps_MoveArticleTotalByDelta(t *testing.T) {
+	uc, repo, policy, _, _, _, clap := newArticleUC()
+	notifications := &mocks.NotificationUsecaseMock{}
+	uc.Notifications = notifications
+	policy.UserExistsFn = func(string) bool { return true }
+	clap.AddClapFn = func(ctx context.Context, uid, aid string) (int, error) { return 7, nil }
+	clap.RemoveClapsFn = func(ctx context.Context, uid, aid string) (int, error) { return 7, nil }
+	total := 9
+	var deltas []int
+	repo.IncrementStatFn = func(ctx context.Context, id string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+		deltas = append(deltas, delta)
+		total += delta
+		return &domain.ArticleStats{ClapCount: total}, nil
+	}
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
+		return &domain.Article{ID: id, AuthorID: "author"}, nil
+	}
+
+	stats, err := uc.AddClap(context.Background(), "u1", "a1")
+	require.NoError(t, err)
+	require.Equal(t, 10, stats.ClapCount)
+	// The milestone is judged on the article total, not the clapper's 7
+	require.Len(t, notifications.Sent, 1)
+
+	stats, err = uc.RemoveClaps(context.Background(), "u1", "a1")
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.ClapCount)
+	require.Equal(t, []int{1, -7}, deltas)
+}
+
+func TestAddClap_MilestoneAnnouncedOnce(t *testing.T) {
+	uc, repo, policy, _, _, _, clap := newArticleUC()
+	notifications := &mocks.NotificationUsecaseMock{}
+	uc.Notifications = notifications
+	policy.UserExistsFn = func(string) bool { return true }
+	clap.AddClapFn = func(ctx context.Context, uid, aid string) (int, error) { return 1, nil }
+	clap.RemoveClapsFn = func(ctx context.Context, uid, aid string) (int, error) { return 1, nil }
+	stored := 9
+	repo.IncrementStatFn = func(ctx context.Context, id string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+		stored += delta
+		return &domain.ArticleStats{ClapCount: stored}, nil
+	}
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
+		return &domain.Article{ID: id, AuthorID: "author"}, nil
+	}
+	notified := map[int]bool{}
+	repo.MarkClapMilestoneFn = func(ctx context.Context, id string, milestone int) (bool, error) {
+		first := !notified[milestone]
+		notified[milestone] = true
+		return first, nil
+	}
+
+	// Reaching 10, undoing and clapping again reaches 10 a second time
+	_, err := uc.AddClap(context.Background(), "u1", "a1")
+	require.NoError(t, err)
+	_, err = uc.RemoveClaps(context.Background(), "u1", "a1")
+	require.NoError(t, err)
+	_, err = uc.AddClap(context.Background(), "u1", "a1")
+	require.NoError(t, err)
+	require.Len(t, notifications.Sent, 1)
+	require.Equal(t, domain.NotificationClapMilestone, notifications.Sent[0].Type)
+	require.Equal(t, "author", notifications.Sent[0].UserID)
+
+	// If the claim cannot be recorded the milestone is not announced
+	stored = 49
+	repo.MarkClapMilestoneFn = func(ctx context.Context, id string, milestone int) (bool, error) {
+		return false, errors.New("write conflict")
+	}
+	_, err = uc.AddClap(context.Background(), "u1", "a1")
+	require.NoError(t, err)
+	require.Len(t, notifications.Sent, 1)
+}
+
+func TestGetUserClaps(t *testing.T) {
+	uc, repo, policy, _, _, _, clap := newArticleUC()
+	policy.UserExistsFn = func(string) bool { return true }
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
+		if id == "missing" {
+			return nil, domain.ErrArticleNotFound
+		}
+		return &domain.Article{ID: id}, nil
+	}
+	clap.GetUserClapsFn = func(ctx context.Context, uid, aid string) (domain.UserClapState, error) {
+		return domain.UserClapState{Count: 3, Max: domain.MaxClapsPerUser}, nil
+	}
+	state, err := uc.GetUserClaps(context.Background(), "u1", "a1")
+	require.NoError(t, err)
+	require.Equal(t, 3, state.Count)
+
+	_, err = uc.GetUserClaps(context.Background(), "u1", "missing")
+	require.ErrorIs(t, err, domain.ErrArticleNotFound)
+
+	policy.UserExistsFn = func(string) bool { return false }
+	_, err = uc.GetUserClaps(context.Background(), "u1", "a1")
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 	"write_base/internal/domain"
@@ -18,7 +19,7 @@ func newArticleUC() (*usecase.ArticleUsecase, *mocks.ArticleRepositoryMock, *moc
 	tagUC := &mocks.TagUsecaseMock{ValidateTagsFn: func([]string) error { return nil }, IsTagApprovedFn: func(string) bool { return true }}
 	viewUC := &mocks.ViewUsecaseMock{}
 	clapUC := &mocks.ClapUsecaseMock{}
-	uc := usecase.NewArticleUsecase(repo, policy, utils, tagUC, viewUC, clapUC, nil).(*usecase.ArticleUsecase)
+	uc := usecase.NewArticleUsecase(repo, policy, utils, tagUC, viewUC, clapUC, nil, nil, nil, nil, nil, nil).(*usecase.ArticleUsecase)
 	return uc, repo, policy, utils, tagUC, viewUC, clapUC
 }
 
@@ -34,6 +35,32 @@ func TestUpdateArticle_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUpdateArticle_KeepsStatsAndStatus(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	published := time.Now().Add(-48 * time.Hour)
+	old := &domain.Article{
+		ID: "a1", AuthorID: "u1", Title: "T", Tags: []string{"go"}, Status: domain.StatusPublished,
+		ContentBlocks: []domain.ContentBlock{{Type: domain.BlockParagraph, Content: domain.BlockContent{Paragraph: &domain.ParagraphContent{Text: "x"}}}},
+		Stats:         domain.ArticleStats{ViewCount: 40, UniqueViewCount: 12, ClapCount: 9, CommentCount: 3, ReactionCount: 2, TrendingScore: 1.5},
+		Timestamps:    domain.ArticleTimes{CreatedAt: published.Add(-time.Hour), PublishedAt: &published},
+	}
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) { return old, nil }
+	repo.GetBySlugFn = func(ctx context.Context, slug string) (*domain.Article, error) { return nil, domain.ErrArticleNotFound }
+	var saved *domain.Article
+	repo.UpdateFn = func(ctx context.Context, a *domain.Article) error { saved = a; return nil }
+
+	// An edit payload carries no status, stats or timestamps
+	a := &domain.Article{ID: "a1", AuthorID: "u1", Title: "New", Tags: []string{"go"}, ContentBlocks: old.ContentBlocks}
+	require.NoError(t, uc.UpdateArticle(context.Background(), "u1", a))
+	require.NotNil(t, saved)
+	require.Equal(t, "New", saved.Title)
+	require.Equal(t, domain.StatusPublished, saved.Status)
+	require.Equal(t, old.Stats, saved.Stats)
+	require.Equal(t, old.Timestamps.CreatedAt, saved.Timestamps.CreatedAt)
+	require.Equal(t, old.Timestamps.PublishedAt, saved.Timestamps.PublishedAt)
+	require.False(t, saved.Timestamps.UpdatedAt.IsZero())
+}
+
 func TestDeleteArticle_Unauthorized(t *testing.T) {
 	uc, repo, policy, _, _, _, _ := newArticleUC()
 	policy.UserOwnsArticleFn = func(uid string, a *domain.Article) bool { return false }
@@ -103,27 +130,36 @@ func TestGetArticleByID_Published_ViewIncrement(t *testing.T) {
 	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
 		return &domain.Article{ID: id, AuthorID: "author", Status: domain.StatusPublished}, nil
 	}
-	called := false
-	viewUC.RecordViewFn = func(ctx context.Context, uid, aid, ip string) error { called = true; return nil }
-	inc := false
-	repo.IncrementViewFn = func(ctx context.Context, id string) error { inc = true; return nil }
+	var reader domain.ViewReader
+	viewUC.RecordViewFn = func(ctx context.Context, a *domain.Article, r domain.ViewReader) error { reader = r; return nil }
 	_, err := uc.GetArticleByID(context.Background(), "a1", "viewer")
 	require.NoError(t, err)
-	require.True(t, called)
-	require.True(t, inc)
+	require.Equal(t, "viewer", reader.UserID)
 }
 
-func TestGetArticleBySlug_Increments(t *testing.T) {
+func TestGetArticleBySlug_RecordsView(t *testing.T) {
 	uc, repo, _, _, _, viewUC, _ := newArticleUC()
-	repo.GetBySlugFn = func(ctx context.Context, slug string) (*domain.Article, error) { return &domain.Article{ID: "a1"}, nil }
-	called := false
-	viewUC.RecordViewFn = func(ctx context.Context, uid, aid, ip string) error { called = true; return nil }
-	inc := false
-	repo.IncrementViewFn = func(ctx context.Context, id string) error { inc = true; return nil }
-	_, err := uc.GetArticleBySlug(context.Background(), "hello", "1.1.1.1")
+	status := domain.StatusPublished
+	repo.GetBySlugFn = func(ctx context.Context, slug string) (*domain.Article, error) {
+		return &domain.Article{ID: "a1", Status: status}, nil
+	}
+	calls := 0
+	viewUC.RecordViewFn = func(ctx context.Context, a *domain.Article, r domain.ViewReader) error {
+		calls++
+		require.Equal(t, "1.1.1.1", r.ClientIP)
+		return errors.New("views unavailable")
+	}
+	reader := domain.ViewReader{ClientIP: "1.1.1.1", UserAgent: "Mozilla/5.0"}
+	// A view that could not be counted does not fail the read
+	_, err := uc.GetArticleBySlug(context.Background(), "hello", reader)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+
+	// Unpublished articles are not counted
+	status = domain.StatusDraft
+	_, err = uc.GetArticleBySlug(context.Background(), "hello", reader)
 	require.NoError(t, err)
-	require.True(t, called)
-	require.True(t, inc)
+	require.Equal(t, 1, calls)
 }
 
 func TestFilterArticles_DefaultStatus(t *testing.T) {
@@ -142,7 +178,7 @@ func TestFilterArticles_DefaultStatus(t *testing.T) {
 func TestSearchArticles_Unauthorized(t *testing.T) {
 	uc, _, policy, _, _, _, _ := newArticleUC()
 	policy.UserExistsFn = func(string) bool { return false }
-	_, _, err := uc.SearchArticles(context.Background(), "u1", "q", domain.Pagination{})
+	_, _, err := uc.SearchArticles(context.Background(), "u1", domain.SearchQuery{Text: "q"}, domain.Pagination{})
 	require.ErrorIs(t, err, domain.ErrUnauthorized)
 }
 
@@ -153,6 +189,19 @@ func TestListArticlesByTags_Unapproved(t *testing.T) {
 	require.ErrorIs(t, err, domain.ErrUnapprovedTags)
 }
 
+func TestListArticlesByTags_ExpandsTags(t *testing.T) {
+	uc, repo, _, _, tagUC, _, _ := newArticleUC()
+	tagUC.ExpandTagsFn = func(tags []string) []string { return append(tags, "generics") }
+	var queried []string
+	repo.ListByTagsFn = func(ctx context.Context, tags []string, p domain.Pagination) ([]domain.Article, int, error) {
+		queried = tags
+		return nil, 0, nil
+	}
+	_, _, err := uc.ListArticlesByTags(context.Background(), "u", []string{"go"}, domain.Pagination{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "generics"}, queried)
+}
+
 func TestAdminListAllArticles_Unauthorized(t *testing.T) {
 	uc, _, policy, _, _, _, _ := newArticleUC()
 	policy.IsAdminFn = func(string, string) bool { return false }
@@ -48,7 +48,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, err := uc.CreateArticle(context.Background(), tc.userID, tc.input)
 // 			if err != tc.expectErr {
@@ -121,7 +121,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, err := uc.UpdateArticle(context.Background(), tc.userID, tc.input)
 // 			if err != tc.expectErr {
@@ -165,7 +165,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			err := uc.DeleteArticle(context.Background(), tc.userID, tc.articleID)
 // 			if err != tc.expectErr {
@@ -209,7 +209,7 @@ package usecase_test
 //             if tc.setup != nil {
 //                 tc.setup(repo, policy)
 //             }
-//             uc := usecase.NewArticleUsecase(repo, policy)
+//             uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 //             _, err := uc.RestoreArticle(context.Background(), tc.userID, tc.articleID)
 //             if err != tc.expectErr {
@@ -253,7 +253,7 @@ package usecase_test
 //             if tc.setup != nil {
 //                 tc.setup(repo, policy)
 //             }
-//             uc := usecase.NewArticleUsecase(repo, policy)
+//             uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 //             stats, err := uc.GetArticleStats(context.Background(), tc.articleID)
 //             if err != tc.expectErr {
@@ -304,7 +304,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, err := uc.PublishArticle(context.Background(), tc.userID, tc.articleID)
 // 			if err != tc.expectErr {
@@ -349,7 +349,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, err := uc.UnpublishArticle(context.Background(), tc.userID, tc.articleID, false)
 // 			if err != tc.expectErr {
@@ -393,7 +393,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, err := uc.ArchiveArticle(context.Background(), tc.userID, tc.articleID)
 // 			if err != tc.expectErr {
@@ -437,7 +437,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, err := uc.UnarchiveArticle(context.Background(), tc.userID, tc.articleID)
 // 			if err != tc.expectErr {
@@ -491,7 +491,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, err := uc.GetArticleByID(context.Background(), tc.viewerID, tc.articleID, tc.userRole)
 // 			if err != tc.expectErr {
@@ -536,7 +536,7 @@ package usecase_test
 //             if tc.setup != nil {
 //                 tc.setup(repo, policy)
 //             }
-//             uc := usecase.NewArticleUsecase(repo, policy)
+//             uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 //             _, err := uc.ViewArticleBySlug(context.Background(), tc.slug, tc.clientIP)
 //             if err != tc.expectErr {
@@ -585,7 +585,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			articles, count, err := uc.ListUserArticles(context.Background(), tc.userID, tc.authorID, tc.pag)
 // 			if err != tc.expectErr {
@@ -644,7 +644,7 @@ package usecase_test
 // 				tc.setup(repo)
 // 			}
 
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 // 			articles, count, err := uc.ListTrendingArticles(context.Background(), tc.pag, tc.windowDays)
 
 // 			if err != tc.expectErr {
@@ -691,7 +691,7 @@ package usecase_test
 //             if tc.setup != nil {
 //                 tc.setup(repo, policy)
 //             }
-//             uc := usecase.NewArticleUsecase(repo, policy)
+//             uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 //             articles, count, err := uc.ListArticlesByTag(context.Background(), tc.userID, tc.tag, tc.pag)
 //             if err != tc.expectErr {
@@ -739,7 +739,7 @@ package usecase_test
 //             if tc.setup != nil {
 //                 tc.setup(repo, policy)
 //             }
-//             uc := usecase.NewArticleUsecase(repo, policy)
+//             uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 //             articles, count, err := uc.SearchArticles(context.Background(), tc.userID, tc.query, tc.pag)
 //             if err != tc.expectErr {
@@ -786,7 +786,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, _, err := uc.FilterArticles(context.Background(), tc.userID, tc.filter, tc.pag)
 // 			if err != tc.expectErr {
@@ -833,7 +833,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, err := uc.ClapArticle(context.Background(), tc.articleID, tc.userID)
 // 			if err != tc.expectErr {
@@ -877,7 +877,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			err := uc.EmptyTrash(context.Background(), tc.userID)
 // 			if err != tc.expectErr {
@@ -926,7 +926,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, _, err := uc.AdminListAllArticles(context.Background(), tc.userID, tc.userRole, tc.pag)
 // 			if err != tc.expectErr {
@@ -970,7 +970,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			err := uc.AdminHardDeleteArticle(context.Background(), tc.userID, tc.userRole, tc.articleID)
 // 			if err != tc.expectErr {
@@ -1015,7 +1015,7 @@ package usecase_test
 // 			if tc.setup != nil {
 // 				tc.setup(repo, policy)
 // 			}
-// 			uc := usecase.NewArticleUsecase(repo, policy)
+// 			uc := usecase.NewArticleUsecase(repo, policy, nil, nil)
 
 // 			_, err := uc.AdminUnpublishArticle(context.Background(), tc.userID, tc.userRole, tc.articleID)
 // 			if err != tc.expectErr {
@@ -24,7 +24,7 @@ func TestArticleUsecase_CreateArticle_Success(t *testing.T) {
 	repo.GetBySlugFn = func(ctx context.Context, slug string) (*domain.Article, error) { return nil, domain.ErrArticleNotFound }
 	repo.CreateFn = func(ctx context.Context, a *domain.Article) error { return nil }
 
-	uc := usecase.NewArticleUsecase(repo, policy, utils, tagUC, viewUC, clapUC, nil)
+	uc := usecase.NewArticleUsecase(repo, policy, utils, tagUC, viewUC, clapUC, nil, nil, nil, nil, nil, nil)
 
 	input := &domain.Article{Title: "Hello", Tags: []string{"go"}, ContentBlocks: []domain.ContentBlock{{Type: domain.BlockParagraph, Order: 0, Content: domain.BlockContent{Paragraph: &domain.ParagraphContent{Text: "hi"}}}}}
 	id, err := uc.CreateArticle(context.Background(), "u1", input)
@@ -45,7 +45,7 @@ func TestArticleUsecase_PublishArticle_RequiresApprovedTags(t *testing.T) {
 	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) { return art, nil }
 	repo.PublishFn = func(ctx context.Context, id string, at time.Time) error { return nil }
 
-	uc := usecase.NewArticleUsecase(repo, policy, utils, tagUC, viewUC, clapUC, nil)
+	uc := usecase.NewArticleUsecase(repo, policy, utils, tagUC, viewUC, clapUC, nil, nil, nil, nil, nil, nil)
 
 	out, err := uc.PublishArticle(context.Background(), "a1", "u1")
 	require.NoError(t, err)
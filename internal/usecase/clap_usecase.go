@@ -15,41 +15,26 @@ func NewClapUsecase(clapRepo domain.ClapRepository,u domain.IUtils) domain.ClapU
 }
 
 func (uc *ClapUsecaseImpl) AddClap(ctx context.Context, userID, articleID string) (int, error) {
-	// Check if user has existing clap record
-	clap, err := uc.clapRepo.GetByUserAndArticle(ctx, userID, articleID)
+	// The cap is checked by the increment itself, so concurrent taps cannot overshoot it
+	clap, err := uc.clapRepo.Increment(ctx, userID, articleID, domain.MaxClapsPerUser)
 	if err != nil {
 		return 0, err
 	}
-	
-	// Create new clap if doesn't exist
-	if clap == nil {
-		clap = &domain.Clap{
-			ID: uc.utils.GenerateUUID(),
-			UserID:    userID,
-			ArticleID: articleID,
-			Count:     1,
-		}
-		if err := uc.clapRepo.Create(ctx, clap); err != nil {
-			return 0, err
-		}
-	} else {
-		// Check clap limit
-		if clap.Count >= domain.MaxClapsPerUser {
-			return 0, domain.ErrClapLimitExceeded
-		}
-		
-		// Increment existing clap
-		clap.Count++
-		if err := uc.clapRepo.Update(ctx, clap); err != nil {
-			return 0, err
-		}
-	}
-	
-	// Get total clap count for article
-	total, err := uc.clapRepo.GetArticleClapCount(ctx, articleID)
+	return clap.Count, nil
+}
+
+func (uc *ClapUsecaseImpl) RemoveClaps(ctx context.Context, userID, articleID string) (int, error) {
+	return uc.clapRepo.Remove(ctx, userID, articleID)
+}
+
+func (uc *ClapUsecaseImpl) GetUserClaps(ctx context.Context, userID, articleID string) (domain.UserClapState, error) {
+	state := domain.UserClapState{Max: domain.MaxClapsPerUser}
+	clap, err := uc.clapRepo.GetByUserAndArticle(ctx, userID, articleID)
 	if err != nil {
-		return 0, err
+		return state, err
 	}
-	
-	return total, nil
-}
\ No newline at end of file
+	if clap != nil {
+		state.Count = clap.Count
+	}
+	return state, nil
+}
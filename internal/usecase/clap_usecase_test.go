@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+// capClapRepo keeps per-user counts in memory and applies the cap atomically,
+// as the MongoDB increment does.
+type capClapRepo struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (r *capClapRepo) Increment(ctx context.Context, userID, articleID string, max int) (*domain.Clap, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.counts[userID] >= max {
+		return nil, domain.ErrClapLimitExceeded
+	}
+	r.counts[userID]++
+	return &domain.Clap{UserID: userID, ArticleID: articleID, Count: r.counts[userID]}, nil
+}
+func (r *capClapRepo) Remove(ctx context.Context, userID, articleID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.counts[userID]
+	delete(r.counts, userID)
+	return n, nil
+}
+func (r *capClapRepo) GetByUserAndArticle(ctx context.Context, userID, articleID string) (*domain.Clap, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n, ok := r.counts[userID]; ok {
+		return &domain.Clap{UserID: userID, ArticleID: articleID, Count: n}, nil
+	}
+	return nil, nil
+}
+func (r *capClapRepo) GetArticleClapCount(ctx context.Context, articleID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	total := 0
+	for _, n := range r.counts {
+		total += n
+	}
+	return total, nil
+}
+
+func TestClapUsecase_CapUndoAndState(t *testing.T) {
+	repo := &capClapRepo{counts: map[string]int{"u2": 4}}
+	uc := usecase.NewClapUsecase(repo, nil)
+	ctx := context.Background()
+
+	// Concurrent taps never go past the cap
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	limited := 0
+	for i := 0; i < domain.MaxClapsPerUser+5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := uc.AddClap(ctx, "u1", "a1"); err == domain.ErrClapLimitExceeded {
+				mu.Lock()
+				limited++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 5, limited)
+
+	state, err := uc.GetUserClaps(ctx, "u1", "a1")
+	require.NoError(t, err)
+	require.Equal(t, domain.UserClapState{Count: domain.MaxClapsPerUser, Max: domain.MaxClapsPerUser}, state)
+	require.Equal(t, 0, state.Remaining())
+
+	removed, err := uc.RemoveClaps(ctx, "u1", "a1")
+	require.NoError(t, err)
+	require.Equal(t, domain.MaxClapsPerUser, removed)
+
+	state, err = uc.GetUserClaps(ctx, "u1", "a1")
+	require.NoError(t, err)
+	require.Equal(t, 0, state.Count)
+	require.Equal(t, domain.MaxClapsPerUser, state.Remaining())
+}
@@ -2,41 +2,399 @@ package usecase
 
 import (
 	"context"
+	"time"
 	"write_base/internal/domain"
 )
 
 type CommentUsecase struct {
-	repo domain.ICommentRepository
+	repo          domain.ICommentRepository
+	articles      domain.IArticleRepository
+	moderation    domain.IModerationService
+	mentions      domain.IMentionService
+	notifications domain.INotificationUsecase
+	now           func() time.Time
 }
 
-func NewCommentUsecase(repo domain.ICommentRepository) *CommentUsecase {
-	return &CommentUsecase{repo: repo}
+func NewCommentUsecase(repo domain.ICommentRepository, articles domain.IArticleRepository, moderation domain.IModerationService, mentions domain.IMentionService, notifications domain.INotificationUsecase) *CommentUsecase {
+	return &CommentUsecase{repo: repo, articles: articles, moderation: moderation, mentions: mentions, notifications: notifications, now: time.Now}
+}
+
+// moderate blocks disallowed comments before they are stored; the verdict is
+// returned so flagged comments can be queued for review once they have an ID.
+func (uc *CommentUsecase) moderate(ctx context.Context, content string) (*domain.ModerationVerdict, error) {
+	if uc.moderation == nil {
+		return &domain.ModerationVerdict{Action: domain.ModerationAllow}, nil
+	}
+	verdict, err := uc.moderation.Moderate(ctx, domain.ContentKindComment, content)
+	if err != nil {
+		return nil, err
+	}
+	if verdict.Action == domain.ModerationBlock {
+		return nil, domain.ErrContentBlocked
+	}
+	return verdict, nil
+}
+
+func (uc *CommentUsecase) flagIfNeeded(ctx context.Context, commentID string, verdict *domain.ModerationVerdict) {
+	if verdict.Action == domain.ModerationFlag {
+		_ = uc.moderation.FlagForReview(ctx, domain.ContentKindComment, commentID, verdict)
+	}
+}
+
+func (uc *CommentUsecase) resolveMentions(ctx context.Context, comment *domain.Comment) {
+	if uc.mentions != nil {
+		comment.Mentions = uc.mentions.Resolve(ctx, comment.Content)
+	}
+}
+
+// publishMentions tells listeners about users mentioned since previous.
+func (uc *CommentUsecase) publishMentions(ctx context.Context, comment *domain.Comment, previous []domain.Mention) {
+	if uc.mentions == nil {
+		return
+	}
+	event := domain.MentionEvent{
+		Source:    domain.MentionInComment,
+		SourceID:  comment.ID,
+		ArticleID: comment.PostID,
+		ActorID:   comment.UserID,
+		CreatedAt: time.Unix(comment.UpdatedAt, 0),
+	}
+	uc.mentions.Publish(ctx, event, comment.Mentions, previous)
 }
 
 func (uc *CommentUsecase) CreateComment(ctx context.Context, comment *domain.Comment) error {
-	return uc.repo.Create(ctx, comment)
+	if comment.UserID == "" {
+		return domain.ErrUnauthorized
+	}
+	article, err := uc.publishedArticle(ctx, comment.PostID)
+	if err != nil {
+		return err
+	}
+	var parent *domain.Comment
+	if comment.ParentID != nil {
+		parent, err = uc.repo.GetByID(ctx, *comment.ParentID)
+		if err != nil {
+			return err
+		}
+		if parent.Deleted {
+			return domain.ErrCommentNotFound
+		}
+		if parent.PostID != comment.PostID {
+			return domain.ErrCommentParent
+		}
+	}
+	verdict, err := uc.moderate(ctx, comment.Content)
+	if err != nil {
+		return err
+	}
+	// Thread pages sort and page by creation time
+	comment.CreatedAt = uc.now().Unix()
+	comment.UpdatedAt = comment.CreatedAt
+	uc.resolveMentions(ctx, comment)
+	if err := uc.repo.Create(ctx, comment); err != nil {
+		return err
+	}
+	uc.countComment(ctx, comment.PostID, 1)
+	uc.flagIfNeeded(ctx, comment.ID, verdict)
+	uc.publishMentions(ctx, comment, nil)
+	uc.notifyComment(ctx, comment, article, parent)
+	return nil
+}
+
+// countComment moves the article's comment counter; the reconcile job
+// repairs it if the update fails.
+func (uc *CommentUsecase) countComment(ctx context.Context, postID string, delta int) {
+	_, _ = uc.articles.IncrementStat(ctx, postID, domain.StatComments, delta)
+}
+
+// notifyComment tells the parent's author about a reply and the article's
+// author about a new comment; someone who is both hears about it once.
+func (uc *CommentUsecase) notifyComment(ctx context.Context, comment *domain.Comment, article *domain.Article, parent *domain.Comment) {
+	if uc.notifications == nil {
+		return
+	}
+	n := domain.Notification{ActorID: comment.UserID, ArticleID: comment.PostID, CommentID: comment.ID}
+	if parent != nil {
+		n.UserID, n.Type = parent.UserID, domain.NotificationCommentReply
+		_ = uc.notifications.Notify(ctx, n)
+		if parent.UserID == article.AuthorID {
+			return
+		}
+	}
+	n.UserID, n.Type = article.AuthorID, domain.NotificationArticleComment
+	_ = uc.notifications.Notify(ctx, n)
+}
+
+// publishedArticle loads the article a comment is posted on; only published
+// articles take comments.
+func (uc *CommentUsecase) publishedArticle(ctx context.Context, postID string) (*domain.Article, error) {
+	article, err := uc.articles.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if article.Status != domain.StatusPublished {
+		return nil, domain.ErrArticleNotPublished
+	}
+	return article, nil
+}
+
+// live loads a comment that has not been deleted.
+func (uc *CommentUsecase) live(ctx context.Context, commentID string) (*domain.Comment, error) {
+	existing, err := uc.repo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Deleted {
+		return nil, domain.ErrCommentNotFound
+	}
+	return existing, nil
+}
+
+// authorize loads the comment and checks that userID wrote it.
+func (uc *CommentUsecase) authorize(ctx context.Context, userID, commentID string) (*domain.Comment, error) {
+	if userID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	existing, err := uc.live(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.UserID != userID {
+		return nil, domain.ErrCommentPermission
+	}
+	return existing, nil
+}
+
+// authorizeArticleAuthor loads the comment and checks that userID wrote the
+// article it was posted on.
+func (uc *CommentUsecase) authorizeArticleAuthor(ctx context.Context, userID, commentID string) (*domain.Comment, error) {
+	if userID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	existing, err := uc.live(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	article, err := uc.articles.GetByID(ctx, existing.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if article.AuthorID != userID {
+		return nil, domain.ErrCommentPermission
+	}
+	return existing, nil
+}
+
+func (uc *CommentUsecase) UpdateComment(ctx context.Context, userID string, comment *domain.Comment) error {
+	existing, err := uc.authorize(ctx, userID, comment.ID)
+	if err != nil {
+		return err
+	}
+	verdict, err := uc.moderate(ctx, comment.Content)
+	if err != nil {
+		return err
+	}
+	comment.UpdatedAt = uc.now().Unix()
+	comment.EditedAt = comment.UpdatedAt
+	uc.resolveMentions(ctx, comment)
+	if err := uc.repo.Update(ctx, comment); err != nil {
+		return err
+	}
+	uc.flagIfNeeded(ctx, comment.ID, verdict)
+	// Only users newly mentioned by the edit hear about it
+	comment.UserID, comment.PostID = existing.UserID, existing.PostID
+	uc.publishMentions(ctx, comment, existing.Mentions)
+	return nil
+}
+
+func (uc *CommentUsecase) DeleteComment(ctx context.Context, userID, commentID string) error {
+	existing, err := uc.authorize(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+	// Delete only matches a live comment, so a repeated delete is not counted twice
+	if err := uc.repo.Delete(ctx, commentID); err != nil {
+		return err
+	}
+	uc.countComment(ctx, existing.PostID, -1)
+	return nil
 }
 
-func (uc *CommentUsecase) UpdateComment(ctx context.Context, comment *domain.Comment) error {
-	return uc.repo.Update(ctx, comment)
+func (uc *CommentUsecase) PinComment(ctx context.Context, userID, commentID string, pinned bool) error {
+	existing, err := uc.authorizeArticleAuthor(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+	if !pinned {
+		if !existing.Pinned {
+			return nil
+		}
+		return uc.repo.SetPinned(ctx, existing.PostID, "")
+	}
+	if existing.ParentID != nil {
+		return domain.ErrCommentPinReply
+	}
+	if existing.Hidden {
+		return domain.ErrCommentNotFound
+	}
+	return uc.repo.SetPinned(ctx, existing.PostID, existing.ID)
 }
 
-func (uc *CommentUsecase) DeleteComment(ctx context.Context, commentID string) error {
-	return uc.repo.Delete(ctx, commentID)
+func (uc *CommentUsecase) HideComment(ctx context.Context, userID, commentID string, hidden bool) error {
+	if _, err := uc.authorizeArticleAuthor(ctx, userID, commentID); err != nil {
+		return err
+	}
+	return uc.repo.SetHidden(ctx, commentID, hidden)
+}
+
+// GetCommentHistory is not available for deleted or hidden comments, whose
+// content is no longer shown.
+func (uc *CommentUsecase) GetCommentHistory(ctx context.Context, commentID string) ([]domain.CommentRevision, error) {
+	existing, err := uc.live(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Hidden {
+		return nil, domain.ErrCommentNotFound
+	}
+	return uc.repo.GetHistory(ctx, commentID)
 }
 
 func (uc *CommentUsecase) GetCommentByID(ctx context.Context, commentID string) (*domain.Comment, error) {
-	return uc.repo.GetByID(ctx, commentID)
+	comment, err := uc.repo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	comment.Redact()
+	return comment, nil
 }
 
 func (uc *CommentUsecase) GetCommentsByPostID(ctx context.Context, postID string) ([]*domain.Comment, error) {
-	return uc.repo.GetByPostID(ctx, postID)
+	return redactAll(uc.repo.GetByPostID(ctx, postID))
 }
 
+// GetCommentsByUserID leaves out deleted comments, which no longer show their author.
 func (uc *CommentUsecase) GetCommentsByUserID(ctx context.Context, userID string) ([]*domain.Comment, error) {
-	return uc.repo.GetByUserID(ctx, userID)
+	comments, err := uc.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	live := comments[:0]
+	for _, c := range comments {
+		if !c.Deleted {
+			live = append(live, c)
+		}
+	}
+	return redactAll(live, nil)
 }
 
 func (uc *CommentUsecase) GetReplies(ctx context.Context, parentID string) ([]*domain.Comment, error) {
-	return uc.repo.GetReplies(ctx, parentID)
+	return redactAll(uc.repo.GetReplies(ctx, parentID))
+}
+
+func redactAll(comments []*domain.Comment, err error) ([]*domain.Comment, error) {
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		c.Redact()
+	}
+	return comments, nil
+}
+
+func (uc *CommentUsecase) GetCommentTree(ctx context.Context, postID string, query domain.CommentThreadQuery) ([]domain.CommentNode, string, error) {
+	return uc.thread(ctx, domain.CommentListQuery{PostID: postID}, query)
+}
+
+func (uc *CommentUsecase) GetReplyTree(ctx context.Context, commentID string, query domain.CommentThreadQuery) ([]domain.CommentNode, string, error) {
+	parent, err := uc.repo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, "", err
+	}
+	return uc.thread(ctx, domain.CommentListQuery{PostID: parent.PostID, ParentID: commentID}, query)
+}
+
+// thread pages through one level and expands reply previews below it.
+func (uc *CommentUsecase) thread(ctx context.Context, list domain.CommentListQuery, query domain.CommentThreadQuery) ([]domain.CommentNode, string, error) {
+	query = query.WithDefaults()
+	if query.Cursor != "" {
+		after, err := domain.DecodeCommentCursor(query.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if after.Sort != query.Sort {
+			return nil, "", domain.ErrInvalidCursor
+		}
+		list.After = after
+	}
+	list.Sort = query.Sort
+	list.Limit = query.PageSize + 1
+
+	nodes, err := uc.repo.ListThread(ctx, list)
+	if err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if len(nodes) > query.PageSize {
+		nodes = nodes[:query.PageSize]
+		next = domain.CommentCursorFor(query.Sort, nodes[len(nodes)-1]).Encode()
+	}
+	// The pinned comment leads the first page of top-level comments
+	if list.ParentID == "" && list.After == nil {
+		pinned, err := uc.repo.GetPinned(ctx, list.PostID)
+		if err != nil {
+			return nil, "", err
+		}
+		if pinned != nil {
+			nodes = append([]domain.CommentNode{*pinned}, nodes...)
+		}
+	}
+	if err := uc.expand(ctx, nodes, query); err != nil {
+		return nil, "", err
+	}
+	return nodes, next, nil
+}
+
+// expand fills reply counts and previews one level at a time, so each level
+// costs two queries however many comments it holds.
+func (uc *CommentUsecase) expand(ctx context.Context, nodes []domain.CommentNode, query domain.CommentThreadQuery) error {
+	level := make([]*domain.CommentNode, len(nodes))
+	for i := range nodes {
+		level[i] = &nodes[i]
+	}
+	for depth := 1; len(level) > 0; depth++ {
+		ids := make([]string, len(level))
+		for i, n := range level {
+			ids[i] = n.ID
+		}
+		counts, err := uc.repo.CountReplies(ctx, ids)
+		if err != nil {
+			return err
+		}
+		var withReplies []string
+		for _, n := range level {
+			n.Redact()
+			n.ReplyCount = counts[n.ID]
+			if n.ReplyCount > 0 {
+				withReplies = append(withReplies, n.ID)
+			}
+		}
+
+		replies := map[string][]domain.CommentNode{}
+		if depth < query.Depth && query.Replies > 0 && len(withReplies) > 0 {
+			if replies, err = uc.repo.ListReplies(ctx, withReplies, query.Sort, query.Replies); err != nil {
+				return err
+			}
+		}
+		var next []*domain.CommentNode
+		for _, n := range level {
+			n.Replies = replies[n.ID]
+			n.HasMoreReplies = n.ReplyCount > len(n.Replies)
+			for j := range n.Replies {
+				next = append(next, &n.Replies[j])
+			}
+		}
+		level = next
+	}
+	return nil
 }
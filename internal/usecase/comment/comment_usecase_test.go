@@ -3,13 +3,27 @@ package usecase
 import (
 	"context"
 	"testing"
+	"time"
 	"write_base/internal/domain"
 	"write_base/internal/mocks"
 )
 
+// publishedArticles serves published articles written by "writer", except for
+// the "draft" ID.
+func publishedArticles() *mocks.ArticleRepositoryMock {
+	return &mocks.ArticleRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) {
+			if id == "draft" {
+				return &domain.Article{ID: id, AuthorID: "writer", Status: domain.StatusDraft}, nil
+			}
+			return &domain.Article{ID: id, AuthorID: "writer", Status: domain.StatusPublished}, nil
+		},
+	}
+}
+
 func TestCommentUsecase_CRUD(t *testing.T) {
 	repo := &mocks.CommentRepositoryMock{}
-	uc := NewCommentUsecase(repo)
+	uc := NewCommentUsecase(repo, publishedArticles(), nil, nil, nil)
 
 	c := &domain.Comment{ID: "c1", PostID: "p1", UserID: "u1", Content: "hello"}
 
@@ -21,14 +35,14 @@ func TestCommentUsecase_CRUD(t *testing.T) {
 	}
 
 	// Update
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Comment, error) { return c, nil }
 	updated := false
 	repo.UpdateFn = func(ctx context.Context, comment *domain.Comment) error { updated = true; return nil }
-	if err := uc.UpdateComment(context.Background(), c); err != nil || !updated {
+	if err := uc.UpdateComment(context.Background(), "u1", c); err != nil || !updated {
 		t.Fatalf("update failed")
 	}
 
 	// GetByID
-	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Comment, error) { return c, nil }
 	if out, err := uc.GetCommentByID(context.Background(), "c1"); err != nil || out.ID != "c1" {
 		t.Fatalf("get by id failed")
 	}
@@ -56,7 +70,441 @@ func TestCommentUsecase_CRUD(t *testing.T) {
 	// Delete
 	deleted := false
 	repo.DeleteFn = func(ctx context.Context, id string) error { deleted = true; return nil }
-	if err := uc.DeleteComment(context.Background(), "c1"); err != nil || !deleted {
+	if err := uc.DeleteComment(context.Background(), "u1", "c1"); err != nil || !deleted {
 		t.Fatalf("delete failed")
 	}
 }
+
+func TestCommentUsecase_Moderation(t *testing.T) {
+	repo := &mocks.CommentRepositoryMock{}
+	var flagged string
+	mod := &mocks.ModerationServiceMock{
+		ModerateFn: func(ctx context.Context, kind domain.ContentKind, text string) (*domain.ModerationVerdict, error) {
+			switch text {
+			case "blocked":
+				return &domain.ModerationVerdict{Action: domain.ModerationBlock}, nil
+			case "flagged":
+				return &domain.ModerationVerdict{Action: domain.ModerationFlag}, nil
+			}
+			return &domain.ModerationVerdict{Action: domain.ModerationAllow}, nil
+		},
+		FlagForReviewFn: func(ctx context.Context, kind domain.ContentKind, targetID string, v *domain.ModerationVerdict) error {
+			flagged = targetID
+			return nil
+		},
+	}
+	uc := NewCommentUsecase(repo, publishedArticles(), mod, nil, nil)
+
+	created := false
+	repo.CreateFn = func(ctx context.Context, comment *domain.Comment) error { created = true; return nil }
+	if err := uc.CreateComment(context.Background(), &domain.Comment{ID: "c1", UserID: "u1", Content: "blocked"}); err != domain.ErrContentBlocked || created {
+		t.Fatalf("expected blocked comment to be rejected, got %v", err)
+	}
+
+	if err := uc.CreateComment(context.Background(), &domain.Comment{ID: "c2", UserID: "u1", Content: "flagged"}); err != nil || !created {
+		t.Fatalf("flagged comment should still be created: %v", err)
+	}
+	if flagged != "c2" {
+		t.Fatalf("expected c2 to be flagged for review, got %q", flagged)
+	}
+}
+
+func TestCommentUsecase_OnlyAuthorModifies(t *testing.T) {
+	repo := &mocks.CommentRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Comment, error) {
+			return &domain.Comment{ID: id, UserID: "author"}, nil
+		},
+		UpdateFn: func(ctx context.Context, comment *domain.Comment) error {
+			t.Fatalf("update must not be reached")
+			return nil
+		},
+		DeleteFn: func(ctx context.Context, id string) error {
+			t.Fatalf("delete must not be reached")
+			return nil
+		},
+	}
+	uc := NewCommentUsecase(repo, publishedArticles(), nil, nil, nil)
+	ctx := context.Background()
+
+	if err := uc.UpdateComment(ctx, "intruder", &domain.Comment{ID: "c1", Content: "x"}); err != domain.ErrCommentPermission {
+		t.Fatalf("expected permission error on update, got %v", err)
+	}
+	if err := uc.DeleteComment(ctx, "intruder", "c1"); err != domain.ErrCommentPermission {
+		t.Fatalf("expected permission error on delete, got %v", err)
+	}
+	if err := uc.DeleteComment(ctx, "", "c1"); err != domain.ErrUnauthorized {
+		t.Fatalf("expected unauthorized without a user, got %v", err)
+	}
+	if err := uc.CreateComment(ctx, &domain.Comment{PostID: "p1", Content: "hi"}); err != domain.ErrUnauthorized {
+		t.Fatalf("expected unauthorized create without a user, got %v", err)
+	}
+}
+
+// threadRepo serves a fixed thread: top-level t1..t3, t1 has replies r1, r2, r3
+// and r1 has one reply rr1. Comments are already in "newest" order.
+func threadRepo(listed *[]domain.CommentListQuery) *mocks.CommentRepositoryMock {
+	children := map[string][]domain.CommentNode{
+		"":   {{Comment: domain.Comment{ID: "t1", CreatedAt: 30}}, {Comment: domain.Comment{ID: "t2", CreatedAt: 20}}, {Comment: domain.Comment{ID: "t3", CreatedAt: 10}}},
+		"t1": {{Comment: domain.Comment{ID: "r1"}}, {Comment: domain.Comment{ID: "r2"}}, {Comment: domain.Comment{ID: "r3"}}},
+		"r1": {{Comment: domain.Comment{ID: "rr1"}}},
+	}
+	return &mocks.CommentRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Comment, error) {
+			return &domain.Comment{ID: id, PostID: "p1"}, nil
+		},
+		ListThreadFn: func(ctx context.Context, q domain.CommentListQuery) ([]domain.CommentNode, error) {
+			*listed = append(*listed, q)
+			all := children[q.ParentID]
+			start := 0
+			if q.After != nil {
+				for i, n := range all {
+					if n.ID == q.After.ID {
+						start = i + 1
+					}
+				}
+			}
+			all = all[start:]
+			if len(all) > q.Limit {
+				all = all[:q.Limit]
+			}
+			return append([]domain.CommentNode(nil), all...), nil
+		},
+		ListRepliesFn: func(ctx context.Context, parentIDs []string, sort domain.CommentSort, perParent int) (map[string][]domain.CommentNode, error) {
+			out := map[string][]domain.CommentNode{}
+			for _, id := range parentIDs {
+				replies := children[id]
+				if len(replies) > perParent {
+					replies = replies[:perParent]
+				}
+				out[id] = append([]domain.CommentNode(nil), replies...)
+			}
+			return out, nil
+		},
+		CountRepliesFn: func(ctx context.Context, parentIDs []string) (map[string]int, error) {
+			counts := map[string]int{}
+			for _, id := range parentIDs {
+				counts[id] = len(children[id])
+			}
+			return counts, nil
+		},
+	}
+}
+
+func TestCommentUsecase_Tree(t *testing.T) {
+	var listed []domain.CommentListQuery
+	uc := NewCommentUsecase(threadRepo(&listed), publishedArticles(), nil, nil, nil)
+	ctx := context.Background()
+
+	nodes, next, err := uc.GetCommentTree(ctx, "p1", domain.CommentThreadQuery{PageSize: 2, Replies: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 || nodes[0].ID != "t1" || next == "" {
+		t.Fatalf("unexpected first page: %+v next=%q", nodes, next)
+	}
+	if listed[0].PostID != "p1" || listed[0].ParentID != "" || listed[0].Sort != domain.CommentSortNewest || listed[0].Limit != 3 {
+		t.Fatalf("unexpected list query: %+v", listed[0])
+	}
+	t1 := nodes[0]
+	if t1.ReplyCount != 3 || len(t1.Replies) != 2 || !t1.HasMoreReplies {
+		t.Fatalf("t1 should show 2 of 3 replies: %+v", t1)
+	}
+	r1 := t1.Replies[0]
+	if r1.ReplyCount != 1 || len(r1.Replies) != 1 || r1.HasMoreReplies {
+		t.Fatalf("r1 should be fully expanded at depth 3: %+v", r1)
+	}
+	if rr1 := r1.Replies[0]; rr1.ReplyCount != 0 || rr1.Replies != nil {
+		t.Fatalf("rr1 is a leaf: %+v", rr1)
+	}
+
+	nodes, next, err = uc.GetCommentTree(ctx, "p1", domain.CommentThreadQuery{PageSize: 2, Cursor: next})
+	if err != nil || len(nodes) != 1 || nodes[0].ID != "t3" || next != "" {
+		t.Fatalf("unexpected last page: %+v next=%q err=%v", nodes, next, err)
+	}
+
+	if _, _, err := uc.GetCommentTree(ctx, "p1", domain.CommentThreadQuery{Sort: domain.CommentSortOldest, Cursor: domain.CommentCursor{Sort: domain.CommentSortNewest, ID: "t1"}.Encode()}); err != domain.ErrInvalidCursor {
+		t.Fatalf("a cursor from another sort must be rejected, got %v", err)
+	}
+	if _, _, err := uc.GetCommentTree(ctx, "p1", domain.CommentThreadQuery{Cursor: "garbage"}); err != domain.ErrInvalidCursor {
+		t.Fatalf("expected invalid cursor, got %v", err)
+	}
+
+	// Depth 1 collapses every branch; load more replies on t1 from the thread endpoint
+	nodes, _, _ = uc.GetCommentTree(ctx, "p1", domain.CommentThreadQuery{Depth: 1, Replies: 3})
+	if nodes[0].Replies != nil || !nodes[0].HasMoreReplies {
+		t.Fatalf("depth 1 should collapse replies: %+v", nodes[0])
+	}
+	replies, _, err := uc.GetReplyTree(ctx, "t1", domain.CommentThreadQuery{Depth: 1, Replies: 3})
+	if err != nil || len(replies) != 3 || !replies[0].HasMoreReplies {
+		t.Fatalf("unexpected replies: %+v err=%v", replies, err)
+	}
+	if last := listed[len(listed)-1]; last.ParentID != "t1" || last.PostID != "p1" {
+		t.Fatalf("reply tree should list the children of t1: %+v", last)
+	}
+}
+
+func TestCommentUsecase_SetsTimestamps(t *testing.T) {
+	var saved *domain.Comment
+	repo := &mocks.CommentRepositoryMock{CreateFn: func(ctx context.Context, c *domain.Comment) error { saved = c; return nil }}
+	uc := NewCommentUsecase(repo, publishedArticles(), nil, nil, nil)
+	uc.now = func() time.Time { return time.Unix(1700000000, 0) }
+	if err := uc.CreateComment(context.Background(), &domain.Comment{UserID: "u1", Content: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	if saved.CreatedAt != 1700000000 || saved.UpdatedAt != 1700000000 {
+		t.Fatalf("unexpected timestamps: %+v", saved)
+	}
+}
+
+func TestCommentUsecase_CreateValidatesArticleAndParent(t *testing.T) {
+	repo := &mocks.CommentRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Comment, error) {
+			return &domain.Comment{ID: id, PostID: "p1", Deleted: id == "gone"}, nil
+		},
+		CreateFn: func(ctx context.Context, c *domain.Comment) error {
+			t.Fatalf("create must not be reached")
+			return nil
+		},
+	}
+	uc := NewCommentUsecase(repo, publishedArticles(), nil, nil, nil)
+	ctx := context.Background()
+	parent := func(id string) *string { return &id }
+
+	if err := uc.CreateComment(ctx, &domain.Comment{UserID: "u1", PostID: "draft", Content: "hi"}); err != domain.ErrArticleNotPublished {
+		t.Fatalf("expected unpublished article to be rejected, got %v", err)
+	}
+	if err := uc.CreateComment(ctx, &domain.Comment{UserID: "u1", PostID: "p2", ParentID: parent("c1"), Content: "hi"}); err != domain.ErrCommentParent {
+		t.Fatalf("expected a parent from another article to be rejected, got %v", err)
+	}
+	if err := uc.CreateComment(ctx, &domain.Comment{UserID: "u1", PostID: "p1", ParentID: parent("gone"), Content: "hi"}); err != domain.ErrCommentNotFound {
+		t.Fatalf("expected a deleted parent to be rejected, got %v", err)
+	}
+}
+
+func TestCommentUsecase_EditAndDelete(t *testing.T) {
+	stored := &domain.Comment{ID: "c1", PostID: "p1", UserID: "u1", Content: "first"}
+	var edited *domain.Comment
+	repo := &mocks.CommentRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Comment, error) { c := *stored; return &c, nil },
+		UpdateFn:  func(ctx context.Context, c *domain.Comment) error { edited = c; return nil },
+		DeleteFn: func(ctx context.Context, id string) error {
+			stored.Deleted, stored.Content = true, ""
+			return nil
+		},
+		GetHistoryFn: func(ctx context.Context, id string) ([]domain.CommentRevision, error) {
+			return []domain.CommentRevision{{Content: "first", CreatedAt: 1}}, nil
+		},
+	}
+	uc := NewCommentUsecase(repo, publishedArticles(), nil, nil, nil)
+	uc.now = func() time.Time { return time.Unix(1700000000, 0) }
+	ctx := context.Background()
+
+	if err := uc.UpdateComment(ctx, "u1", &domain.Comment{ID: "c1", Content: "second"}); err != nil {
+		t.Fatal(err)
+	}
+	if edited.EditedAt != 1700000000 {
+		t.Fatalf("an edit must be marked: %+v", edited)
+	}
+	if history, err := uc.GetCommentHistory(ctx, "c1"); err != nil || len(history) != 1 {
+		t.Fatalf("unexpected history %+v: %v", history, err)
+	}
+
+	if err := uc.DeleteComment(ctx, "u1", "c1"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := uc.GetCommentByID(ctx, "c1")
+	if err != nil || got.Content != domain.DeletedCommentContent || got.UserID != "" {
+		t.Fatalf("a deleted comment should read as a placeholder: %+v, %v", got, err)
+	}
+	if err := uc.UpdateComment(ctx, "u1", &domain.Comment{ID: "c1", Content: "third"}); err != domain.ErrCommentNotFound {
+		t.Fatalf("expected deleted comment to be read-only, got %v", err)
+	}
+	if err := uc.DeleteComment(ctx, "u1", "c1"); err != domain.ErrCommentNotFound {
+		t.Fatalf("expected second delete to fail, got %v", err)
+	}
+	if _, err := uc.GetCommentHistory(ctx, "c1"); err != domain.ErrCommentNotFound {
+		t.Fatalf("expected no history for a deleted comment, got %v", err)
+	}
+}
+
+func TestCommentUsecase_PinAndHide(t *testing.T) {
+	comments := map[string]*domain.Comment{
+		"top":   {ID: "top", PostID: "p1", UserID: "reader"},
+		"reply": {ID: "reply", PostID: "p1", UserID: "reader", ParentID: func() *string { s := "top"; return &s }()},
+	}
+	var pinned [2]string
+	repo := &mocks.CommentRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Comment, error) { c := *comments[id]; return &c, nil },
+		SetPinnedFn: func(ctx context.Context, postID, commentID string) error {
+			pinned = [2]string{postID, commentID}
+			comments["top"].Pinned = commentID == "top"
+			return nil
+		},
+		SetHiddenFn: func(ctx context.Context, id string, hidden bool) error {
+			comments[id].Hidden = hidden
+			return nil
+		},
+	}
+	uc := NewCommentUsecase(repo, publishedArticles(), nil, nil, nil)
+	ctx := context.Background()
+
+	if err := uc.PinComment(ctx, "reader", "top", true); err != domain.ErrCommentPermission {
+		t.Fatalf("only the article author may pin, got %v", err)
+	}
+	if err := uc.PinComment(ctx, "writer", "reply", true); err != domain.ErrCommentPinReply {
+		t.Fatalf("expected replies to be unpinnable, got %v", err)
+	}
+	if err := uc.PinComment(ctx, "writer", "top", true); err != nil || pinned != [2]string{"p1", "top"} {
+		t.Fatalf("pin failed: %v %v", pinned, err)
+	}
+	if err := uc.PinComment(ctx, "writer", "top", false); err != nil || pinned != [2]string{"p1", ""} {
+		t.Fatalf("unpin failed: %v %v", pinned, err)
+	}
+
+	if err := uc.HideComment(ctx, "reader", "reply", true); err != domain.ErrCommentPermission {
+		t.Fatalf("only the article author may hide, got %v", err)
+	}
+	if err := uc.HideComment(ctx, "writer", "reply", true); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := uc.GetCommentByID(ctx, "reply"); got.Content != domain.HiddenCommentContent {
+		t.Fatalf("a hidden comment should read as a placeholder: %+v", got)
+	}
+	if _, err := uc.GetCommentHistory(ctx, "reply"); err != domain.ErrCommentNotFound {
+		t.Fatalf("expected no history for a hidden comment, got %v", err)
+	}
+}
+
+func TestCommentUsecase_TreeLeadsWithPinned(t *testing.T) {
+	var listed []domain.CommentListQuery
+	repo := threadRepo(&listed)
+	repo.GetPinnedFn = func(ctx context.Context, postID string) (*domain.CommentNode, error) {
+		return &domain.CommentNode{Comment: domain.Comment{ID: "pin", Pinned: true}}, nil
+	}
+	uc := NewCommentUsecase(repo, publishedArticles(), nil, nil, nil)
+
+	nodes, next, err := uc.GetCommentTree(context.Background(), "p1", domain.CommentThreadQuery{PageSize: 2})
+	if err != nil || len(nodes) != 3 || nodes[0].ID != "pin" || nodes[1].ID != "t1" {
+		t.Fatalf("unexpected first page: %+v %v", nodes, err)
+	}
+	nodes, _, _ = uc.GetCommentTree(context.Background(), "p1", domain.CommentThreadQuery{PageSize: 2, Cursor: next})
+	if len(nodes) != 1 || nodes[0].ID != "t3" {
+		t.Fatalf("the pinned comment belongs on the first page only: %+v", nodes)
+	}
+}
+
+func TestCommentUsecase_Mentions(t *testing.T) {
+	stored := &domain.Comment{ID: "c1", PostID: "p1", UserID: "u1", Mentions: []domain.Mention{{UserID: "id-alice", Username: "alice"}}}
+	var saved *domain.Comment
+	repo := &mocks.CommentRepositoryMock{
+		CreateFn:  func(ctx context.Context, c *domain.Comment) error { saved = c; return nil },
+		UpdateFn:  func(ctx context.Context, c *domain.Comment) error { saved = c; return nil },
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Comment, error) { c := *stored; return &c, nil },
+	}
+	var published [][2][]domain.Mention
+	mentions := &mocks.MentionServiceMock{
+		ResolveFn: func(ctx context.Context, text string) []domain.Mention {
+			var out []domain.Mention
+			for _, name := range domain.ParseMentions(text) {
+				out = append(out, domain.Mention{UserID: "id-" + name, Username: name})
+			}
+			return out
+		},
+		PublishFn: func(ctx context.Context, e domain.MentionEvent, mentions, previous []domain.Mention) {
+			if e.Source != domain.MentionInComment || e.ActorID != "u1" || e.ArticleID != "p1" {
+				t.Fatalf("unexpected mention event %+v", e)
+			}
+			published = append(published, [2][]domain.Mention{mentions, previous})
+		},
+	}
+	uc := NewCommentUsecase(repo, publishedArticles(), nil, mentions, nil)
+	ctx := context.Background()
+
+	if err := uc.CreateComment(ctx, &domain.Comment{UserID: "u1", PostID: "p1", Content: "hey @alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Mentions) != 1 || len(published) != 1 || published[0][1] != nil {
+		t.Fatalf("mentions should be stored and published on create: %+v %+v", saved, published)
+	}
+
+	if err := uc.UpdateComment(ctx, "u1", &domain.Comment{ID: "c1", Content: "hey @alice and @bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Mentions) != 2 || len(published) != 2 || len(published[1][1]) != 1 {
+		t.Fatalf("an edit should publish against the stored mentions: %+v", published)
+	}
+}
+
+func TestCommentUsecase_Notifications(t *testing.T) {
+	parents := map[string]*domain.Comment{
+		"byReader": {ID: "byReader", PostID: "p1", UserID: "reader"},
+		"byWriter": {ID: "byWriter", PostID: "p1", UserID: "writer"},
+	}
+	repo := &mocks.CommentRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Comment, error) { return parents[id], nil },
+		CreateFn:  func(ctx context.Context, c *domain.Comment) error { c.ID = "new"; return nil },
+	}
+	notifications := &mocks.NotificationUsecaseMock{}
+	uc := NewCommentUsecase(repo, publishedArticles(), nil, nil, notifications)
+	ctx := context.Background()
+
+	// A reply notifies the parent's author and the article's author
+	parent := "byReader"
+	if err := uc.CreateComment(ctx, &domain.Comment{PostID: "p1", UserID: "u1", ParentID: &parent, Content: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications.Sent) != 2 {
+		t.Fatalf("expected 2 notifications, got %+v", notifications.Sent)
+	}
+	if n := notifications.Sent[0]; n.UserID != "reader" || n.Type != domain.NotificationCommentReply || n.CommentID != "new" || n.ActorID != "u1" {
+		t.Fatalf("unexpected reply notification: %+v", n)
+	}
+	if n := notifications.Sent[1]; n.UserID != "writer" || n.Type != domain.NotificationArticleComment || n.ArticleID != "p1" {
+		t.Fatalf("unexpected comment notification: %+v", n)
+	}
+
+	// Replying to the article's author notifies them once, as a reply
+	notifications.Sent = nil
+	parent = "byWriter"
+	if err := uc.CreateComment(ctx, &domain.Comment{PostID: "p1", UserID: "u1", ParentID: &parent, Content: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications.Sent) != 1 || notifications.Sent[0].Type != domain.NotificationCommentReply {
+		t.Fatalf("expected a single reply notification, got %+v", notifications.Sent)
+	}
+}
+
+func TestCommentUsecase_CountsComments(t *testing.T) {
+	repo := &mocks.CommentRepositoryMock{}
+	articles := publishedArticles()
+	counts := map[string]int{}
+	articles.IncrementStatFn = func(ctx context.Context, id string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+		if counter != domain.StatComments {
+			t.Fatalf("unexpected counter %s", counter)
+		}
+		counts[id] += delta
+		return &domain.ArticleStats{CommentCount: counts[id]}, nil
+	}
+	uc := NewCommentUsecase(repo, articles, nil, nil, nil)
+
+	c := &domain.Comment{ID: "c1", PostID: "p1", UserID: "u1", Content: "hello"}
+	if err := uc.CreateComment(context.Background(), c); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if counts["p1"] != 1 {
+		t.Fatalf("expected 1 comment, got %d", counts["p1"])
+	}
+
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Comment, error) { return c, nil }
+	if err := uc.DeleteComment(context.Background(), "u1", "c1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	// A delete that lost the race to another one is not counted
+	repo.DeleteFn = func(ctx context.Context, id string) error { return domain.ErrCommentNotFound }
+	if err := uc.DeleteComment(context.Background(), "u1", "c1"); err != domain.ErrCommentNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if counts["p1"] != 0 {
+		t.Fatalf("expected 0 comments, got %d", counts["p1"])
+	}
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"write_base/internal/domain"
+)
+
+type FeedUsecase struct {
+	Feed       domain.IFeedRepository
+	Follows    domain.IFollowRepository
+	TagFollows domain.ITagFollowRepository
+	Now        func() time.Time
+}
+
+var _ domain.IFeedUsecase = (*FeedUsecase)(nil)
+
+func NewFeedUsecase(feed domain.IFeedRepository, follows domain.IFollowRepository, tagFollows domain.ITagFollowRepository) *FeedUsecase {
+	return &FeedUsecase{Feed: feed, Follows: follows, TagFollows: tagFollows, Now: time.Now}
+}
+
+// GetFeed merges recent articles from followed authors, followed and favourite tags and
+// trending content, drops what the reader wrote or has already read, and pages
+// through the result by rank.
+func (u *FeedUsecase) GetFeed(ctx context.Context, userID string, pageSize int, cursor string) ([]domain.FeedItem, string, error) {
+	c, cancel := context.WithTimeout(ctx, domain.DefaultTimeout)
+	defer cancel()
+
+	if userID == "" {
+		return nil, "", domain.ErrUnauthorized
+	}
+	pag := domain.Pagination{PageSize: pageSize}
+	pag.ValidatePagination()
+	var after *domain.FeedCursor
+	if cursor != "" {
+		var err error
+		if after, err = domain.DecodeFeedCursor(cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	items, err := u.candidates(c, userID)
+	if err != nil {
+		return nil, "", domain.ErrInternalServer
+	}
+	ids := make([]string, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	read, err := u.Feed.ReadArticleIDs(c, userID, ids)
+	if err != nil {
+		return nil, "", domain.ErrInternalServer
+	}
+
+	feed := make([]domain.FeedItem, 0, len(items))
+	for id, item := range items {
+		if read[id] || item.Article.AuthorID == userID {
+			continue
+		}
+		feed = append(feed, *item)
+	}
+	domain.SortFeed(feed)
+
+	start := 0
+	if after != nil {
+		for start < len(feed) && !after.After(feed[start]) {
+			start++
+		}
+	}
+	end := min(start+pag.PageSize, len(feed))
+	page := feed[start:end]
+
+	next := ""
+	if end < len(feed) {
+		last := page[len(page)-1]
+		next = domain.FeedCursor{Rank: last.Rank, ID: last.ID()}.Encode()
+	}
+	return page, next, nil
+}
+
+// candidates collects the feed sources by article ID, adding up their boosts.
+func (u *FeedUsecase) candidates(ctx context.Context, userID string) (map[string]*domain.FeedItem, error) {
+	since := u.Now().Add(-domain.FeedWindow)
+
+	following, err := u.Follows.GetFollowing(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	authorIDs := make([]string, 0, len(following))
+	for _, f := range following {
+		authorIDs = append(authorIDs, f.ID)
+	}
+	// Followed tags come first; tags of clapped articles fill in for readers who follow few
+	followedTags, err := u.TagFollows.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	interests, err := u.Feed.TagInterests(ctx, userID, domain.FeedInterestTags)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(followedTags)+len(interests))
+	seenTag := map[string]bool{}
+	for _, f := range followedTags {
+		if !seenTag[f.TagName] {
+			seenTag[f.TagName] = true
+			tags = append(tags, f.TagName)
+		}
+	}
+	for _, t := range interests {
+		if !seenTag[t] {
+			seenTag[t] = true
+			tags = append(tags, t)
+		}
+	}
+
+	sources := []struct {
+		reason string
+		boost  time.Duration
+		load   func() ([]domain.Article, error)
+	}{
+		{domain.FeedReasonFollowing, domain.FeedAuthorBoost, func() ([]domain.Article, error) {
+			return u.Feed.RecentByAuthors(ctx, authorIDs, since, domain.FeedSourceLimit)
+		}},
+		{domain.FeedReasonTag, domain.FeedTagBoost, func() ([]domain.Article, error) {
+			return u.Feed.RecentByTags(ctx, tags, since, domain.FeedSourceLimit)
+		}},
+		{domain.FeedReasonTrending, domain.FeedTrendingBoost, func() ([]domain.Article, error) {
+			return u.Feed.TopTrending(ctx, since, domain.FeedSourceLimit)
+		}},
+	}
+
+	items := map[string]*domain.FeedItem{}
+	for _, src := range sources {
+		articles, err := src.load()
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range articles {
+			if a.Timestamps.PublishedAt == nil {
+				continue
+			}
+			item, ok := items[a.ID]
+			if !ok {
+				item = &domain.FeedItem{Article: a, Rank: a.Timestamps.PublishedAt.UTC()}
+				items[a.ID] = item
+			}
+			item.Rank = item.Rank.Add(src.boost)
+			item.Reasons = append(item.Reasons, src.reason)
+		}
+	}
+	return items, nil
+}
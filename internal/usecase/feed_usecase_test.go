@@ -0,0 +1,124 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+var feedNow = time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+func feedArticle(id, author string, age time.Duration) domain.Article {
+	at := feedNow.Add(-age)
+	return domain.Article{ID: id, AuthorID: author, Status: domain.StatusPublished, Timestamps: domain.ArticleTimes{PublishedAt: &at}}
+}
+
+func feedIDs(items []domain.FeedItem) []string {
+	ids := make([]string, len(items))
+	for i, it := range items {
+		ids[i] = it.Article.ID
+	}
+	return ids
+}
+
+func newFeedUC(feed *mocks.FeedRepositoryMock) *usecase.FeedUsecase {
+	follows := &mocks.FollowRepositoryMock{
+		GetFollowingFn: func(ctx context.Context, userID string) ([]*domain.User, error) {
+			return []*domain.User{{ID: "author"}}, nil
+		},
+	}
+	tagFollows := &mocks.TagFollowRepositoryMock{
+		ListByUserFn: func(ctx context.Context, userID string) ([]domain.TagFollow, error) {
+			return []domain.TagFollow{{UserID: userID, TagID: "t1", TagName: "rust"}, {UserID: userID, TagID: "t2", TagName: "go"}}, nil
+		},
+	}
+	uc := usecase.NewFeedUsecase(feed, follows, tagFollows)
+	uc.Now = func() time.Time { return feedNow }
+	return uc
+}
+
+func TestGetFeed_MergesRanksAndFilters(t *testing.T) {
+	var gotAuthors, gotTags []string
+	var gotSince time.Time
+	feed := &mocks.FeedRepositoryMock{
+		RecentByAuthorsFn: func(ctx context.Context, authorIDs []string, since time.Time, limit int) ([]domain.Article, error) {
+			gotAuthors, gotSince = authorIDs, since
+			return []domain.Article{feedArticle("followed-old", "author", 40*time.Hour), feedArticle("both", "author", 12*time.Hour)}, nil
+		},
+		TagInterestsFn: func(ctx context.Context, userID string, limit int) ([]string, error) {
+			return []string{"go"}, nil
+		},
+		RecentByTagsFn: func(ctx context.Context, tags []string, since time.Time, limit int) ([]domain.Article, error) {
+			gotTags = tags
+			return []domain.Article{feedArticle("both", "author", 12*time.Hour), feedArticle("mine", "reader", time.Hour)}, nil
+		},
+		TopTrendingFn: func(ctx context.Context, since time.Time, limit int) ([]domain.Article, error) {
+			return []domain.Article{feedArticle("trending-new", "other", time.Hour), feedArticle("seen", "other", time.Hour)}, nil
+		},
+		ReadArticleIDsFn: func(ctx context.Context, userID string, ids []string) (map[string]bool, error) {
+			require.ElementsMatch(t, []string{"followed-old", "both", "mine", "trending-new", "seen"}, ids)
+			return map[string]bool{"seen": true}, nil
+		},
+	}
+	items, next, err := newFeedUC(feed).GetFeed(context.Background(), "reader", 10, "")
+	require.NoError(t, err)
+	require.Empty(t, next)
+	require.Equal(t, []string{"both", "trending-new", "followed-old"}, feedIDs(items))
+	require.Equal(t, []string{domain.FeedReasonFollowing, domain.FeedReasonTag}, items[0].Reasons)
+	require.Equal(t, []string{"author"}, gotAuthors)
+	require.Equal(t, []string{"rust", "go"}, gotTags)
+	require.Equal(t, feedNow.Add(-domain.FeedWindow), gotSince)
+}
+
+func TestGetFeed_CursorPages(t *testing.T) {
+	var articles []domain.Article
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		articles = append(articles, feedArticle(id, "other", time.Duration(i)*time.Hour))
+	}
+	feed := &mocks.FeedRepositoryMock{
+		TopTrendingFn: func(ctx context.Context, since time.Time, limit int) ([]domain.Article, error) {
+			return articles, nil
+		},
+	}
+	uc := newFeedUC(feed)
+	ctx := context.Background()
+
+	var seen []string
+	cursor := ""
+	for {
+		items, next, err := uc.GetFeed(ctx, "reader", 2, cursor)
+		require.NoError(t, err)
+		seen = append(seen, feedIDs(items)...)
+		if next == "" {
+			break
+		}
+		// A new article published meanwhile must not shift later pages
+		newest := feedArticle("fresh", "other", -time.Hour)
+		articles = append(articles, newest)
+		cursor = next
+	}
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, seen)
+
+	_, _, err := uc.GetFeed(ctx, "reader", 2, "not-a-cursor")
+	require.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestGetFeed_Errors(t *testing.T) {
+	ctx := context.Background()
+	_, _, err := newFeedUC(&mocks.FeedRepositoryMock{}).GetFeed(ctx, "", 10, "")
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	feed := &mocks.FeedRepositoryMock{
+		TopTrendingFn: func(ctx context.Context, since time.Time, limit int) ([]domain.Article, error) {
+			return nil, errors.New("db down")
+		},
+	}
+	_, _, err = newFeedUC(feed).GetFeed(ctx, "reader", 10, "")
+	require.ErrorIs(t, err, domain.ErrInternalServer)
+}
@@ -6,18 +6,38 @@ import (
 )
 
 type FollowService struct {
-	repo domain.IFollowRepository
+	repo          domain.IFollowRepository
+	notifications domain.INotificationUsecase
 }
 
-func NewFollowService(repo domain.IFollowRepository) *FollowService {
-	return &FollowService{repo: repo}
+func NewFollowService(repo domain.IFollowRepository, notifications domain.INotificationUsecase) *FollowService {
+	return &FollowService{repo: repo, notifications: notifications}
 }
 
 func (s *FollowService) FollowUser(ctx context.Context, followerID, followeeID string) error {
-	return s.repo.FollowUser(ctx, followerID, followeeID)
+	if followerID == "" {
+		return domain.ErrUnauthorized
+	}
+	if followerID == followeeID {
+		return domain.ErrCannotFollowSelf
+	}
+	if err := s.repo.FollowUser(ctx, followerID, followeeID); err != nil {
+		return err
+	}
+	if s.notifications != nil {
+		_ = s.notifications.Notify(ctx, domain.Notification{
+			UserID:  followeeID,
+			Type:    domain.NotificationNewFollower,
+			ActorID: followerID,
+		})
+	}
+	return nil
 }
 
 func (s *FollowService) UnfollowUser(ctx context.Context, followerID, followeeID string) error {
+	if followerID == "" {
+		return domain.ErrUnauthorized
+	}
 	return s.repo.UnfollowUser(ctx, followerID, followeeID)
 }
 
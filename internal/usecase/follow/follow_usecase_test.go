@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 	"write_base/internal/domain"
+	"write_base/internal/mocks"
 )
 
 type followRepoMock struct {
@@ -38,10 +39,16 @@ func TestFollowService_Basic(t *testing.T) {
 		GetFollowingFn: func(ctx context.Context, u string) ([]*domain.User, error) { return []*domain.User{{ID: "u3"}}, nil },
 		IsFollowingFn:  func(ctx context.Context, f, e string) (bool, error) { return true, nil },
 	}
-	s := NewFollowService(repo)
+	s := NewFollowService(repo, nil)
 	if err := s.FollowUser(context.Background(), "u1", "u2"); err != nil {
 		t.Fatal(err)
 	}
+	if err := s.FollowUser(context.Background(), "u1", "u1"); err != domain.ErrCannotFollowSelf {
+		t.Fatalf("expected self-follow to be rejected, got %v", err)
+	}
+	if err := s.FollowUser(context.Background(), "", "u2"); err != domain.ErrUnauthorized {
+		t.Fatalf("expected unauthorized without a user, got %v", err)
+	}
 	if err := s.UnfollowUser(context.Background(), "u1", "u2"); err != nil {
 		t.Fatal(err)
 	}
@@ -55,3 +62,26 @@ func TestFollowService_Basic(t *testing.T) {
 		t.Fatalf("isFollowing bad")
 	}
 }
+
+func TestFollowService_NotifiesFollowee(t *testing.T) {
+	repo := &followRepoMock{FollowUserFn: func(ctx context.Context, f, e string) error { return nil }}
+	notifications := &mocks.NotificationUsecaseMock{}
+	s := NewFollowService(repo, notifications)
+	if err := s.FollowUser(context.Background(), "u1", "u2"); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications.Sent) != 1 {
+		t.Fatalf("expected one notification, got %+v", notifications.Sent)
+	}
+	if n := notifications.Sent[0]; n.UserID != "u2" || n.ActorID != "u1" || n.Type != domain.NotificationNewFollower {
+		t.Fatalf("unexpected notification: %+v", n)
+	}
+
+	repo.FollowUserFn = func(ctx context.Context, f, e string) error { return domain.ErrAlreadyFollowing }
+	if err := s.FollowUser(context.Background(), "u1", "u2"); err == nil {
+		t.Fatal("expected the repository error")
+	}
+	if len(notifications.Sent) != 1 {
+		t.Fatalf("a failed follow must not notify")
+	}
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+	"write_base/internal/domain"
+)
+
+type MentionService struct {
+	users domain.IUserRepository
+	now   func() time.Time
+
+	mu        sync.RWMutex
+	listeners []domain.IMentionListener
+}
+
+func NewMentionService(users domain.IUserRepository, listeners ...domain.IMentionListener) *MentionService {
+	return &MentionService{users: users, now: time.Now, listeners: listeners}
+}
+
+func (s *MentionService) Subscribe(listener domain.IMentionListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// resolver looks each username up once, however often it is mentioned.
+type resolver struct {
+	s     *MentionService
+	known map[string]*domain.Mention
+}
+
+func (s *MentionService) resolver() *resolver {
+	return &resolver{s: s, known: map[string]*domain.Mention{}}
+}
+
+// resolve skips usernames that do not exist or cannot be looked up: a broken
+// mention stays plain text rather than failing the save.
+func (r *resolver) resolve(ctx context.Context, text string) []domain.Mention {
+	var out []domain.Mention
+	for _, name := range domain.ParseMentions(text) {
+		m, ok := r.known[name]
+		if !ok {
+			if len(r.known) >= domain.MaxMentions {
+				break
+			}
+			if user, err := r.s.users.GetByUsername(ctx, name); err == nil && user != nil {
+				m = &domain.Mention{UserID: user.ID, Username: name}
+			}
+			r.known[name] = m
+		}
+		if m != nil {
+			out = append(out, *m)
+		}
+	}
+	return out
+}
+
+func (s *MentionService) Resolve(ctx context.Context, text string) []domain.Mention {
+	return s.resolver().resolve(ctx, text)
+}
+
+func (s *MentionService) ResolveArticle(ctx context.Context, article *domain.Article) {
+	r := s.resolver()
+	for i := range article.ContentBlocks {
+		if p := article.ContentBlocks[i].Content.Paragraph; p != nil {
+			p.Mentions = r.resolve(ctx, p.Text)
+		}
+	}
+}
+
+func (s *MentionService) Publish(ctx context.Context, event domain.MentionEvent, mentions, previous []domain.Mention) {
+	seen := map[string]bool{event.ActorID: true}
+	for _, m := range previous {
+		seen[m.UserID] = true
+	}
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = s.now()
+	}
+	for _, m := range mentions {
+		if seen[m.UserID] {
+			continue
+		}
+		seen[m.UserID] = true
+		event.UserID, event.Username = m.UserID, m.Username
+		for _, l := range listeners {
+			l.OnMention(ctx, event)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"write_base/internal/domain"
+)
+
+// fakeUsers only implements the username lookup.
+type fakeUsers struct {
+	domain.IUserRepository
+	ids     map[string]string
+	lookups int
+}
+
+func (f *fakeUsers) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	f.lookups++
+	if id, ok := f.ids[username]; ok {
+		return &domain.User{ID: id, Username: username}, nil
+	}
+	return nil, errors.New("not found")
+}
+
+type recorder []domain.MentionEvent
+
+func (r *recorder) OnMention(ctx context.Context, e domain.MentionEvent) { *r = append(*r, e) }
+
+func TestMentionService_Resolve(t *testing.T) {
+	users := &fakeUsers{ids: map[string]string{"alice": "u1", "bob": "u2"}}
+	s := NewMentionService(users)
+	ctx := context.Background()
+
+	got := s.Resolve(ctx, "hi @alice, @ghost and @bob")
+	if len(got) != 2 || got[0] != (domain.Mention{UserID: "u1", Username: "alice"}) || got[1].UserID != "u2" {
+		t.Fatalf("unexpected mentions %v", got)
+	}
+
+	users.lookups = 0
+	article := &domain.Article{ContentBlocks: []domain.ContentBlock{
+		{Type: domain.BlockParagraph, Content: domain.BlockContent{Paragraph: &domain.ParagraphContent{Text: "@alice"}}},
+		{Type: domain.BlockCode, Content: domain.BlockContent{Code: &domain.CodeContent{Code: "@bob"}}},
+		{Type: domain.BlockParagraph, Content: domain.BlockContent{Paragraph: &domain.ParagraphContent{Text: "@alice again, and @ghost"}}},
+	}}
+	s.ResolveArticle(ctx, article)
+	if m := article.ContentBlocks[2].Content.Paragraph.Mentions; len(m) != 1 || m[0].UserID != "u1" {
+		t.Fatalf("unexpected paragraph mentions %v", m)
+	}
+	if users.lookups != 2 {
+		t.Fatalf("each username should be looked up once per article, got %d lookups", users.lookups)
+	}
+}
+
+func TestMentionService_Publish(t *testing.T) {
+	var events recorder
+	s := NewMentionService(&fakeUsers{})
+	s.Subscribe(&events)
+
+	mentions := []domain.Mention{{UserID: "u1", Username: "alice"}, {UserID: "u2", Username: "bob"}, {UserID: "author", Username: "me"}}
+	s.Publish(context.Background(), domain.MentionEvent{Source: domain.MentionInComment, SourceID: "c1", ActorID: "author"}, mentions, []domain.Mention{{UserID: "u1"}})
+
+	if len(events) != 1 || events[0].UserID != "u2" || events[0].SourceID != "c1" || events[0].CreatedAt.IsZero() {
+		t.Fatalf("only bob is newly mentioned by someone else: %+v", events)
+	}
+}
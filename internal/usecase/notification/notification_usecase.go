@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"write_base/internal/domain"
+)
+
+type NotificationUsecase struct {
+	repo   domain.INotificationRepository
+	users  domain.IUserRepository
+	hub    domain.INotificationHub
+	broker domain.INotificationBroker
+	now    func() time.Time
+}
+
+func NewNotificationUsecase(repo domain.INotificationRepository, users domain.IUserRepository, hub domain.INotificationHub, broker domain.INotificationBroker) *NotificationUsecase {
+	return &NotificationUsecase{repo: repo, users: users, hub: hub, broker: broker, now: time.Now}
+}
+
+// messages are the default texts; %s is the actor's username.
+var messages = map[domain.NotificationType]string{
+	domain.NotificationNewFollower:     "%s started following you",
+	domain.NotificationArticleComment:  "%s commented on your article",
+	domain.NotificationCommentReply:    "%s replied to your comment",
+	domain.NotificationMention:         "%s mentioned you",
+	domain.NotificationArticleApproved: "Your article was approved after review",
+	domain.NotificationArticleRejected: "Your article was unpublished by a moderator",
+	domain.NotificationReportResolved:  "Your report was resolved",
+}
+
+func (uc *NotificationUsecase) message(ctx context.Context, n domain.Notification) string {
+	text := messages[n.Type]
+	if !strings.Contains(text, "%s") {
+		return text
+	}
+	name := "Someone"
+	if n.ActorID != "" && uc.users != nil {
+		if actor, err := uc.users.GetByID(ctx, n.ActorID); err == nil && actor.Username != "" {
+			name = actor.Username
+		}
+	}
+	return fmt.Sprintf(text, name)
+}
+
+func (uc *NotificationUsecase) Notify(ctx context.Context, n domain.Notification) error {
+	if !n.Type.Valid() {
+		return domain.ErrInvalidNotificationType
+	}
+	if n.UserID == "" || n.UserID == n.ActorID {
+		return nil
+	}
+	disabled, err := uc.repo.GetDisabledTypes(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+	for _, t := range disabled {
+		if t == n.Type {
+			return nil
+		}
+	}
+	if n.Message == "" {
+		n.Message = uc.message(ctx, n)
+	}
+	n.ID, n.Read = "", false
+	n.CreatedAt = uc.now().Unix()
+	if err := uc.repo.Create(ctx, &n); err != nil {
+		return err
+	}
+	// Streams catch up from storage on reconnect, so a lost publish is not fatal
+	if uc.broker != nil {
+		_ = uc.broker.Publish(ctx, n)
+	}
+	return nil
+}
+
+// OnMention makes the service a mention listener.
+func (uc *NotificationUsecase) OnMention(ctx context.Context, e domain.MentionEvent) {
+	n := domain.Notification{
+		UserID:    e.UserID,
+		Type:      domain.NotificationMention,
+		ActorID:   e.ActorID,
+		ArticleID: e.ArticleID,
+	}
+	if e.Source == domain.MentionInComment {
+		n.CommentID = e.SourceID
+	}
+	_ = uc.Notify(ctx, n)
+}
+
+func (uc *NotificationUsecase) List(ctx context.Context, userID string, unreadOnly bool, pageSize int, cursor string) ([]domain.Notification, string, error) {
+	if userID == "" {
+		return nil, "", domain.ErrUnauthorized
+	}
+	if pageSize <= 0 {
+		pageSize = domain.DefaultNotificationPageSize
+	}
+	if pageSize > domain.MaxNotificationPageSize {
+		pageSize = domain.MaxNotificationPageSize
+	}
+	query := domain.NotificationQuery{UnreadOnly: unreadOnly, Limit: pageSize + 1}
+	if cursor != "" {
+		after, err := domain.DecodeNotificationCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		query.After = after
+	}
+	items, err := uc.repo.List(ctx, userID, query)
+	if err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if len(items) > pageSize {
+		items = items[:pageSize]
+		last := items[len(items)-1]
+		next = domain.NotificationCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	return items, next, nil
+}
+
+func (uc *NotificationUsecase) UnreadCount(ctx context.Context, userID string) (int, error) {
+	if userID == "" {
+		return 0, domain.ErrUnauthorized
+	}
+	return uc.repo.CountUnread(ctx, userID)
+}
+
+func (uc *NotificationUsecase) MarkRead(ctx context.Context, userID, notificationID string) error {
+	if userID == "" {
+		return domain.ErrUnauthorized
+	}
+	return uc.repo.MarkRead(ctx, userID, notificationID)
+}
+
+func (uc *NotificationUsecase) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	if userID == "" {
+		return 0, domain.ErrUnauthorized
+	}
+	return uc.repo.MarkAllRead(ctx, userID)
+}
+
+func (uc *NotificationUsecase) GetPreferences(ctx context.Context, userID string) (domain.NotificationPreferences, error) {
+	if userID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	disabled, err := uc.repo.GetDisabledTypes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	prefs := domain.NotificationPreferences{}
+	for _, t := range domain.NotificationTypes {
+		prefs[t] = true
+	}
+	for _, t := range disabled {
+		if t.Valid() {
+			prefs[t] = false
+		}
+	}
+	return prefs, nil
+}
+
+func (uc *NotificationUsecase) UpdatePreferences(ctx context.Context, userID string, changes domain.NotificationPreferences) (domain.NotificationPreferences, error) {
+	for t := range changes {
+		if !t.Valid() {
+			return nil, domain.ErrInvalidNotificationType
+		}
+	}
+	prefs, err := uc.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var disabled []domain.NotificationType
+	for _, t := range domain.NotificationTypes {
+		if on, ok := changes[t]; ok {
+			prefs[t] = on
+		}
+		if !prefs[t] {
+			disabled = append(disabled, t)
+		}
+	}
+	if err := uc.repo.SetDisabledTypes(ctx, userID, disabled); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+func (uc *NotificationUsecase) Stream(ctx context.Context, userID, lastEventID string) (<-chan domain.Notification, error) {
+	if userID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	if uc.hub == nil {
+		return nil, domain.ErrNotificationStreamUnavailable
+	}
+	// Subscribe before replaying so nothing stored in between is missed
+	live, unsubscribe := uc.hub.Subscribe(userID)
+	var missed []domain.Notification
+	if lastEventID != "" {
+		var err error
+		missed, err = uc.repo.ListAfter(ctx, userID, lastEventID, domain.MaxNotificationPageSize)
+		if err != nil {
+			unsubscribe()
+			return nil, err
+		}
+	}
+
+	out := make(chan domain.Notification)
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+		replayed := make(map[string]bool, len(missed))
+		for _, n := range missed {
+			replayed[n.ID] = true
+			select {
+			case out <- n:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for {
+			select {
+			case n, ok := <-live:
+				if !ok {
+					return
+				}
+				if replayed[n.ID] {
+					continue
+				}
+				select {
+				case out <- n:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+)
+
+// fakeUsers only implements the ID lookup used for actor names.
+type fakeUsers struct {
+	domain.IUserRepository
+	names map[string]string
+}
+
+func (f *fakeUsers) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return &domain.User{ID: id, Username: f.names[id]}, nil
+}
+
+func TestNotificationUsecase_Notify(t *testing.T) {
+	var stored []domain.Notification
+	repo := &mocks.NotificationRepositoryMock{
+		CreateFn: func(ctx context.Context, n *domain.Notification) error { stored = append(stored, *n); return nil },
+		GetDisabledTypesFn: func(ctx context.Context, userID string) ([]domain.NotificationType, error) {
+			if userID == "quiet" {
+				return []domain.NotificationType{domain.NotificationNewFollower}, nil
+			}
+			return nil, nil
+		},
+	}
+	users := &fakeUsers{names: map[string]string{"u2": "bob"}}
+	uc := NewNotificationUsecase(repo, users, nil, nil)
+	uc.now = func() time.Time { return time.Unix(100, 0) }
+	ctx := context.Background()
+
+	if err := uc.Notify(ctx, domain.Notification{UserID: "u1", Type: "bogus"}); err != domain.ErrInvalidNotificationType {
+		t.Fatalf("expected invalid type, got %v", err)
+	}
+	// Acting on your own content, or a type turned off, stores nothing
+	_ = uc.Notify(ctx, domain.Notification{UserID: "u1", ActorID: "u1", Type: domain.NotificationNewFollower})
+	_ = uc.Notify(ctx, domain.Notification{UserID: "quiet", ActorID: "u2", Type: domain.NotificationNewFollower})
+	if len(stored) != 0 {
+		t.Fatalf("expected nothing stored, got %+v", stored)
+	}
+
+	if err := uc.Notify(ctx, domain.Notification{UserID: "u1", ActorID: "u2", Type: domain.NotificationNewFollower}); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.Notify(ctx, domain.Notification{UserID: "u1", Type: domain.NotificationReportResolved}); err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 {
+		t.Fatalf("expected 2 stored, got %+v", stored)
+	}
+	if n := stored[0]; n.Message != "bob started following you" || n.CreatedAt != 100 || n.Read {
+		t.Fatalf("unexpected notification: %+v", n)
+	}
+	if stored[1].Message != "Your report was resolved" {
+		t.Fatalf("unexpected message: %q", stored[1].Message)
+	}
+}
+
+func TestNotificationUsecase_OnMention(t *testing.T) {
+	var stored []domain.Notification
+	repo := &mocks.NotificationRepositoryMock{
+		CreateFn: func(ctx context.Context, n *domain.Notification) error { stored = append(stored, *n); return nil },
+	}
+	uc := NewNotificationUsecase(repo, nil, nil, nil)
+	uc.OnMention(context.Background(), domain.MentionEvent{
+		Source: domain.MentionInComment, SourceID: "c1", ArticleID: "a1", ActorID: "u2", UserID: "u1",
+	})
+	if len(stored) != 1 {
+		t.Fatalf("expected one notification, got %+v", stored)
+	}
+	if n := stored[0]; n.Type != domain.NotificationMention || n.CommentID != "c1" || n.ArticleID != "a1" || n.Message != "Someone mentioned you" {
+		t.Fatalf("unexpected notification: %+v", n)
+	}
+}
+
+func TestNotificationUsecase_ListPages(t *testing.T) {
+	var query domain.NotificationQuery
+	repo := &mocks.NotificationRepositoryMock{
+		ListFn: func(ctx context.Context, userID string, q domain.NotificationQuery) ([]domain.Notification, error) {
+			query = q
+			return []domain.Notification{{ID: "n3", CreatedAt: 3}, {ID: "n2", CreatedAt: 2}, {ID: "n1", CreatedAt: 1}}, nil
+		},
+	}
+	uc := NewNotificationUsecase(repo, nil, nil, nil)
+	ctx := context.Background()
+
+	items, next, err := uc.List(ctx, "u1", true, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || query.Limit != 3 || !query.UnreadOnly {
+		t.Fatalf("unexpected page: %+v query %+v", items, query)
+	}
+	cursor, err := domain.DecodeNotificationCursor(next)
+	if err != nil || cursor.ID != "n2" || cursor.CreatedAt != 2 {
+		t.Fatalf("unexpected cursor %+v (%v)", cursor, err)
+	}
+
+	if _, _, err := uc.List(ctx, "u1", false, 2, next); err != nil || query.After == nil || query.After.ID != "n2" {
+		t.Fatalf("cursor not passed on: %+v (%v)", query, err)
+	}
+	if _, _, err := uc.List(ctx, "u1", false, 0, "!!"); err != domain.ErrInvalidCursor {
+		t.Fatalf("expected invalid cursor, got %v", err)
+	}
+	if _, _, err := uc.List(ctx, "", false, 0, ""); err != domain.ErrUnauthorized {
+		t.Fatalf("expected unauthorized, got %v", err)
+	}
+	_, _, _ = uc.List(ctx, "u1", false, 0, "")
+	if query.Limit != domain.DefaultNotificationPageSize+1 {
+		t.Fatalf("expected default page size, got %d", query.Limit)
+	}
+}
+
+func TestNotificationUsecase_Preferences(t *testing.T) {
+	var disabled []domain.NotificationType
+	repo := &mocks.NotificationRepositoryMock{
+		GetDisabledTypesFn: func(ctx context.Context, userID string) ([]domain.NotificationType, error) { return disabled, nil },
+		SetDisabledTypesFn: func(ctx context.Context, userID string, types []domain.NotificationType) error {
+			disabled = types
+			return nil
+		},
+	}
+	uc := NewNotificationUsecase(repo, nil, nil, nil)
+	ctx := context.Background()
+
+	prefs, err := uc.GetPreferences(ctx, "u1")
+	if err != nil || len(prefs) != len(domain.NotificationTypes) || !prefs[domain.NotificationMention] {
+		t.Fatalf("expected everything on by default, got %v (%v)", prefs, err)
+	}
+	prefs, err = uc.UpdatePreferences(ctx, "u1", domain.NotificationPreferences{domain.NotificationMention: false})
+	if err != nil || prefs[domain.NotificationMention] || !prefs[domain.NotificationNewFollower] {
+		t.Fatalf("unexpected preferences %v (%v)", prefs, err)
+	}
+	if len(disabled) != 1 || disabled[0] != domain.NotificationMention {
+		t.Fatalf("unexpected stored types %v", disabled)
+	}
+	if _, err := uc.UpdatePreferences(ctx, "u1", domain.NotificationPreferences{"bogus": true}); err != domain.ErrInvalidNotificationType {
+		t.Fatalf("expected invalid type, got %v", err)
+	}
+}
+
+// fakeHub hands out one subscription the test can feed.
+type fakeHub struct {
+	live         chan domain.Notification
+	unsubscribed bool
+}
+
+func (h *fakeHub) Subscribe(userID string) (<-chan domain.Notification, func()) {
+	return h.live, func() { h.unsubscribed = true }
+}
+func (h *fakeHub) Deliver(n domain.Notification) { h.live <- n }
+
+type fakeBroker struct{ published []domain.Notification }
+
+func (b *fakeBroker) Publish(ctx context.Context, n domain.Notification) error {
+	b.published = append(b.published, n)
+	return nil
+}
+func (b *fakeBroker) Run(ctx context.Context, deliver func(domain.Notification)) error { return nil }
+
+func TestNotificationUsecase_NotifyPublishes(t *testing.T) {
+	repo := &mocks.NotificationRepositoryMock{
+		CreateFn: func(ctx context.Context, n *domain.Notification) error { n.ID = "n1"; return nil },
+	}
+	broker := &fakeBroker{}
+	uc := NewNotificationUsecase(repo, nil, nil, broker)
+	if err := uc.Notify(context.Background(), domain.Notification{UserID: "u1", Type: domain.NotificationReportResolved}); err != nil {
+		t.Fatal(err)
+	}
+	if len(broker.published) != 1 || broker.published[0].ID != "n1" {
+		t.Fatalf("expected the stored notification to be published, got %+v", broker.published)
+	}
+}
+
+func TestNotificationUsecase_StreamReplaysThenDeliversLive(t *testing.T) {
+	var afterID string
+	repo := &mocks.NotificationRepositoryMock{
+		ListAfterFn: func(ctx context.Context, userID, lastID string, limit int) ([]domain.Notification, error) {
+			afterID = lastID
+			return []domain.Notification{{ID: "n2"}, {ID: "n3"}}, nil
+		},
+	}
+	hub := &fakeHub{live: make(chan domain.Notification, 4)}
+	uc := NewNotificationUsecase(repo, nil, hub, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// n3 was stored while the replay was read, so it arrives both ways
+	hub.live <- domain.Notification{ID: "n3"}
+	hub.live <- domain.Notification{ID: "n4"}
+	events, err := uc.Stream(ctx, "u1", "n1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for len(got) < 3 {
+		select {
+		case n := <-events:
+			got = append(got, n.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after %v", got)
+		}
+	}
+	if afterID != "n1" || strings.Join(got, ",") != "n2,n3,n4" {
+		t.Fatalf("unexpected events %v after %q", got, afterID)
+	}
+
+	cancel()
+	for range events {
+	}
+	if !hub.unsubscribed {
+		t.Fatal("expected the hub subscription to end with the stream")
+	}
+}
+
+func TestNotificationUsecase_StreamErrors(t *testing.T) {
+	repo := &mocks.NotificationRepositoryMock{
+		ListAfterFn: func(ctx context.Context, userID, lastID string, limit int) ([]domain.Notification, error) {
+			return nil, domain.ErrInvalidCursor
+		},
+	}
+	ctx := context.Background()
+	if _, err := NewNotificationUsecase(repo, nil, nil, nil).Stream(ctx, "u1", ""); err != domain.ErrNotificationStreamUnavailable {
+		t.Fatalf("expected unavailable without a hub, got %v", err)
+	}
+	hub := &fakeHub{live: make(chan domain.Notification)}
+	uc := NewNotificationUsecase(repo, nil, hub, nil)
+	if _, err := uc.Stream(ctx, "", ""); err != domain.ErrUnauthorized {
+		t.Fatalf("expected unauthorized, got %v", err)
+	}
+	if _, err := uc.Stream(ctx, "u1", "bad"); err != domain.ErrInvalidCursor || !hub.unsubscribed {
+		t.Fatalf("expected invalid cursor and no subscription left, got %v", err)
+	}
+}
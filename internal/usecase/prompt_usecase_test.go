@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptUsecase_Render_FallsBackToBuiltin(t *testing.T) {
+	uc := usecase.NewPromptTemplateUsecase(&mocks.PromptTemplateRepositoryMock{}, &mocks.UtilsMock{})
+
+	out, err := uc.Render(context.Background(), domain.PromptAISuggestions, map[string]interface{}{"Prompt": "go testing"})
+	require.NoError(t, err)
+	require.Equal(t, 0, out.Version)
+	require.Contains(t, out.Text, `Given the topic or keywords: "go testing"`)
+}
+
+func TestPromptUsecase_Render_UsesLatestStoredVersion(t *testing.T) {
+	repo := &mocks.PromptTemplateRepositoryMock{
+		GetLatestFn: func(ctx context.Context, name string) (*domain.PromptTemplate, error) {
+			return &domain.PromptTemplate{Name: name, Version: 3, Body: "Write about {{.Prompt}} in a playful tone."}, nil
+		},
+	}
+	uc := usecase.NewPromptTemplateUsecase(repo, &mocks.UtilsMock{})
+
+	out, err := uc.Render(context.Background(), domain.PromptAIGenerateContent, map[string]interface{}{"Prompt": "gophers"})
+	require.NoError(t, err)
+	require.Equal(t, 3, out.Version)
+	require.Equal(t, "Write about gophers in a playful tone.", out.Text)
+}
+
+func TestPromptUsecase_Render_UnknownTemplate(t *testing.T) {
+	uc := usecase.NewPromptTemplateUsecase(&mocks.PromptTemplateRepositoryMock{}, &mocks.UtilsMock{})
+
+	_, err := uc.Render(context.Background(), "nope", nil)
+	require.Equal(t, domain.ErrPromptTemplateNotFound, err)
+}
+
+func TestPromptUsecase_UpdateTemplate_BumpsVersion(t *testing.T) {
+	var stored *domain.PromptTemplate
+	repo := &mocks.PromptTemplateRepositoryMock{
+		GetLatestFn: func(ctx context.Context, name string) (*domain.PromptTemplate, error) {
+			return &domain.PromptTemplate{Name: name, Version: 1, Body: "old {{.Prompt}}", Description: "desc"}, nil
+		},
+		CreateFn: func(ctx context.Context, tpl *domain.PromptTemplate) error { stored = tpl; return nil },
+	}
+	uc := usecase.NewPromptTemplateUsecase(repo, &mocks.UtilsMock{})
+
+	tpl, err := uc.UpdateTemplate(context.Background(), "admin-1", domain.PromptAIGenerateContent, "new {{.Prompt}}", "")
+	require.NoError(t, err)
+	require.Equal(t, 2, tpl.Version)
+	require.Equal(t, "desc", tpl.Description)
+	require.Equal(t, "admin-1", stored.UpdatedBy)
+}
+
+func TestPromptUsecase_UpdateTemplate_RejectsInvalidBodies(t *testing.T) {
+	repo := &mocks.PromptTemplateRepositoryMock{
+		CreateFn: func(ctx context.Context, tpl *domain.PromptTemplate) error {
+			t.Fatal("invalid template must not be stored")
+			return nil
+		},
+	}
+	uc := usecase.NewPromptTemplateUsecase(repo, &mocks.UtilsMock{})
+
+	// syntax error
+	_, err := uc.UpdateTemplate(context.Background(), "admin-1", domain.PromptAISuggestions, "{{.Prompt", "")
+	require.Equal(t, domain.ErrInvalidPromptTemplate, err)
+	// unknown variable
+	_, err = uc.UpdateTemplate(context.Background(), "admin-1", domain.PromptAISuggestions, "{{.Topic}}", "")
+	require.Equal(t, domain.ErrInvalidPromptTemplate, err)
+}
+
+func TestPromptUsecase_PreviewTemplate_UsesSampleVariables(t *testing.T) {
+	uc := usecase.NewPromptTemplateUsecase(&mocks.PromptTemplateRepositoryMock{}, &mocks.UtilsMock{})
+
+	out, err := uc.PreviewTemplate(context.Background(), domain.PromptArticleEditContent, "", nil)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out.Text, "You are an article assistant."))
+	require.Contains(t, out.Text, `title: "Getting started with Go generics"`)
+
+	out, err = uc.PreviewTemplate(context.Background(), domain.PromptAISuggestions, "Ideas for {{.Prompt}}", map[string]interface{}{"Prompt": "rust"})
+	require.NoError(t, err)
+	require.Equal(t, 1, out.Version)
+	require.Equal(t, "Ideas for rust", out.Text)
+}
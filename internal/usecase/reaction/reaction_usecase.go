@@ -2,23 +2,135 @@ package usecase
 
 import (
 	"context"
+	"time"
 	"write_base/internal/domain"
 )
 
 type ReactionService struct {
-	repo domain.IReactionRepository
+	repo     domain.IReactionRepository
+	articles domain.IArticleRepository
+	comments domain.ICommentRepository
+	types    []domain.ReactionOption
+	allowed  map[domain.ReactionType]bool
+	now      func() time.Time
 }
 
-func NewReactionService(repo domain.IReactionRepository) *ReactionService {
-	return &ReactionService{repo: repo}
+// NewReactionService offers the given reaction set, or the default one when it is empty.
+func NewReactionService(repo domain.IReactionRepository, articles domain.IArticleRepository, comments domain.ICommentRepository, types []domain.ReactionOption) *ReactionService {
+	if len(types) == 0 {
+		types = domain.DefaultReactionSet
+	}
+	allowed := make(map[domain.ReactionType]bool, len(types))
+	for _, t := range types {
+		allowed[t.Type] = true
+	}
+	return &ReactionService{repo: repo, articles: articles, comments: comments, types: types, allowed: allowed, now: time.Now}
+}
+
+func (s *ReactionService) ReactionTypes() []domain.ReactionOption {
+	return s.types
+}
+
+// resolveTarget checks that the article or comment can be reacted to. A
+// comment reaction is filed under the comment's article.
+func (s *ReactionService) resolveTarget(ctx context.Context, reaction *domain.Reaction) error {
+	if reaction.CommentID != nil && *reaction.CommentID == "" {
+		reaction.CommentID = nil
+	}
+	if reaction.CommentID != nil && s.comments != nil {
+		comment, err := s.comments.GetByID(ctx, *reaction.CommentID)
+		if err != nil {
+			return err
+		}
+		if comment.Deleted {
+			return domain.ErrCommentNotFound
+		}
+		reaction.PostID = comment.PostID
+	}
+	if reaction.PostID == "" {
+		return domain.ErrArticleNotFound
+	}
+	if s.articles != nil {
+		article, err := s.articles.GetByID(ctx, reaction.PostID)
+		if err != nil {
+			return err
+		}
+		if article.Status != domain.StatusPublished {
+			return domain.ErrArticleNotPublished
+		}
+	}
+	return nil
+}
+
+func (s *ReactionService) validate(ctx context.Context, reaction *domain.Reaction) error {
+	if reaction.UserID == "" {
+		return domain.ErrUnauthorized
+	}
+	if !s.allowed[reaction.Type] {
+		return domain.ErrInvalidReactionType
+	}
+	if err := s.resolveTarget(ctx, reaction); err != nil {
+		return err
+	}
+	reaction.CreatedAt = s.now().Unix()
+	return nil
 }
 
 func (s *ReactionService) AddReaction(ctx context.Context, reaction *domain.Reaction) error {
-	return s.repo.AddReaction(ctx, reaction)
+	if err := s.validate(ctx, reaction); err != nil {
+		return err
+	}
+	added, err := s.repo.AddReaction(ctx, reaction)
+	if err != nil {
+		return err
+	}
+	if added {
+		s.countReaction(ctx, reaction, 1)
+	}
+	return nil
 }
 
-func (s *ReactionService) RemoveReaction(ctx context.Context, reactionID string) error {
-	return s.repo.RemoveReaction(ctx, reactionID)
+func (s *ReactionService) ToggleReaction(ctx context.Context, reaction *domain.Reaction) (bool, error) {
+	if err := s.validate(ctx, reaction); err != nil {
+		return false, err
+	}
+	added, err := s.repo.ToggleReaction(ctx, reaction)
+	if err != nil {
+		return false, err
+	}
+	if added {
+		s.countReaction(ctx, reaction, 1)
+	} else {
+		s.countReaction(ctx, reaction, -1)
+	}
+	return added, nil
+}
+
+// countReaction moves the article's reaction counter, which only covers
+// reactions on the article itself; the reconcile job repairs it if the update fails.
+func (s *ReactionService) countReaction(ctx context.Context, reaction *domain.Reaction, delta int) {
+	if s.articles == nil || reaction.CommentID != nil {
+		return
+	}
+	_, _ = s.articles.IncrementStat(ctx, reaction.PostID, domain.StatReactions, delta)
+}
+
+func (s *ReactionService) RemoveReaction(ctx context.Context, userID, reactionID string) error {
+	if userID == "" {
+		return domain.ErrUnauthorized
+	}
+	reaction, err := s.repo.GetByID(ctx, reactionID)
+	if err != nil {
+		return err
+	}
+	if reaction.UserID != userID {
+		return domain.ErrForbidden
+	}
+	if err := s.repo.RemoveReaction(ctx, reactionID); err != nil {
+		return err
+	}
+	s.countReaction(ctx, reaction, -1)
+	return nil
 }
 
 func (s *ReactionService) GetReactionsByPost(ctx context.Context, postID string) ([]*domain.Reaction, error) {
@@ -32,3 +144,30 @@ func (s *ReactionService) GetReactionsByUser(ctx context.Context, userID string)
 func (s *ReactionService) CountReactions(ctx context.Context, postID string, reactionType domain.ReactionType) (int, error) {
 	return s.repo.CountReactions(ctx, postID, reactionType)
 }
+
+func (s *ReactionService) GetReactionSummary(ctx context.Context, userID string, target domain.ReactionTarget) (*domain.ReactionSummary, error) {
+	if target.PostID == "" && target.CommentID == "" {
+		return nil, domain.ErrArticleNotFound
+	}
+	stored, err := s.repo.CountByType(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	// Every configured type is listed; types no longer offered are left out
+	summary := &domain.ReactionSummary{Counts: make(map[domain.ReactionType]int, len(s.types))}
+	for _, t := range s.types {
+		summary.Counts[t.Type] = stored[t.Type]
+	}
+	if userID != "" {
+		mine, err := s.repo.GetUserReactionTypes(ctx, userID, target)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range mine {
+			if s.allowed[t] {
+				summary.Mine = append(summary.Mine, t)
+			}
+		}
+	}
+	return summary, nil
+}
@@ -4,22 +4,30 @@ import (
 	"context"
 	"testing"
 	"write_base/internal/domain"
+	"write_base/internal/mocks"
 )
 
 type reactionRepoMock struct {
-	AddReactionFn        func(ctx context.Context, r *domain.Reaction) error
+	AddReactionFn        func(ctx context.Context, r *domain.Reaction) (bool, error)
 	RemoveReactionFn     func(ctx context.Context, id string) error
+	GetByIDFn            func(ctx context.Context, id string) (*domain.Reaction, error)
 	GetReactionsByPostFn func(ctx context.Context, postID string) ([]*domain.Reaction, error)
 	GetReactionsByUserFn func(ctx context.Context, userID string) ([]*domain.Reaction, error)
 	CountReactionsFn     func(ctx context.Context, postID string, t domain.ReactionType) (int, error)
+	ToggleReactionFn     func(ctx context.Context, r *domain.Reaction) (bool, error)
+	CountByTypeFn        func(ctx context.Context, target domain.ReactionTarget) (map[domain.ReactionType]int, error)
+	GetUserTypesFn       func(ctx context.Context, userID string, target domain.ReactionTarget) ([]domain.ReactionType, error)
 }
 
-func (m *reactionRepoMock) AddReaction(ctx context.Context, r *domain.Reaction) error {
+func (m *reactionRepoMock) AddReaction(ctx context.Context, r *domain.Reaction) (bool, error) {
 	return m.AddReactionFn(ctx, r)
 }
 func (m *reactionRepoMock) RemoveReaction(ctx context.Context, id string) error {
 	return m.RemoveReactionFn(ctx, id)
 }
+func (m *reactionRepoMock) GetByID(ctx context.Context, id string) (*domain.Reaction, error) {
+	return m.GetByIDFn(ctx, id)
+}
 func (m *reactionRepoMock) GetReactionsByPost(ctx context.Context, postID string) ([]*domain.Reaction, error) {
 	return m.GetReactionsByPostFn(ctx, postID)
 }
@@ -30,10 +38,23 @@ func (m *reactionRepoMock) CountReactions(ctx context.Context, postID string, t
 	return m.CountReactionsFn(ctx, postID, t)
 }
 
+func (m *reactionRepoMock) ToggleReaction(ctx context.Context, r *domain.Reaction) (bool, error) {
+	return m.ToggleReactionFn(ctx, r)
+}
+func (m *reactionRepoMock) CountByType(ctx context.Context, target domain.ReactionTarget) (map[domain.ReactionType]int, error) {
+	return m.CountByTypeFn(ctx, target)
+}
+func (m *reactionRepoMock) GetUserReactionTypes(ctx context.Context, userID string, target domain.ReactionTarget) ([]domain.ReactionType, error) {
+	return m.GetUserTypesFn(ctx, userID, target)
+}
+
 func TestReactionService_Basic(t *testing.T) {
 	repo := &reactionRepoMock{
-		AddReactionFn:    func(ctx context.Context, r *domain.Reaction) error { return nil },
+		AddReactionFn:    func(ctx context.Context, r *domain.Reaction) (bool, error) { return true, nil },
 		RemoveReactionFn: func(ctx context.Context, id string) error { return nil },
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Reaction, error) {
+			return &domain.Reaction{ID: id, UserID: "u1"}, nil
+		},
 		GetReactionsByPostFn: func(ctx context.Context, postID string) ([]*domain.Reaction, error) {
 			return []*domain.Reaction{{ID: "r1"}}, nil
 		},
@@ -42,13 +63,19 @@ func TestReactionService_Basic(t *testing.T) {
 		},
 		CountReactionsFn: func(ctx context.Context, postID string, t domain.ReactionType) (int, error) { return 2, nil },
 	}
-	s := NewReactionService(repo)
-	if err := s.AddReaction(context.Background(), &domain.Reaction{ID: "r1"}); err != nil {
+	s := NewReactionService(repo, nil, nil, nil)
+	if err := s.AddReaction(context.Background(), &domain.Reaction{ID: "r1", PostID: "p1", UserID: "u1", Type: domain.ReactionLike}); err != nil {
 		t.Fatal(err)
 	}
-	if err := s.RemoveReaction(context.Background(), "r1"); err != nil {
+	if err := s.RemoveReaction(context.Background(), "u1", "r1"); err != nil {
 		t.Fatal(err)
 	}
+	if err := s.RemoveReaction(context.Background(), "u2", "r1"); err != domain.ErrForbidden {
+		t.Fatalf("expected forbidden for another user's reaction, got %v", err)
+	}
+	if err := s.AddReaction(context.Background(), &domain.Reaction{PostID: "p1"}); err != domain.ErrUnauthorized {
+		t.Fatalf("expected unauthorized without a user, got %v", err)
+	}
 	if list, err := s.GetReactionsByPost(context.Background(), "p1"); err != nil || len(list) != 1 {
 		t.Fatalf("byPost bad")
 	}
@@ -59,3 +86,139 @@ func TestReactionService_Basic(t *testing.T) {
 		t.Fatalf("count bad")
 	}
 }
+
+func TestReactionService_ValidatesTypeAndTarget(t *testing.T) {
+	var added *domain.Reaction
+	repo := &reactionRepoMock{
+		AddReactionFn:    func(ctx context.Context, r *domain.Reaction) (bool, error) { added = r; return true, nil },
+		ToggleReactionFn: func(ctx context.Context, r *domain.Reaction) (bool, error) { return true, nil },
+	}
+	articles := &mocks.ArticleRepositoryMock{GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) {
+		if id == "draft" {
+			return &domain.Article{ID: id, Status: domain.StatusDraft}, nil
+		}
+		return &domain.Article{ID: id, Status: domain.StatusPublished}, nil
+	}}
+	comments := &mocks.CommentRepositoryMock{GetByIDFn: func(ctx context.Context, id string) (*domain.Comment, error) {
+		return &domain.Comment{ID: id, PostID: "p9", Deleted: id == "gone"}, nil
+	}}
+	set := []domain.ReactionOption{{Type: "like", Emoji: "👍"}, {Type: "fire", Emoji: "🔥"}}
+	s := NewReactionService(repo, articles, comments, set)
+	ctx := context.Background()
+
+	if err := s.AddReaction(ctx, &domain.Reaction{PostID: "p1", UserID: "u1", Type: "sad"}); err != domain.ErrInvalidReactionType {
+		t.Fatalf("expected a type outside the set to be rejected, got %v", err)
+	}
+	if err := s.AddReaction(ctx, &domain.Reaction{PostID: "draft", UserID: "u1", Type: "fire"}); err != domain.ErrArticleNotPublished {
+		t.Fatalf("expected drafts to be rejected, got %v", err)
+	}
+	gone := "gone"
+	if _, err := s.ToggleReaction(ctx, &domain.Reaction{UserID: "u1", CommentID: &gone, Type: "fire"}); err != domain.ErrCommentNotFound {
+		t.Fatalf("expected deleted comments to be rejected, got %v", err)
+	}
+
+	// A comment reaction is filed under the comment's article
+	c1 := "c1"
+	if err := s.AddReaction(ctx, &domain.Reaction{PostID: "other", UserID: "u1", CommentID: &c1, Type: "fire"}); err != nil {
+		t.Fatal(err)
+	}
+	if added.PostID != "p9" || added.CreatedAt == 0 {
+		t.Fatalf("unexpected reaction %+v", added)
+	}
+	if ok, err := s.ToggleReaction(ctx, &domain.Reaction{PostID: "p1", UserID: "u1", Type: "like"}); err != nil || !ok {
+		t.Fatalf("toggle bad: %v %v", ok, err)
+	}
+	if types := s.ReactionTypes(); len(types) != 2 || types[1].Emoji != "🔥" {
+		t.Fatalf("unexpected types %+v", types)
+	}
+}
+
+func TestReactionService_CountsArticleReactions(t *testing.T) {
+	present := map[string]bool{}
+	repo := &reactionRepoMock{
+		AddReactionFn: func(ctx context.Context, r *domain.Reaction) (bool, error) {
+			if present[string(r.Type)] {
+				return false, nil
+			}
+			present[string(r.Type)] = true
+			return true, nil
+		},
+		ToggleReactionFn: func(ctx context.Context, r *domain.Reaction) (bool, error) {
+			present[string(r.Type)] = !present[string(r.Type)]
+			return present[string(r.Type)], nil
+		},
+	}
+	count := 0
+	articles := &mocks.ArticleRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) {
+			return &domain.Article{ID: id, Status: domain.StatusPublished}, nil
+		},
+		IncrementStatFn: func(ctx context.Context, id string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+			if id != "p1" || counter != domain.StatReactions {
+				t.Fatalf("unexpected increment of %s on %s", counter, id)
+			}
+			count += delta
+			return &domain.ArticleStats{ReactionCount: count}, nil
+		},
+	}
+	comments := &mocks.CommentRepositoryMock{GetByIDFn: func(ctx context.Context, id string) (*domain.Comment, error) {
+		return &domain.Comment{ID: id, PostID: "p1"}, nil
+	}}
+	s := NewReactionService(repo, articles, comments, nil)
+	ctx := context.Background()
+
+	// Adding the same reaction twice counts it once
+	for i := 0; i < 2; i++ {
+		if err := s.AddReaction(ctx, &domain.Reaction{PostID: "p1", UserID: "u1", Type: domain.ReactionLike}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.ToggleReaction(ctx, &domain.Reaction{PostID: "p1", UserID: "u1", Type: "love"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ToggleReaction(ctx, &domain.Reaction{PostID: "p1", UserID: "u1", Type: domain.ReactionLike}); err != nil {
+		t.Fatal(err)
+	}
+	// Reactions on comments do not count towards the article
+	c1 := "c1"
+	if _, err := s.ToggleReaction(ctx, &domain.Reaction{UserID: "u1", CommentID: &c1, Type: "wow"}); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 article reaction, got %d", count)
+	}
+}
+
+func TestReactionService_Summary(t *testing.T) {
+	var counted domain.ReactionTarget
+	repo := &reactionRepoMock{
+		CountByTypeFn: func(ctx context.Context, target domain.ReactionTarget) (map[domain.ReactionType]int, error) {
+			counted = target
+			return map[domain.ReactionType]int{"like": 3, "retired": 7}, nil
+		},
+		GetUserTypesFn: func(ctx context.Context, userID string, target domain.ReactionTarget) ([]domain.ReactionType, error) {
+			return []domain.ReactionType{"like", "retired"}, nil
+		},
+	}
+	s := NewReactionService(repo, nil, nil, []domain.ReactionOption{{Type: "like", Emoji: "👍"}, {Type: "fire", Emoji: "🔥"}})
+	ctx := context.Background()
+
+	summary, err := s.GetReactionSummary(ctx, "u1", domain.ReactionTarget{CommentID: "c1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counted.CommentID != "c1" {
+		t.Fatalf("unexpected target %+v", counted)
+	}
+	if len(summary.Counts) != 2 || summary.Counts["like"] != 3 || summary.Counts["fire"] != 0 {
+		t.Fatalf("unexpected counts %v", summary.Counts)
+	}
+	if len(summary.Mine) != 1 || summary.Mine[0] != "like" {
+		t.Fatalf("unexpected own reactions %v", summary.Mine)
+	}
+
+	repo.GetUserTypesFn = nil
+	if summary, err := s.GetReactionSummary(ctx, "", domain.ReactionTarget{PostID: "p1"}); err != nil || summary.Mine != nil {
+		t.Fatalf("expected no own reactions when signed out: %+v %v", summary, err)
+	}
+}
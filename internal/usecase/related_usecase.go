@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"write_base/internal/domain"
+)
+
+type RelatedUsecase struct {
+	Articles domain.IArticleRepository
+	Related  domain.IRelatedRepository
+}
+
+var _ domain.IRelatedUsecase = (*RelatedUsecase)(nil)
+
+func NewRelatedUsecase(articles domain.IArticleRepository, related domain.IRelatedRepository) *RelatedUsecase {
+	return &RelatedUsecase{Articles: articles, Related: related}
+}
+
+// GetRelatedArticles ranks published articles by shared tags, shared author and
+// co-engagement, leaving out the article itself and anything the reader wrote.
+func (u *RelatedUsecase) GetRelatedArticles(ctx context.Context, articleID, userID string, limit int) ([]domain.RelatedArticle, error) {
+	c, cancel := context.WithTimeout(ctx, domain.DefaultTimeout)
+	defer cancel()
+
+	if articleID == "" {
+		return nil, domain.ErrInvalidArticlePayload
+	}
+	if limit <= 0 {
+		limit = domain.DefaultRelatedLimit
+	}
+	limit = min(limit, domain.MaxRelatedLimit)
+
+	base, err := u.Articles.GetByID(c, articleID)
+	if err != nil {
+		if err == domain.ErrArticleNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternalServer
+	}
+	if base.Status != domain.StatusPublished && base.AuthorID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	candidates, err := u.Related.TagAuthorCandidates(c, *base, domain.RelatedCandidatePool)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	coReaders, err := u.Related.CoEngaged(c, base.ID, domain.RelatedCandidatePool)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+
+	// Co-engaged articles that share neither tags nor author still need loading
+	seen := make(map[string]bool, len(candidates))
+	for _, a := range candidates {
+		seen[a.ID] = true
+	}
+	var missing []string
+	for id := range coReaders {
+		if !seen[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		more, err := u.Articles.GetByIDs(c, missing)
+		if err != nil {
+			return nil, domain.ErrInternalServer
+		}
+		candidates = append(candidates, more...)
+	}
+
+	related := make([]domain.RelatedArticle, 0, len(candidates))
+	for _, a := range candidates {
+		if a.ID == base.ID || a.Status != domain.StatusPublished || (userID != "" && a.AuthorID == userID) {
+			continue
+		}
+		if r := domain.ScoreRelated(*base, a, coReaders[a.ID]); r.Score > 0 {
+			related = append(related, r)
+		}
+	}
+	domain.SortRelated(related)
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	return related, nil
+}
@@ -0,0 +1,97 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func relatedIDs(related []domain.RelatedArticle) []string {
+	ids := make([]string, len(related))
+	for i, r := range related {
+		ids[i] = r.Article.ID
+	}
+	return ids
+}
+
+func pub(id, author string, tags ...string) domain.Article {
+	return domain.Article{ID: id, AuthorID: author, Tags: tags, Status: domain.StatusPublished}
+}
+
+func TestGetRelatedArticles_RanksAndExcludes(t *testing.T) {
+	base := pub("base", "au1", "go", "testing")
+	var requested []string
+	articles := &mocks.ArticleRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) { return &base, nil },
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]domain.Article, error) {
+			requested = ids
+			draft := pub("co-draft", "au3")
+			draft.Status = domain.StatusDraft
+			return []domain.Article{pub("co-only", "au3"), draft}, nil
+		},
+	}
+	related := &mocks.RelatedRepositoryMock{
+		TagAuthorCandidatesFn: func(ctx context.Context, a domain.Article, limit int) ([]domain.Article, error) {
+			require.Equal(t, domain.RelatedCandidatePool, limit)
+			return []domain.Article{
+				pub("two-tags", "au2", "go", "testing"),
+				pub("same-author", "au1"),
+				pub("one-tag", "au2", "go"),
+				pub("mine", "reader", "go", "testing"),
+			}, nil
+		},
+		CoEngagedFn: func(ctx context.Context, id string, limit int) (map[string]int, error) {
+			return map[string]int{"one-tag": 10, "co-only": 1, "co-draft": 5}, nil
+		},
+	}
+	uc := usecase.NewRelatedUsecase(articles, related)
+
+	got, err := uc.GetRelatedArticles(context.Background(), "base", "reader", 10)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"co-only", "co-draft"}, requested)
+	require.Equal(t, []string{"one-tag", "two-tags", "same-author", "co-only"}, relatedIDs(got))
+	require.Equal(t, 10, got[0].CoReaders)
+	require.Equal(t, []string{"go", "testing"}, got[1].SharedTags)
+
+	got, err = uc.GetRelatedArticles(context.Background(), "base", "reader", 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+}
+
+func TestGetRelatedArticles_Errors(t *testing.T) {
+	draft := pub("d1", "au1")
+	draft.Status = domain.StatusDraft
+	articles := &mocks.ArticleRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) {
+			if id == "missing" {
+				return nil, domain.ErrArticleNotFound
+			}
+			return &draft, nil
+		},
+	}
+	related := &mocks.RelatedRepositoryMock{}
+	uc := usecase.NewRelatedUsecase(articles, related)
+	ctx := context.Background()
+
+	_, err := uc.GetRelatedArticles(ctx, "", "u1", 0)
+	require.ErrorIs(t, err, domain.ErrInvalidArticlePayload)
+	_, err = uc.GetRelatedArticles(ctx, "missing", "u1", 0)
+	require.ErrorIs(t, err, domain.ErrArticleNotFound)
+	_, err = uc.GetRelatedArticles(ctx, "d1", "someone", 0)
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	// Authors may preview recommendations for their own drafts
+	_, err = uc.GetRelatedArticles(ctx, "d1", "au1", 0)
+	require.NoError(t, err)
+
+	related.CoEngagedFn = func(ctx context.Context, id string, limit int) (map[string]int, error) {
+		return nil, errors.New("db down")
+	}
+	_, err = uc.GetRelatedArticles(ctx, "d1", "au1", 0)
+	require.ErrorIs(t, err, domain.ErrInternalServer)
+}
@@ -6,21 +6,76 @@ import (
 )
 
 type ReportService struct {
-	repo domain.IReportRepository
+	repo          domain.IReportRepository
+	articles      domain.IArticleRepository
+	notifications domain.INotificationUsecase
 }
 
-func NewReportService(repo domain.IReportRepository) *ReportService {
-	return &ReportService{repo: repo}
+func NewReportService(repo domain.IReportRepository, articles domain.IArticleRepository, notifications domain.INotificationUsecase) *ReportService {
+	return &ReportService{repo: repo, articles: articles, notifications: notifications}
 }
 
 func (s *ReportService) CreateReport(ctx context.Context, report *domain.Report) error {
+	if report.ReporterID == "" {
+		return domain.ErrUnauthorized
+	}
 	return s.repo.CreateReport(ctx, report)
 }
 
-func (s *ReportService) GetReports(ctx context.Context, filter map[string]interface{}) ([]*domain.Report, error) {
+func (s *ReportService) GetReports(ctx context.Context, role domain.UserRole, filter map[string]interface{}) ([]*domain.Report, error) {
+	if !role.IsAdmin() {
+		return nil, domain.ErrForbidden
+	}
 	return s.repo.GetReports(ctx, filter)
 }
 
-func (s *ReportService) UpdateReportStatus(ctx context.Context, reportID string, status domain.ReportStatus) error {
-	return s.repo.UpdateReportStatus(ctx, reportID, status)
+func (s *ReportService) UpdateReportStatus(ctx context.Context, role domain.UserRole, reportID string, status domain.ReportStatus) error {
+	if !role.IsAdmin() {
+		return domain.ErrForbidden
+	}
+	if status != domain.ReportResolved {
+		return s.repo.UpdateReportStatus(ctx, reportID, status)
+	}
+	report, err := s.repo.GetReportByID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	if report.Status == domain.ReportResolved {
+		return domain.ErrReportAlreadyResolved
+	}
+	if err := s.repo.UpdateReportStatus(ctx, reportID, status); err != nil {
+		return err
+	}
+	s.notifyResolved(ctx, report)
+	return nil
+}
+
+// notifyResolved tells the reporter their report was handled. Reports filed
+// by moderation are reviews of flagged content: resolving one on an article
+// that is still published approves it.
+func (s *ReportService) notifyResolved(ctx context.Context, report *domain.Report) {
+	if s.notifications == nil {
+		return
+	}
+	if report.ReporterID != domain.SystemReporterID {
+		_ = s.notifications.Notify(ctx, domain.Notification{
+			UserID:   report.ReporterID,
+			Type:     domain.NotificationReportResolved,
+			ReportID: report.ID,
+		})
+		return
+	}
+	if report.TargetType != string(domain.ContentKindArticle) || s.articles == nil {
+		return
+	}
+	article, err := s.articles.GetByID(ctx, report.TargetID)
+	if err != nil || article.Status != domain.StatusPublished {
+		return
+	}
+	_ = s.notifications.Notify(ctx, domain.Notification{
+		UserID:    article.AuthorID,
+		Type:      domain.NotificationArticleApproved,
+		ArticleID: article.ID,
+		ReportID:  report.ID,
+	})
 }
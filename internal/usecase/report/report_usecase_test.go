@@ -4,11 +4,13 @@ import (
 	"context"
 	"testing"
 	"write_base/internal/domain"
+	"write_base/internal/mocks"
 )
 
 type reportRepoMock struct {
 	CreateReportFn       func(ctx context.Context, r *domain.Report) error
 	GetReportsFn         func(ctx context.Context, f map[string]interface{}) ([]*domain.Report, error)
+	GetReportByIDFn      func(ctx context.Context, id string) (*domain.Report, error)
 	UpdateReportStatusFn func(ctx context.Context, id string, s domain.ReportStatus) error
 }
 
@@ -18,6 +20,12 @@ func (m *reportRepoMock) CreateReport(ctx context.Context, r *domain.Report) err
 func (m *reportRepoMock) GetReports(ctx context.Context, f map[string]interface{}) ([]*domain.Report, error) {
 	return m.GetReportsFn(ctx, f)
 }
+func (m *reportRepoMock) GetReportByID(ctx context.Context, id string) (*domain.Report, error) {
+	if m.GetReportByIDFn == nil {
+		return &domain.Report{ID: id, Status: domain.ReportPending}, nil
+	}
+	return m.GetReportByIDFn(ctx, id)
+}
 func (m *reportRepoMock) UpdateReportStatus(ctx context.Context, id string, s domain.ReportStatus) error {
 	return m.UpdateReportStatusFn(ctx, id, s)
 }
@@ -30,14 +38,75 @@ func TestReportService_Basic(t *testing.T) {
 		},
 		UpdateReportStatusFn: func(ctx context.Context, id string, s domain.ReportStatus) error { return nil },
 	}
-	s := NewReportService(repo)
-	if err := s.CreateReport(context.Background(), &domain.Report{ID: "rep1"}); err != nil {
+	s := NewReportService(repo, nil, nil)
+	if err := s.CreateReport(context.Background(), &domain.Report{ID: "rep1", ReporterID: "u1"}); err != nil {
 		t.Fatal(err)
 	}
-	if list, err := s.GetReports(context.Background(), map[string]interface{}{}); err != nil || len(list) != 1 {
+	if list, err := s.GetReports(context.Background(), domain.RoleAdmin, map[string]interface{}{}); err != nil || len(list) != 1 {
 		t.Fatalf("get bad")
 	}
-	if err := s.UpdateReportStatus(context.Background(), "rep1", domain.ReportResolved); err != nil {
+	if err := s.UpdateReportStatus(context.Background(), domain.RoleSuperAdmin, "rep1", domain.ReportResolved); err != nil {
 		t.Fatal(err)
 	}
+	if _, err := s.GetReports(context.Background(), domain.RoleUser, map[string]interface{}{}); err != domain.ErrForbidden {
+		t.Fatalf("expected forbidden listing for non-admin, got %v", err)
+	}
+	if err := s.UpdateReportStatus(context.Background(), domain.RoleUser, "rep1", domain.ReportResolved); err != domain.ErrForbidden {
+		t.Fatalf("expected forbidden update for non-admin, got %v", err)
+	}
+	if err := s.CreateReport(context.Background(), &domain.Report{TargetID: "p1"}); err != domain.ErrUnauthorized {
+		t.Fatalf("expected unauthorized without a reporter, got %v", err)
+	}
+}
+
+func TestReportService_ResolveNotifies(t *testing.T) {
+	reports := map[string]*domain.Report{
+		"rep1": {ID: "rep1", ReporterID: "u1", TargetID: "c1", TargetType: "comment", Status: domain.ReportPending},
+		"rep2": {ID: "rep2", ReporterID: domain.SystemReporterID, TargetID: "a1", TargetType: "article", Status: domain.ReportPending},
+		"rep3": {ID: "rep3", ReporterID: "u1", Status: domain.ReportResolved},
+	}
+	updated := 0
+	repo := &reportRepoMock{
+		GetReportByIDFn: func(ctx context.Context, id string) (*domain.Report, error) {
+			if r, ok := reports[id]; ok {
+				return r, nil
+			}
+			return nil, domain.ErrReportNotFound
+		},
+		UpdateReportStatusFn: func(ctx context.Context, id string, s domain.ReportStatus) error {
+			updated++
+			return nil
+		},
+	}
+	articles := &mocks.ArticleRepositoryMock{GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) {
+		return &domain.Article{ID: id, AuthorID: "author", Status: domain.StatusPublished}, nil
+	}}
+	notifications := &mocks.NotificationUsecaseMock{}
+	s := NewReportService(repo, articles, notifications)
+	ctx := context.Background()
+
+	if err := s.UpdateReportStatus(ctx, domain.RoleAdmin, "rep1", domain.ReportResolved); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateReportStatus(ctx, domain.RoleAdmin, "rep2", domain.ReportResolved); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateReportStatus(ctx, domain.RoleAdmin, "rep3", domain.ReportResolved); err != domain.ErrReportAlreadyResolved {
+		t.Fatalf("expected already resolved, got %v", err)
+	}
+	if err := s.UpdateReportStatus(ctx, domain.RoleAdmin, "missing", domain.ReportResolved); err != domain.ErrReportNotFound {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if updated != 2 {
+		t.Fatalf("expected 2 updates, got %d", updated)
+	}
+	if len(notifications.Sent) != 2 {
+		t.Fatalf("expected 2 notifications, got %+v", notifications.Sent)
+	}
+	if n := notifications.Sent[0]; n.UserID != "u1" || n.Type != domain.NotificationReportResolved || n.ReportID != "rep1" {
+		t.Fatalf("reporter notification wrong: %+v", n)
+	}
+	if n := notifications.Sent[1]; n.UserID != "author" || n.Type != domain.NotificationArticleApproved || n.ArticleID != "a1" {
+		t.Fatalf("approval notification wrong: %+v", n)
+	}
 }
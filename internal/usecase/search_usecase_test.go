@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchArticles_BuildsSnippets(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	var got domain.SearchQuery
+	repo.SearchFn = func(ctx context.Context, q domain.SearchQuery, p domain.Pagination) ([]domain.SearchHit, int, error) {
+		got = q
+		return []domain.SearchHit{{
+			Article: domain.Article{ID: "a1", Title: "Tips", ContentBlocks: []domain.ContentBlock{
+				{Content: domain.BlockContent{Paragraph: &domain.ParagraphContent{Text: "Prefer small interfaces in Go."}}},
+			}},
+			Score: 1.5,
+		}}, 1, nil
+	}
+
+	hits, total, err := uc.SearchArticles(context.Background(), "u1",
+		domain.SearchQuery{Text: `Go -java`, AuthorID: "au1", Tags: []string{"go"}}, domain.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, domain.SearchQuery{Text: "go -java", AuthorID: "au1", Tags: []string{"go"}}, got)
+	require.Equal(t, []domain.SearchSnippet{{Field: "paragraph", Text: "Prefer small interfaces in <mark>Go</mark>."}}, hits[0].Snippets)
+	require.Nil(t, hits[0].Article.ContentBlocks)
+}
+
+func TestSearchArticles_InvalidQuery(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	repo.SearchFn = func(ctx context.Context, q domain.SearchQuery, p domain.Pagination) ([]domain.SearchHit, int, error) {
+		t.Fatal("repository must not be queried")
+		return nil, 0, nil
+	}
+	_, _, err := uc.SearchArticles(context.Background(), "u1", domain.SearchQuery{Text: "-java"}, domain.Pagination{})
+	require.ErrorIs(t, err, domain.ErrInvalidSearchQuery)
+}
+
+func TestSearchArticles_UsesSearchIndex(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	repo.SearchFn = func(ctx context.Context, q domain.SearchQuery, p domain.Pagination) ([]domain.SearchHit, int, error) {
+		t.Fatal("repository must not be queried when an index is configured")
+		return nil, 0, nil
+	}
+	uc.SearchIndex = &mocks.SearchIndexMock{SearchFn: func(ctx context.Context, q domain.SearchQuery, p domain.Pagination) ([]domain.SearchHit, int, error) {
+		return []domain.SearchHit{{Article: domain.Article{ID: "a1", Title: "Go"}}}, 1, nil
+	}}
+	hits, total, err := uc.SearchArticles(context.Background(), "u1", domain.SearchQuery{Text: "go"}, domain.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, "a1", hits[0].Article.ID)
+
+	uc.SearchIndex = &mocks.SearchIndexMock{SearchFn: func(ctx context.Context, q domain.SearchQuery, p domain.Pagination) ([]domain.SearchHit, int, error) {
+		return nil, 0, domain.ErrUnsupportedSearchSort
+	}}
+	_, _, err = uc.SearchArticles(context.Background(), "u1", domain.SearchQuery{Text: "go"}, domain.Pagination{SortField: domain.SortByClapCount})
+	require.ErrorIs(t, err, domain.ErrUnsupportedSearchSort)
+}
+
+func TestSearchIndex_FollowsArticleState(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	var indexed []domain.Article
+	var removed []string
+	uc.SearchIndex = &mocks.SearchIndexMock{
+		IndexFn: func(ctx context.Context, articles ...domain.Article) error {
+			indexed = append(indexed, articles...)
+			return nil
+		},
+		RemoveFn: func(ctx context.Context, id string) error {
+			removed = append(removed, id)
+			return errors.New("index unavailable")
+		},
+	}
+	article := &domain.Article{ID: "a1", AuthorID: "u1", Status: domain.StatusDraft}
+	repo.GetByIDFn = func(ctx context.Context, id string) (*domain.Article, error) {
+		cp := *article
+		return &cp, nil
+	}
+
+	_, err := uc.PublishArticle(context.Background(), "a1", "u1")
+	require.NoError(t, err)
+	require.Len(t, indexed, 1)
+	require.Equal(t, domain.StatusPublished, indexed[0].Status)
+
+	// Index failures are logged, not returned
+	article.Status = domain.StatusPublished
+	_, err = uc.UnpublishArticle(context.Background(), "a1", "u1")
+	require.NoError(t, err)
+	require.NoError(t, uc.DeleteArticle(context.Background(), "a1", "u1"))
+	require.Equal(t, []string{"a1", "a1"}, removed)
+}
+
+func TestAdminRebuildSearchIndex(t *testing.T) {
+	uc, repo, policy, _, _, _, _ := newArticleUC()
+	policy.IsAdminFn = func(uid, role string) bool { return role == "admin" }
+	var calls []string
+	uc.SearchIndex = &mocks.SearchIndexMock{
+		ResetFn: func(ctx context.Context) error { calls = append(calls, "reset"); return nil },
+		IndexFn: func(ctx context.Context, articles ...domain.Article) error {
+			calls = append(calls, "index")
+			return nil
+		},
+	}
+	repo.ForEachPublishedFn = func(ctx context.Context, size int, fn func([]domain.Article) error) error {
+		if err := fn(make([]domain.Article, size)); err != nil {
+			return err
+		}
+		return fn([]domain.Article{{ID: "last"}})
+	}
+
+	_, err := uc.AdminRebuildSearchIndex(context.Background(), "u1", "user")
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+	require.Empty(t, calls)
+
+	n, err := uc.AdminRebuildSearchIndex(context.Background(), "u1", "admin")
+	require.NoError(t, err)
+	require.Equal(t, 501, n)
+	require.Equal(t, []string{"reset", "index", "index"}, calls)
+
+	repo.ForEachPublishedFn = func(ctx context.Context, size int, fn func([]domain.Article) error) error {
+		return errors.New("cursor lost")
+	}
+	_, err = uc.AdminRebuildSearchIndex(context.Background(), "u1", "admin")
+	require.ErrorIs(t, err, domain.ErrInternalServer)
+}
+
+func TestGetSearchFacets(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	want := &domain.ArticleFacets{Tags: []domain.FacetCount{{Value: "go", Count: 4}}}
+	var got domain.SearchQuery
+	repo.SearchFacetsFn = func(ctx context.Context, q domain.SearchQuery) (*domain.ArticleFacets, error) {
+		got = q
+		return want, nil
+	}
+	facets, err := uc.GetSearchFacets(context.Background(), "u1", domain.SearchQuery{Text: `Go "error handling"`, Tags: []string{"go"}})
+	require.NoError(t, err)
+	require.Same(t, want, facets)
+	require.Equal(t, domain.SearchQuery{Text: `"error handling" go`, Tags: []string{"go"}}, got)
+
+	_, err = uc.GetSearchFacets(context.Background(), "u1", domain.SearchQuery{Text: "-java"})
+	require.ErrorIs(t, err, domain.ErrInvalidSearchQuery)
+
+	repo.SearchFacetsFn = func(ctx context.Context, q domain.SearchQuery) (*domain.ArticleFacets, error) {
+		return nil, errors.New("aggregation failed")
+	}
+	_, err = uc.GetSearchFacets(context.Background(), "u1", domain.SearchQuery{Text: "go"})
+	require.ErrorIs(t, err, domain.ErrInternalServer)
+}
+
+func TestGetFilterFacets_DefaultsToPublished(t *testing.T) {
+	uc, repo, _, _, _, _, _ := newArticleUC()
+	var got domain.ArticleFilter
+	repo.FilterFacetsFn = func(ctx context.Context, f domain.ArticleFilter) (*domain.ArticleFacets, error) {
+		got = f
+		return &domain.ArticleFacets{}, nil
+	}
+	_, err := uc.GetFilterFacets(context.Background(), domain.ArticleFilter{Language: "en"})
+	require.NoError(t, err)
+	require.Equal(t, domain.ArticleFilter{Language: "en", Statuses: []domain.ArticleStatus{domain.StatusPublished}}, got)
+}
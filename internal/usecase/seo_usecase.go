@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"write_base/internal/domain"
+)
+
+// validateSEO trims the SEO fields in place and checks them against the
+// length limits; URLs must be absolute http(s) links.
+func validateSEO(seo *domain.ArticleSEO) error {
+	seo.MetaTitle = strings.TrimSpace(seo.MetaTitle)
+	seo.MetaDescription = strings.TrimSpace(seo.MetaDescription)
+	seo.CanonicalURL = strings.TrimSpace(seo.CanonicalURL)
+	seo.OGImage = strings.TrimSpace(seo.OGImage)
+
+	if len([]rune(seo.MetaTitle)) > domain.MaxMetaTitleLength {
+		return domain.ErrInvalidSEOMetadata
+	}
+	if len([]rune(seo.MetaDescription)) > domain.MaxMetaDescriptionLength {
+		return domain.ErrInvalidSEOMetadata
+	}
+	if len(seo.Keywords) > domain.MaxSEOKeywords {
+		return domain.ErrInvalidSEOMetadata
+	}
+	for i, k := range seo.Keywords {
+		seo.Keywords[i] = strings.TrimSpace(k)
+		if seo.Keywords[i] == "" {
+			return domain.ErrInvalidSEOMetadata
+		}
+	}
+	for _, raw := range []string{seo.CanonicalURL, seo.OGImage} {
+		if raw == "" {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return domain.ErrInvalidSEOMetadata
+		}
+	}
+	return nil
+}
+
+// truncateAtWord shortens s to at most max runes, cutting at a word boundary when possible.
+func truncateAtWord(s string, max int) string {
+	s = strings.TrimSpace(s)
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	cut := string(r[:max])
+	if i := strings.LastIndex(cut, " "); i > max/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:-")
+}
+
+// GenerateSEOForArticle asks the AI to propose SEO metadata for an article.
+// The proposal is returned for the author to review; nothing is stored.
+func (u *ArticleUsecase) GenerateSEOForArticle(ctx context.Context, articleID, userID string) (*domain.ArticleSEO, error) {
+	c, cancel := context.WithTimeout(ctx, domain.AITImeout)
+	defer cancel()
+
+	if articleID == "" {
+		return nil, domain.ErrArticleInvalidID
+	}
+	article, err := u.Repo.GetByID(c, articleID)
+	if err != nil {
+		if err == domain.ErrArticleNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternalServer
+	}
+	if !u.Policy.UserOwnsArticle(userID, article) {
+		return nil, domain.ErrUnauthorized
+	}
+	content := articlePlainText(article, maxTagPromptContent)
+	if strings.TrimSpace(content) == "" && article.Title == "" {
+		return nil, domain.ErrArticleContentEmpty
+	}
+
+	prompt, err := u.Prompts.Render(c, domain.PromptArticleSEO, map[string]interface{}{
+		"Title":          article.Title,
+		"Excerpt":        article.Excerpt,
+		"Language":       article.Language,
+		"Tags":           article.Tags,
+		"Content":        content,
+		"MaxTitle":       domain.MaxMetaTitleLength,
+		"MaxDescription": domain.MaxMetaDescriptionLength,
+		"MaxKeywords":    domain.MaxSEOKeywords,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	aiResp, err := u.AIClient.GenerateContent(c, prompt.Text)
+	if err != nil {
+		return nil, err
+	}
+	jsonCandidate, ok := extractJSON(aiResp)
+	if !ok {
+		return nil, domain.ErrInternalServer
+	}
+	var parsed struct {
+		MetaTitle       string   `json:"meta_title"`
+		MetaDescription string   `json:"meta_description"`
+		Keywords        []string `json:"keywords"`
+	}
+	if err := json.Unmarshal([]byte(jsonCandidate), &parsed); err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	if err := u.moderateAIOutput(c, parsed.MetaTitle, parsed.MetaDescription); err != nil {
+		return nil, err
+	}
+
+	// Models do not reliably respect length limits, so enforce them here
+	seo := &domain.ArticleSEO{
+		MetaTitle:       truncateAtWord(parsed.MetaTitle, domain.MaxMetaTitleLength),
+		MetaDescription: truncateAtWord(parsed.MetaDescription, domain.MaxMetaDescriptionLength),
+		Keywords:        []string{},
+		CanonicalURL:    article.SEO.CanonicalURL,
+		OGImage:         article.SEO.OGImage,
+	}
+	if seo.OGImage == "" {
+		seo.OGImage = domain.FirstImageURL(article)
+	}
+	seen := map[string]bool{}
+	for _, k := range parsed.Keywords {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "" || seen[k] || u.moderateAIOutput(c, k) != nil {
+			continue
+		}
+		seen[k] = true
+		seo.Keywords = append(seo.Keywords, k)
+		if len(seo.Keywords) >= domain.MaxSEOKeywords {
+			break
+		}
+	}
+	return seo, nil
+}
+
@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSEOUsecase(repo *mocks.ArticleRepositoryMock, aiResp string) domain.IArticleUsecase {
+	policy := &mocks.PolicyMock{
+		UserOwnsArticleFn:    func(uid string, a *domain.Article) bool { return a.AuthorID == uid },
+		ArticleCreateValidFn: func(a *domain.Article) bool { return true },
+	}
+	aiClient := &mocks.AIMock{GenerateContentFn: func(ctx context.Context, prompt string) (string, error) { return aiResp, nil }}
+	prompts := usecase.NewPromptTemplateUsecase(&mocks.PromptTemplateRepositoryMock{}, &mocks.UtilsMock{})
+	return usecase.NewArticleUsecase(repo, policy, &mocks.UtilsMock{}, &mocks.TagUsecaseMock{}, &mocks.ViewUsecaseMock{}, &mocks.ClapUsecaseMock{}, aiClient, prompts, nil, nil, nil, nil)
+}
+
+func TestArticleUsecase_GenerateSEO_EnforcesLimits(t *testing.T) {
+	repo := &mocks.ArticleRepositoryMock{GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) {
+		return &domain.Article{ID: id, AuthorID: "u1", Title: "Go generics", ContentBlocks: []domain.ContentBlock{
+			{Type: domain.BlockParagraph, Content: domain.BlockContent{Paragraph: &domain.ParagraphContent{Text: "Type parameters"}}},
+			{Type: domain.BlockImage, Content: domain.BlockContent{Image: &domain.ImageContent{URL: "https://img/cover.png"}}},
+		}}, nil
+	}}
+	longDesc := strings.Repeat("generics are great ", 20)
+	uc := newSEOUsecase(repo, `{"meta_title": "A practical guide to Go generics and type parameters for everyday code", "meta_description": "`+longDesc+`", "keywords": ["Go", "go", "generics"]}`)
+
+	seo, err := uc.GenerateSEOForArticle(context.Background(), "a1", "u1")
+	require.NoError(t, err)
+	require.LessOrEqual(t, len([]rune(seo.MetaTitle)), domain.MaxMetaTitleLength)
+	require.LessOrEqual(t, len([]rune(seo.MetaDescription)), domain.MaxMetaDescriptionLength)
+	require.False(t, strings.HasSuffix(seo.MetaDescription, " "))
+	require.Equal(t, []string{"go", "generics"}, seo.Keywords)
+	require.Equal(t, "https://img/cover.png", seo.OGImage)
+}
+
+func TestArticleUsecase_GenerateSEO_RequiresOwnership(t *testing.T) {
+	repo := &mocks.ArticleRepositoryMock{GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) {
+		return &domain.Article{ID: id, AuthorID: "u1", Title: "t"}, nil
+	}}
+	uc := newSEOUsecase(repo, `{}`)
+
+	_, err := uc.GenerateSEOForArticle(context.Background(), "a1", "u2")
+	require.Equal(t, domain.ErrUnauthorized, err)
+}
+
+func TestArticleUsecase_CreateArticle_RejectsInvalidSEO(t *testing.T) {
+	repo := &mocks.ArticleRepositoryMock{}
+	uc := newSEOUsecase(repo, "")
+
+	input := &domain.Article{Title: "Hello", Slug: "hello", Tags: []string{"go"}, SEO: domain.ArticleSEO{CanonicalURL: "not a url"}}
+	_, err := uc.CreateArticle(context.Background(), "u1", input)
+	require.Equal(t, domain.ErrInvalidSEOMetadata, err)
+
+	input.SEO = domain.ArticleSEO{MetaTitle: strings.Repeat("x", domain.MaxMetaTitleLength+1)}
+	_, err = uc.CreateArticle(context.Background(), "u1", input)
+	require.Equal(t, domain.ErrInvalidSEOMetadata, err)
+}
+
+func TestArticleUsecase_UpdateArticle_KeepsStoredSEOWhenOmitted(t *testing.T) {
+	stored := &domain.Article{ID: "a1", AuthorID: "u1", Title: "t", SEO: domain.ArticleSEO{MetaTitle: "Stored"}}
+	var saved *domain.Article
+	repo := &mocks.ArticleRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) { return stored, nil },
+		UpdateFn:  func(ctx context.Context, a *domain.Article) error { saved = a; return nil },
+	}
+	uc := newSEOUsecase(repo, "")
+
+	err := uc.UpdateArticle(context.Background(), "u1", &domain.Article{ID: "a1", AuthorID: "u1", Title: "new", Slug: "new", Tags: []string{"go"}, KeepSEO: true})
+	require.NoError(t, err)
+	require.Equal(t, "Stored", saved.SEO.MetaTitle)
+}
+
+func TestArticleUsecase_UpdateArticle_ClearsSEOWhenSentEmpty(t *testing.T) {
+	stored := &domain.Article{ID: "a1", AuthorID: "u1", Title: "t", SEO: domain.ArticleSEO{MetaTitle: "Stored", Keywords: []string{"go"}}}
+	var saved *domain.Article
+	repo := &mocks.ArticleRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Article, error) { return stored, nil },
+		UpdateFn:  func(ctx context.Context, a *domain.Article) error { saved = a; return nil },
+	}
+	uc := newSEOUsecase(repo, "")
+
+	err := uc.UpdateArticle(context.Background(), "u1", &domain.Article{ID: "a1", AuthorID: "u1", Title: "new", Slug: "new", Tags: []string{"go"}})
+	require.NoError(t, err)
+	require.Equal(t, domain.ArticleSEO{}, saved.SEO)
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"write_base/internal/domain"
+)
+
+type StatsReconcileUsecase struct {
+	Repo domain.IArticleStatsRepository
+}
+
+func NewStatsReconcileUsecase(repo domain.IArticleStatsRepository) *StatsReconcileUsecase {
+	return &StatsReconcileUsecase{Repo: repo}
+}
+
+// Reconcile recounts every article's readers, claps, comments and reactions.
+// Engagement landing during a run can make an article look drifted; the fix is
+// skipped if its counters moved in the meantime, and the next run settles what
+// is left.
+func (u *StatsReconcileUsecase) Reconcile(ctx context.Context, dryRun bool) (*domain.StatsReconcileReport, error) {
+	stored, err := u.Repo.StoredStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := u.Repo.CountEngagement(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.StatsReconcileReport{Checked: len(stored), DryRun: dryRun}
+	for id, s := range stored {
+		// The view total cannot be recounted, and trending is recomputed on its own
+		a := actual[id]
+		a.ViewCount, a.TrendingScore = s.ViewCount, s.TrendingScore
+		if a != s {
+			report.Drifted = append(report.Drifted, domain.StatsDrift{ArticleID: id, Stored: s, Actual: a})
+		}
+	}
+	sort.Slice(report.Drifted, func(i, j int) bool {
+		return report.Drifted[i].ArticleID < report.Drifted[j].ArticleID
+	})
+
+	if dryRun {
+		return report, nil
+	}
+	if report.Fixed, err = u.Repo.FixStats(ctx, report.Drifted); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,68 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeArticleStatsRepo struct {
+	stored map[string]domain.ArticleStats
+	actual map[string]domain.ArticleStats
+	fixed  []domain.StatsDrift
+}
+
+func (r *fakeArticleStatsRepo) StoredStats(ctx context.Context) (map[string]domain.ArticleStats, error) {
+	return r.stored, nil
+}
+func (r *fakeArticleStatsRepo) CountEngagement(ctx context.Context) (map[string]domain.ArticleStats, error) {
+	return r.actual, nil
+}
+func (r *fakeArticleStatsRepo) FixStats(ctx context.Context, drifts []domain.StatsDrift) (int, error) {
+	r.fixed = append(r.fixed, drifts...)
+	return len(drifts), nil
+}
+
+func newDriftedRepo() *fakeArticleStatsRepo {
+	return &fakeArticleStatsRepo{
+		stored: map[string]domain.ArticleStats{
+			"ok":      {ViewCount: 40, ClapCount: 3, CommentCount: 1, TrendingScore: 2.5},
+			"drifted": {ViewCount: 7, ClapCount: 9, CommentCount: 2},
+			"empty":   {ReactionCount: 1},
+		},
+		actual: map[string]domain.ArticleStats{
+			"ok":      {ClapCount: 3, CommentCount: 1},
+			"drifted": {ClapCount: 5, CommentCount: 2, ReactionCount: 4},
+		},
+	}
+}
+
+func TestStatsReconcile_FixesDrift(t *testing.T) {
+	repo := newDriftedRepo()
+	report, err := usecase.NewStatsReconcileUsecase(repo).Reconcile(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Checked)
+	require.Equal(t, 2, report.Fixed)
+	require.Len(t, report.Drifted, 2)
+
+	// Sorted by article ID; views are kept as stored
+	require.Equal(t, "drifted", report.Drifted[0].ArticleID)
+	require.Equal(t, domain.ArticleStats{ViewCount: 7, ClapCount: 5, CommentCount: 2, ReactionCount: 4}, report.Drifted[0].Actual)
+	// An article with no engagement left is reset to zero
+	require.Equal(t, "empty", report.Drifted[1].ArticleID)
+	require.Equal(t, domain.ArticleStats{}, report.Drifted[1].Actual)
+	require.Equal(t, report.Drifted, repo.fixed)
+}
+
+func TestStatsReconcile_DryRun(t *testing.T) {
+	repo := newDriftedRepo()
+	report, err := usecase.NewStatsReconcileUsecase(repo).Reconcile(context.Background(), true)
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Len(t, report.Drifted, 2)
+	require.Zero(t, report.Fixed)
+	require.Empty(t, repo.fixed)
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"write_base/internal/domain"
+)
+
+const (
+	// maxSuggestKeyRunes bounds the trie depth. Longer prefixes are answered by
+	// filtering the best matches found at that depth.
+	maxSuggestKeyRunes = 32
+	// maxSuggestKeyWords bounds how many words of a title start a key of their own.
+	maxSuggestKeyWords = 12
+)
+
+// SuggestUsecase answers type-ahead lookups from in-memory tries, one per
+// suggestion kind. Every node keeps its best MaxSuggestLimit matches, so a
+// lookup costs one walk down the prefix. Refresh rebuilds the tries.
+type SuggestUsecase struct {
+	repo domain.ISuggestRepository
+
+	mu    sync.RWMutex
+	tries map[domain.SuggestionKind]*suggestNode
+}
+
+var _ domain.ISuggestUsecase = (*SuggestUsecase)(nil)
+
+func NewSuggestUsecase(repo domain.ISuggestRepository) *SuggestUsecase {
+	return &SuggestUsecase{repo: repo, tries: map[domain.SuggestionKind]*suggestNode{}}
+}
+
+func (u *SuggestUsecase) Refresh(ctx context.Context) (int, error) {
+	candidates, err := u.repo.SuggestionCandidates(ctx)
+	if err != nil {
+		return 0, domain.ErrInternalServer
+	}
+	tries := map[domain.SuggestionKind]*suggestNode{}
+	for i := range candidates {
+		c := &candidates[i]
+		root, ok := tries[c.Kind]
+		if !ok {
+			root = newSuggestNode()
+			tries[c.Kind] = root
+		}
+		// Every word starts a key, so "tips" finds "Go tips" as well
+		words := strings.Fields(domain.NormalizeSuggestText(c.Text))
+		for w := 0; w < len(words) && w < maxSuggestKeyWords; w++ {
+			key := strings.Join(words[w:], " ")
+			root.insert(key, rankedSuggestion{suggestion: c, key: key, fromStart: w == 0})
+		}
+	}
+
+	u.mu.Lock()
+	u.tries = tries
+	u.mu.Unlock()
+	return len(candidates), nil
+}
+
+func (u *SuggestUsecase) Suggest(ctx context.Context, prefix string, limits domain.SuggestLimits) (*domain.Suggestions, error) {
+	prefix = domain.NormalizeSuggestText(prefix)
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return &domain.Suggestions{
+		Articles: u.lookup(domain.SuggestArticle, prefix, limits.Articles),
+		Tags:     u.lookup(domain.SuggestTag, prefix, limits.Tags),
+		Users:    u.lookup(domain.SuggestUser, prefix, limits.Users),
+	}, nil
+}
+
+func (u *SuggestUsecase) lookup(kind domain.SuggestionKind, prefix string, limit int) []domain.Suggestion {
+	out := []domain.Suggestion{}
+	limit = min(max(limit, 0), domain.MaxSuggestLimit)
+	root, ok := u.tries[kind]
+	if !ok || prefix == "" || limit == 0 {
+		return out
+	}
+	node := root
+	depth := 0
+	for _, r := range prefix {
+		if depth == maxSuggestKeyRunes {
+			break
+		}
+		if node = node.children[r]; node == nil {
+			return out
+		}
+		depth++
+	}
+	for _, m := range node.top {
+		if len(out) == limit {
+			break
+		}
+		if strings.HasPrefix(m.key, prefix) {
+			out = append(out, *m.suggestion)
+		}
+	}
+	return out
+}
+
+type rankedSuggestion struct {
+	suggestion *domain.Suggestion
+	// key is the normalized text the match was found under
+	key string
+	// fromStart is set when the prefix matched the start of the text
+	fromStart bool
+}
+
+// betterThan orders matches at the start of the text first, then by weight,
+// then shorter texts, then alphabetically.
+func (r rankedSuggestion) betterThan(o rankedSuggestion) bool {
+	if r.fromStart != o.fromStart {
+		return r.fromStart
+	}
+	if r.suggestion.Weight != o.suggestion.Weight {
+		return r.suggestion.Weight > o.suggestion.Weight
+	}
+	if len(r.suggestion.Text) != len(o.suggestion.Text) {
+		return len(r.suggestion.Text) < len(o.suggestion.Text)
+	}
+	return r.suggestion.Text < o.suggestion.Text
+}
+
+type suggestNode struct {
+	children map[rune]*suggestNode
+	top      []rankedSuggestion
+}
+
+func newSuggestNode() *suggestNode {
+	return &suggestNode{children: map[rune]*suggestNode{}}
+}
+
+func (n *suggestNode) insert(key string, m rankedSuggestion) {
+	node := n
+	depth := 0
+	for _, r := range key {
+		if depth == maxSuggestKeyRunes {
+			break
+		}
+		child, ok := node.children[r]
+		if !ok {
+			child = newSuggestNode()
+			node.children[r] = child
+		}
+		child.offer(m)
+		node = child
+		depth++
+	}
+}
+
+// offer keeps m if it is among the node's best matches, once per suggestion.
+func (n *suggestNode) offer(m rankedSuggestion) {
+	for i, cur := range n.top {
+		if cur.suggestion.Kind == m.suggestion.Kind && cur.suggestion.ID == m.suggestion.ID {
+			if !m.betterThan(cur) {
+				return
+			}
+			n.top = append(n.top[:i], n.top[i+1:]...)
+			break
+		}
+	}
+	if len(n.top) == domain.MaxSuggestLimit && !m.betterThan(n.top[len(n.top)-1]) {
+		return
+	}
+	i := sort.Search(len(n.top), func(i int) bool { return m.betterThan(n.top[i]) })
+	n.top = append(n.top, rankedSuggestion{})
+	copy(n.top[i+1:], n.top[i:])
+	n.top[i] = m
+	if len(n.top) > domain.MaxSuggestLimit {
+		n.top = n.top[:domain.MaxSuggestLimit]
+	}
+}
@@ -0,0 +1,112 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSuggestUC(t *testing.T, candidates ...domain.Suggestion) *usecase.SuggestUsecase {
+	t.Helper()
+	uc := usecase.NewSuggestUsecase(&mocks.SuggestRepositoryMock{
+		SuggestionCandidatesFn: func(ctx context.Context) ([]domain.Suggestion, error) { return candidates, nil },
+	})
+	n, err := uc.Refresh(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, len(candidates), n)
+	return uc
+}
+
+func suggestionIDs(s []domain.Suggestion) []string {
+	ids := make([]string, len(s))
+	for i, v := range s {
+		ids[i] = v.ID
+	}
+	return ids
+}
+
+var allKinds = domain.SuggestLimits{Articles: 5, Tags: 5, Users: 5}
+
+func TestSuggest_RanksPrefixMatches(t *testing.T) {
+	uc := newSuggestUC(t,
+		domain.Suggestion{Kind: domain.SuggestArticle, ID: "a1", Text: "Go concurrency patterns", Weight: 1},
+		domain.Suggestion{Kind: domain.SuggestArticle, ID: "a2", Text: "Going serverless", Weight: 9},
+		domain.Suggestion{Kind: domain.SuggestArticle, ID: "a3", Text: "Why Go?", Weight: 50},
+		domain.Suggestion{Kind: domain.SuggestArticle, ID: "a4", Text: "Rust traits", Weight: 100},
+		domain.Suggestion{Kind: domain.SuggestTag, ID: "t1", Text: "golang", Weight: 3},
+		domain.Suggestion{Kind: domain.SuggestUser, ID: "u1", Text: "gopher_jane"},
+	)
+
+	got, err := uc.Suggest(context.Background(), "  GO", allKinds)
+	require.NoError(t, err)
+	// Matches at the start of the title beat later words, then weight decides
+	require.Equal(t, []string{"a2", "a1", "a3"}, suggestionIDs(got.Articles))
+	require.Equal(t, []string{"t1"}, suggestionIDs(got.Tags))
+	require.Equal(t, []string{"u1"}, suggestionIDs(got.Users))
+
+	got, err = uc.Suggest(context.Background(), "go conc", allKinds)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a1"}, suggestionIDs(got.Articles))
+	require.Empty(t, got.Tags)
+}
+
+func TestSuggest_LimitsAndEmptyPrefix(t *testing.T) {
+	var candidates []domain.Suggestion
+	for i := 0; i < 15; i++ {
+		candidates = append(candidates, domain.Suggestion{Kind: domain.SuggestTag, ID: fmt.Sprint(i), Text: fmt.Sprintf("tag%02d", i), Weight: float64(i)})
+	}
+	uc := newSuggestUC(t, candidates...)
+	ctx := context.Background()
+
+	got, err := uc.Suggest(ctx, "tag", domain.SuggestLimits{Tags: 3})
+	require.NoError(t, err)
+	require.Equal(t, []string{"14", "13", "12"}, suggestionIDs(got.Tags))
+
+	got, err = uc.Suggest(ctx, "tag", domain.SuggestLimits{Tags: 50})
+	require.NoError(t, err)
+	require.Len(t, got.Tags, domain.MaxSuggestLimit)
+
+	got, err = uc.Suggest(ctx, "!!", allKinds)
+	require.NoError(t, err)
+	require.Empty(t, got.Tags)
+	require.NotNil(t, got.Tags)
+}
+
+func TestSuggest_LongPrefixBeyondTrieDepth(t *testing.T) {
+	long := "a very long article title that keeps going well past the trie depth"
+	uc := newSuggestUC(t,
+		domain.Suggestion{Kind: domain.SuggestArticle, ID: "match", Text: long},
+		domain.Suggestion{Kind: domain.SuggestArticle, ID: "other", Text: "a very long article title that keeps going somewhere else"},
+	)
+	got, err := uc.Suggest(context.Background(), long[:60], allKinds)
+	require.NoError(t, err)
+	require.Equal(t, []string{"match"}, suggestionIDs(got.Articles))
+}
+
+func TestSuggestRefresh_KeepsPreviousOnError(t *testing.T) {
+	calls := 0
+	uc := usecase.NewSuggestUsecase(&mocks.SuggestRepositoryMock{
+		SuggestionCandidatesFn: func(ctx context.Context) ([]domain.Suggestion, error) {
+			calls++
+			if calls > 1 {
+				return nil, errors.New("db down")
+			}
+			return []domain.Suggestion{{Kind: domain.SuggestUser, ID: "u1", Text: "alice"}}, nil
+		},
+	})
+	ctx := context.Background()
+	_, err := uc.Refresh(ctx)
+	require.NoError(t, err)
+	_, err = uc.Refresh(ctx)
+	require.ErrorIs(t, err, domain.ErrInternalServer)
+
+	got, err := uc.Suggest(ctx, "al", allKinds)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u1"}, suggestionIDs(got.Users))
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"write_base/internal/domain"
+)
+
+type TagFollowUsecase struct {
+	Tags    domain.TagRepository
+	Follows domain.ITagFollowRepository
+	Now     func() time.Time
+}
+
+var _ domain.ITagFollowUsecase = (*TagFollowUsecase)(nil)
+
+func NewTagFollowUsecase(tags domain.TagRepository, follows domain.ITagFollowRepository) *TagFollowUsecase {
+	return &TagFollowUsecase{Tags: tags, Follows: follows, Now: time.Now}
+}
+
+func (u *TagFollowUsecase) FollowTag(ctx context.Context, userID, tagID string) error {
+	if userID == "" {
+		return domain.ErrUnauthorized
+	}
+	tag, err := u.Tags.GetByID(ctx, tagID)
+	if err != nil {
+		return err
+	}
+	if tag.Status != domain.TagStatusApproved {
+		return domain.ErrTagNotApproved
+	}
+	err = u.Follows.Follow(ctx, &domain.TagFollow{UserID: userID, TagID: tag.ID, TagName: tag.Name, CreatedAt: u.Now()})
+	if err != nil && err != domain.ErrAlreadyFollowingTag {
+		return domain.ErrInternalServer
+	}
+	return err
+}
+
+func (u *TagFollowUsecase) UnfollowTag(ctx context.Context, userID, tagID string) error {
+	if userID == "" {
+		return domain.ErrUnauthorized
+	}
+	err := u.Follows.Unfollow(ctx, userID, tagID)
+	if err != nil && err != domain.ErrTagFollowNotFound {
+		return domain.ErrInternalServer
+	}
+	return err
+}
+
+func (u *TagFollowUsecase) ListFollowedTags(ctx context.Context, userID string) ([]domain.FollowedTag, error) {
+	follows, err := u.Follows.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	ids := make([]string, len(follows))
+	for i, f := range follows {
+		ids[i] = f.TagID
+	}
+	counts, err := u.Follows.FollowerCounts(ctx, ids)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	tags := make([]domain.FollowedTag, 0, len(follows))
+	for _, f := range follows {
+		tags = append(tags, domain.FollowedTag{TagID: f.TagID, Name: f.TagName, Followers: counts[f.TagID], FollowedAt: f.CreatedAt})
+	}
+	return tags, nil
+}
+
+func (u *TagFollowUsecase) FollowerCount(ctx context.Context, tagID string) (int, error) {
+	if _, err := u.Tags.GetByID(ctx, tagID); err != nil {
+		return 0, err
+	}
+	counts, err := u.Follows.FollowerCounts(ctx, []string{tagID})
+	if err != nil {
+		return 0, domain.ErrInternalServer
+	}
+	return counts[tagID], nil
+}
+
+func (u *TagFollowUsecase) FollowedTagNames(ctx context.Context, userID string) ([]string, error) {
+	follows, err := u.Follows.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	names := make([]string, len(follows))
+	for i, f := range follows {
+		names[i] = f.TagName
+	}
+	return names, nil
+}
+
+func (u *TagFollowUsecase) FollowersOfTags(ctx context.Context, tagNames []string) ([]string, error) {
+	ids, err := u.Follows.FollowerIDs(ctx, tagNames)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	return ids, nil
+}
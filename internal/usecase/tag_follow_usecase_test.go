@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func tagStore(tags ...domain.Tag) *mocks.TagRepositoryMock {
+	return &mocks.TagRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Tag, error) {
+			for _, t := range tags {
+				if t.ID == id {
+					return &t, nil
+				}
+			}
+			return nil, domain.ErrTagNotFound
+		},
+	}
+}
+
+func TestFollowTag(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	var saved *domain.TagFollow
+	follows := &mocks.TagFollowRepositoryMock{
+		FollowFn: func(ctx context.Context, f *domain.TagFollow) error {
+			if saved != nil {
+				return domain.ErrAlreadyFollowingTag
+			}
+			saved = f
+			return nil
+		},
+	}
+	uc := usecase.NewTagFollowUsecase(tagStore(
+		domain.Tag{ID: "t1", Name: "go", Status: domain.TagStatusApproved},
+		domain.Tag{ID: "t2", Name: "pending", Status: domain.TagStatusPending},
+	), follows)
+	uc.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, uc.FollowTag(ctx, "u1", "t1"))
+	require.Equal(t, &domain.TagFollow{UserID: "u1", TagID: "t1", TagName: "go", CreatedAt: now}, saved)
+	require.ErrorIs(t, uc.FollowTag(ctx, "u1", "t1"), domain.ErrAlreadyFollowingTag)
+	require.ErrorIs(t, uc.FollowTag(ctx, "u1", "t2"), domain.ErrTagNotApproved)
+	require.ErrorIs(t, uc.FollowTag(ctx, "u1", "nope"), domain.ErrTagNotFound)
+	require.ErrorIs(t, uc.FollowTag(ctx, "", "t1"), domain.ErrUnauthorized)
+
+	follows.UnfollowFn = func(ctx context.Context, userID, tagID string) error { return errors.New("db down") }
+	require.ErrorIs(t, uc.UnfollowTag(ctx, "u1", "t1"), domain.ErrInternalServer)
+}
+
+func TestListFollowedTags_WithCounts(t *testing.T) {
+	follows := &mocks.TagFollowRepositoryMock{
+		ListByUserFn: func(ctx context.Context, userID string) ([]domain.TagFollow, error) {
+			return []domain.TagFollow{{UserID: userID, TagID: "t1", TagName: "go"}, {UserID: userID, TagID: "t2", TagName: "rust"}}, nil
+		},
+		FollowerCountsFn: func(ctx context.Context, ids []string) (map[string]int, error) {
+			counts := map[string]int{}
+			for _, id := range ids {
+				if id == "t1" {
+					counts[id] = 42
+				}
+			}
+			return counts, nil
+		},
+	}
+	uc := usecase.NewTagFollowUsecase(tagStore(domain.Tag{ID: "t1"}), follows)
+	ctx := context.Background()
+
+	tags, err := uc.ListFollowedTags(ctx, "u1")
+	require.NoError(t, err)
+	require.Equal(t, []domain.FollowedTag{{TagID: "t1", Name: "go", Followers: 42}, {TagID: "t2", Name: "rust"}}, tags)
+
+	names, err := uc.FollowedTagNames(ctx, "u1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "rust"}, names)
+
+	n, err := uc.FollowerCount(ctx, "t1")
+	require.NoError(t, err)
+	require.Equal(t, 42, n)
+	_, err = uc.FollowerCount(ctx, "missing")
+	require.ErrorIs(t, err, domain.ErrTagNotFound)
+}
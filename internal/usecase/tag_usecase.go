@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"log"
 	"strings"
 	"write_base/internal/domain"
 
@@ -10,10 +11,14 @@ import (
 type TagUsecaseImpl struct {
 	tagRepo domain.TagRepository
 	utils domain.IUtils
+	// Merging rewrites articles, moves follows and refreshes the search index
+	articleRepo domain.IArticleRepository
+	tagFollows  domain.ITagFollowRepository
+	searchIndex domain.ISearchIndex
 }
 
-func NewTagUsecase(tagRepo domain.TagRepository,utils domain.IUtils) domain.TagUsecase {
-	return &TagUsecaseImpl{tagRepo: tagRepo,utils:utils}
+func NewTagUsecase(tagRepo domain.TagRepository,utils domain.IUtils, articleRepo domain.IArticleRepository, tagFollows domain.ITagFollowRepository, searchIndex domain.ISearchIndex) domain.TagUsecase {
+	return &TagUsecaseImpl{tagRepo: tagRepo,utils:utils, articleRepo: articleRepo, tagFollows: tagFollows, searchIndex: searchIndex}
 }
 
 func (uc *TagUsecaseImpl) CreateTag(ctx context.Context, userID string, name string) (*domain.Tag, error) {
@@ -27,6 +32,9 @@ func (uc *TagUsecaseImpl) CreateTag(ctx context.Context, userID string, name str
 	if existing != nil {
 		return nil, domain.ErrTagAlreadyExists
 	}
+	if synonymOf, _ := uc.tagRepo.GetBySynonym(ctx, name); synonymOf != nil {
+		return nil, domain.ErrTagAlreadyExists
+	}
 
 	tagID := uc.utils.GenerateUUID()
 
@@ -66,13 +74,13 @@ func (uc *TagUsecaseImpl) DeleteTag(ctx context.Context, tagID string) error {
 }
 
 func (uc *TagUsecaseImpl) IsTagApproved(name string) bool {
-	tag, err := uc.tagRepo.GetByName(context.Background(), name)
+	tag, err := uc.resolve(context.Background(), name)
 	return err == nil && tag.Status == domain.TagStatusApproved
 }
 
 func (uc *TagUsecaseImpl) ValidateTags(tags []string) error {
 	for _, tag := range tags {
-		tagStatus, err := uc.tagRepo.GetByName(context.Background(), tag)
+		tagStatus, err := uc.resolve(context.Background(), tag)
 		if err != nil {
 			return domain.ErrTagNotFound
 		}
@@ -81,4 +89,236 @@ func (uc *TagUsecaseImpl) ValidateTags(tags []string) error {
 		}
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// resolve finds the tag a name refers to, by its own name or one of its synonyms.
+func (uc *TagUsecaseImpl) resolve(ctx context.Context, name string) (*domain.Tag, error) {
+	tag, err := uc.tagRepo.GetByName(ctx, name)
+	if err == nil {
+		return tag, nil
+	}
+	if err != domain.ErrTagNotFound {
+		return nil, err
+	}
+	return uc.tagRepo.GetBySynonym(ctx, name)
+}
+
+// CanonicalTags keeps unknown names as given so ValidateTags stays the one
+// place that rejects them.
+func (uc *TagUsecaseImpl) CanonicalTags(tags []string) []string {
+	out := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, name := range tags {
+		if tag, err := uc.resolve(context.Background(), name); err == nil {
+			name = tag.Name
+		}
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func (uc *TagUsecaseImpl) ExpandTags(tags []string) []string {
+	ctx := context.Background()
+	out := uc.CanonicalTags(tags)
+	seen := map[string]bool{}
+	for _, name := range out {
+		seen[name] = true
+	}
+
+	var queue []string
+	for _, name := range out {
+		if tag, err := uc.tagRepo.GetByName(ctx, name); err == nil {
+			queue = append(queue, tag.ID)
+		}
+	}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		children, err := uc.tagRepo.List(ctx, domain.TagFilter{Status: domain.TagStatusApproved, ParentID: id})
+		if err != nil {
+			log.Printf("listing child tags of %s failed: %v", id, err)
+			continue
+		}
+		for _, child := range children {
+			if !seen[child.Name] {
+				seen[child.Name] = true
+				out = append(out, child.Name)
+			}
+			queue = append(queue, child.ID)
+		}
+	}
+	return out
+}
+
+//=============================================================================//
+//                      Synonyms and Hierarchy (admin)                         //
+//=============================================================================//
+
+func (uc *TagUsecaseImpl) AddSynonym(ctx context.Context, tagID, synonym string) (*domain.Tag, error) {
+	synonym = domain.NormalizeSynonym(synonym)
+	if synonym == "" {
+		return nil, domain.ErrInvalidTagName
+	}
+	tag, err := uc.tagRepo.GetByID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	if synonym == domain.NormalizeSynonym(tag.Name) {
+		return tag, nil
+	}
+	for _, s := range tag.Synonyms {
+		if s == synonym {
+			return tag, nil
+		}
+	}
+	if other, err := uc.resolve(ctx, synonym); err == nil && other.ID != tag.ID {
+		return nil, domain.ErrSynonymInUse
+	}
+	tag.Synonyms = append(tag.Synonyms, synonym)
+	return uc.tagRepo.Update(ctx, tag)
+}
+
+func (uc *TagUsecaseImpl) RemoveSynonym(ctx context.Context, tagID, synonym string) (*domain.Tag, error) {
+	synonym = domain.NormalizeSynonym(synonym)
+	tag, err := uc.tagRepo.GetByID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]string, 0, len(tag.Synonyms))
+	for _, s := range tag.Synonyms {
+		if s != synonym {
+			kept = append(kept, s)
+		}
+	}
+	tag.Synonyms = kept
+	return uc.tagRepo.Update(ctx, tag)
+}
+
+// SetParent nests the tag under parentID; an empty parentID makes it top-level.
+func (uc *TagUsecaseImpl) SetParent(ctx context.Context, tagID, parentID string) (*domain.Tag, error) {
+	tag, err := uc.tagRepo.GetByID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	// Walk up from the new parent; meeting the tag itself would close a loop
+	visited := map[string]bool{}
+	for id := parentID; id != ""; {
+		if id == tag.ID {
+			return nil, domain.ErrTagHierarchyCycle
+		}
+		if visited[id] {
+			break
+		}
+		visited[id] = true
+		ancestor, err := uc.tagRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		id = ancestor.ParentID
+	}
+	tag.ParentID = parentID
+	return uc.tagRepo.Update(ctx, tag)
+}
+
+//=============================================================================//
+//                               Merge (admin)                                 //
+//=============================================================================//
+
+// MergeTags folds source into target: articles and followers move over, the
+// source name and synonyms become target synonyms, its children are re-parented
+// and the source tag is deleted.
+func (uc *TagUsecaseImpl) MergeTags(ctx context.Context, sourceID, targetID string) (*domain.TagMergeResult, error) {
+	if sourceID == targetID {
+		return nil, domain.ErrTagMergeSelf
+	}
+	source, err := uc.tagRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := uc.tagRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Status != domain.TagStatusApproved {
+		return nil, domain.ErrTagMergeTarget
+	}
+
+	articleIDs, err := uc.articleRepo.ReplaceTag(ctx, source.Name, target.Name)
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	uc.reindexArticles(ctx, articleIDs)
+
+	if err := uc.tagFollows.MoveFollows(ctx, source.ID, target.ID, target.Name); err != nil {
+		return nil, domain.ErrInternalServer
+	}
+
+	children, err := uc.tagRepo.List(ctx, domain.TagFilter{ParentID: source.ID})
+	if err != nil {
+		return nil, err
+	}
+	for i := range children {
+		child := children[i]
+		if child.ID == target.ID {
+			// The target takes the source's place in the hierarchy
+			target.ParentID = source.ParentID
+			continue
+		}
+		child.ParentID = target.ID
+		if _, err := uc.tagRepo.Update(ctx, &child); err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := append([]string{domain.NormalizeSynonym(source.Name)}, source.Synonyms...)
+	seen := map[string]bool{domain.NormalizeSynonym(target.Name): true}
+	for _, s := range target.Synonyms {
+		seen[s] = true
+	}
+	for _, s := range candidates {
+		if !seen[s] {
+			seen[s] = true
+			target.Synonyms = append(target.Synonyms, s)
+		}
+	}
+	if _, err := uc.tagRepo.Update(ctx, target); err != nil {
+		return nil, err
+	}
+	if err := uc.tagRepo.Delete(ctx, source.ID); err != nil {
+		return nil, err
+	}
+	return &domain.TagMergeResult{Tag: *target, ArticleIDs: articleIDs}, nil
+}
+
+// reindexArticles refreshes search documents for rewritten published articles.
+// As with article writes, index failures are logged and never fail the merge.
+func (uc *TagUsecaseImpl) reindexArticles(ctx context.Context, articleIDs []string) {
+	if uc.searchIndex == nil || len(articleIDs) == 0 {
+		return
+	}
+	articles, err := uc.articleRepo.GetByIDs(ctx, articleIDs)
+	if err != nil {
+		log.Printf("search index update after tag merge failed: %v", err)
+		return
+	}
+	var published []domain.Article
+	for _, a := range articles {
+		if a.Status == domain.StatusPublished {
+			published = append(published, a)
+		}
+	}
+	if len(published) == 0 {
+		return
+	}
+	if err := uc.searchIndex.Index(ctx, published...); err != nil {
+		log.Printf("search index update after tag merge failed: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memTags is a TagRepositoryMock backed by a map so updates are visible to later reads.
+func memTags(tags ...domain.Tag) (*mocks.TagRepositoryMock, map[string]*domain.Tag) {
+	byID := map[string]*domain.Tag{}
+	for i := range tags {
+		t := tags[i]
+		byID[t.ID] = &t
+	}
+	copyOf := func(t *domain.Tag) *domain.Tag {
+		c := *t
+		c.Synonyms = append([]string(nil), t.Synonyms...)
+		return &c
+	}
+	repo := &mocks.TagRepositoryMock{
+		GetByIDFn: func(ctx context.Context, id string) (*domain.Tag, error) {
+			if t, ok := byID[id]; ok {
+				return copyOf(t), nil
+			}
+			return nil, domain.ErrTagNotFound
+		},
+		GetByNameFn: func(ctx context.Context, name string) (*domain.Tag, error) {
+			for _, t := range byID {
+				if t.Name == name {
+					return copyOf(t), nil
+				}
+			}
+			return nil, domain.ErrTagNotFound
+		},
+		GetBySynonymFn: func(ctx context.Context, synonym string) (*domain.Tag, error) {
+			for _, t := range byID {
+				for _, s := range t.Synonyms {
+					if s == domain.NormalizeSynonym(synonym) {
+						return copyOf(t), nil
+					}
+				}
+			}
+			return nil, domain.ErrTagNotFound
+		},
+		ListFn: func(ctx context.Context, f domain.TagFilter) ([]domain.Tag, error) {
+			var out []domain.Tag
+			for _, t := range byID {
+				if (f.Status == "" || t.Status == f.Status) && (f.ParentID == "" || t.ParentID == f.ParentID) {
+					out = append(out, *copyOf(t))
+				}
+			}
+			return out, nil
+		},
+		UpdateFn: func(ctx context.Context, t *domain.Tag) (*domain.Tag, error) {
+			byID[t.ID] = copyOf(t)
+			return t, nil
+		},
+		DeleteFn: func(ctx context.Context, id string) error {
+			delete(byID, id)
+			return nil
+		},
+	}
+	return repo, byID
+}
+
+func TestTagSynonymsResolveToCanonicalTag(t *testing.T) {
+	repo, _ := memTags(
+		domain.Tag{ID: "t1", Name: "golang", Status: domain.TagStatusApproved, Synonyms: []string{"go"}},
+		domain.Tag{ID: "t2", Name: "rust", Status: domain.TagStatusApproved},
+	)
+	uc := usecase.NewTagUsecase(repo, nil, &mocks.ArticleRepositoryMock{}, &mocks.TagFollowRepositoryMock{}, nil)
+	ctx := context.Background()
+
+	require.True(t, uc.IsTagApproved("Go"))
+	require.NoError(t, uc.ValidateTags([]string{"go", "rust"}))
+	require.ErrorIs(t, uc.ValidateTags([]string{"zig"}), domain.ErrTagNotFound)
+	require.Equal(t, []string{"golang", "rust", "zig"}, uc.CanonicalTags([]string{"go", "golang", "rust", "zig"}))
+
+	_, err := uc.AddSynonym(ctx, "t2", " GO ")
+	require.ErrorIs(t, err, domain.ErrSynonymInUse)
+	_, err = uc.AddSynonym(ctx, "t2", "golang")
+	require.ErrorIs(t, err, domain.ErrSynonymInUse)
+	tag, err := uc.AddSynonym(ctx, "t2", "Rust-Lang")
+	require.NoError(t, err)
+	require.Equal(t, []string{"rust-lang"}, tag.Synonyms)
+	require.True(t, uc.IsTagApproved("rust-lang"))
+
+	tag, err = uc.RemoveSynonym(ctx, "t2", "rust-lang")
+	require.NoError(t, err)
+	require.Empty(t, tag.Synonyms)
+	require.False(t, uc.IsTagApproved("rust-lang"))
+}
+
+func TestTagHierarchy(t *testing.T) {
+	repo, _ := memTags(
+		domain.Tag{ID: "p", Name: "programming", Status: domain.TagStatusApproved},
+		domain.Tag{ID: "c1", Name: "golang", Status: domain.TagStatusApproved, ParentID: "p", Synonyms: []string{"go"}},
+		domain.Tag{ID: "c2", Name: "generics", Status: domain.TagStatusApproved, ParentID: "c1"},
+		domain.Tag{ID: "c3", Name: "draft-lang", Status: domain.TagStatusPending, ParentID: "p"},
+	)
+	uc := usecase.NewTagUsecase(repo, nil, &mocks.ArticleRepositoryMock{}, &mocks.TagFollowRepositoryMock{}, nil)
+	ctx := context.Background()
+
+	require.ElementsMatch(t, []string{"programming", "golang", "generics"}, uc.ExpandTags([]string{"programming"}))
+	require.ElementsMatch(t, []string{"golang", "generics"}, uc.ExpandTags([]string{"go"}))
+
+	_, err := uc.SetParent(ctx, "p", "c2")
+	require.ErrorIs(t, err, domain.ErrTagHierarchyCycle)
+	_, err = uc.SetParent(ctx, "p", "p")
+	require.ErrorIs(t, err, domain.ErrTagHierarchyCycle)
+	_, err = uc.SetParent(ctx, "c2", "missing")
+	require.ErrorIs(t, err, domain.ErrTagNotFound)
+
+	tag, err := uc.SetParent(ctx, "c2", "")
+	require.NoError(t, err)
+	require.Empty(t, tag.ParentID)
+	require.ElementsMatch(t, []string{"programming", "golang"}, uc.ExpandTags([]string{"programming"}))
+}
+
+func TestMergeTags(t *testing.T) {
+	repo, byID := memTags(
+		domain.Tag{ID: "src", Name: "Golang", Status: domain.TagStatusApproved, Synonyms: []string{"go-lang"}, ParentID: "prog"},
+		domain.Tag{ID: "dst", Name: "go", Status: domain.TagStatusApproved, ParentID: "src"},
+		domain.Tag{ID: "kid", Name: "goroutines", Status: domain.TagStatusApproved, ParentID: "src"},
+		domain.Tag{ID: "prog", Name: "programming", Status: domain.TagStatusApproved},
+		domain.Tag{ID: "new", Name: "pending", Status: domain.TagStatusPending},
+	)
+	var replaced [2]string
+	var moved [3]string
+	var indexed []string
+	articles := &mocks.ArticleRepositoryMock{
+		ReplaceTagFn: func(ctx context.Context, from, to string) ([]string, error) {
+			replaced = [2]string{from, to}
+			return []string{"a1", "a2"}, nil
+		},
+		GetByIDsFn: func(ctx context.Context, ids []string) ([]domain.Article, error) {
+			return []domain.Article{
+				{ID: "a1", Status: domain.StatusPublished},
+				{ID: "a2", Status: domain.StatusDraft},
+			}, nil
+		},
+	}
+	follows := &mocks.TagFollowRepositoryMock{
+		MoveFollowsFn: func(ctx context.Context, from, to, name string) error {
+			moved = [3]string{from, to, name}
+			return nil
+		},
+	}
+	index := &mocks.SearchIndexMock{
+		IndexFn: func(ctx context.Context, articles ...domain.Article) error {
+			for _, a := range articles {
+				indexed = append(indexed, a.ID)
+			}
+			return nil
+		},
+	}
+	uc := usecase.NewTagUsecase(repo, nil, articles, follows, index)
+	ctx := context.Background()
+
+	_, err := uc.MergeTags(ctx, "src", "src")
+	require.ErrorIs(t, err, domain.ErrTagMergeSelf)
+	_, err = uc.MergeTags(ctx, "src", "new")
+	require.ErrorIs(t, err, domain.ErrTagMergeTarget)
+	_, err = uc.MergeTags(ctx, "src", "missing")
+	require.ErrorIs(t, err, domain.ErrTagNotFound)
+
+	result, err := uc.MergeTags(ctx, "src", "dst")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a1", "a2"}, result.ArticleIDs)
+	require.Equal(t, [2]string{"Golang", "go"}, replaced)
+	require.Equal(t, [3]string{"src", "dst", "go"}, moved)
+	require.Equal(t, []string{"a1"}, indexed)
+
+	require.NotContains(t, byID, "src")
+	require.Equal(t, []string{"golang", "go-lang"}, byID["dst"].Synonyms)
+	require.Equal(t, "prog", byID["dst"].ParentID)
+	require.Equal(t, "dst", byID["kid"].ParentID)
+	require.True(t, uc.IsTagApproved("Golang"))
+	require.Equal(t, []string{"go"}, uc.CanonicalTags([]string{"golang", "go"}))
+}
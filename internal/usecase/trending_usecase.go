@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+	"write_base/internal/domain"
+)
+
+type TrendingUsecase struct {
+	Repo   domain.ITrendingRepository
+	Config domain.TrendingConfig
+	Now    func() time.Time
+}
+
+func NewTrendingUsecase(repo domain.ITrendingRepository, cfg domain.TrendingConfig) *TrendingUsecase {
+	return &TrendingUsecase{Repo: repo, Config: cfg, Now: time.Now}
+}
+
+// RecomputeScores sums the weighted engagement of every article, decaying each
+// hourly bucket exponentially with its age.
+func (u *TrendingUsecase) RecomputeScores(ctx context.Context) (int, error) {
+	now := u.Now()
+	buckets, err := u.Repo.EngagementSince(ctx, now.Add(-u.Config.Window))
+	if err != nil {
+		return 0, err
+	}
+
+	scores := make(map[string]float64)
+	for _, b := range buckets {
+		if b.ArticleID == "" || b.Count <= 0 {
+			continue
+		}
+		scores[b.ArticleID] += u.Config.Weight(b.Kind) * float64(b.Count) * u.decay(now.Sub(b.Hour))
+	}
+	for id, score := range scores {
+		if score <= 0 {
+			delete(scores, id)
+		}
+	}
+
+	if err := u.Repo.SaveTrendingScores(ctx, scores); err != nil {
+		return 0, err
+	}
+	return len(scores), nil
+}
+
+// decay is 1 for fresh engagement and halves every HalfLife.
+func (u *TrendingUsecase) decay(age time.Duration) float64 {
+	if age < 0 || u.Config.HalfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(u.Config.HalfLife))
+}
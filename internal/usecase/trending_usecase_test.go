@@ -0,0 +1,85 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testTrendingConfig = domain.TrendingConfig{
+	Window:         7 * 24 * time.Hour,
+	HalfLife:       24 * time.Hour,
+	ViewWeight:     1,
+	ClapWeight:     2,
+	CommentWeight:  5,
+	ReactionWeight: 3,
+}
+
+func TestRecomputeScores_WeightsAndDecay(t *testing.T) {
+	now := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+	var since time.Time
+	var saved map[string]float64
+	repo := &mocks.TrendingRepositoryMock{
+		EngagementSinceFn: func(ctx context.Context, s time.Time) ([]domain.EngagementBucket, error) {
+			since = s
+			return []domain.EngagementBucket{
+				{ArticleID: "fresh", Kind: domain.EngagementView, Hour: now, Count: 10},
+				{ArticleID: "fresh", Kind: domain.EngagementComment, Hour: now, Count: 2},
+				{ArticleID: "old", Kind: domain.EngagementView, Hour: now.Add(-48 * time.Hour), Count: 40},
+				{ArticleID: "old", Kind: domain.EngagementReaction, Hour: now.Add(-24 * time.Hour), Count: 2},
+				{ArticleID: "", Kind: domain.EngagementView, Hour: now, Count: 5},
+			}, nil
+		},
+		SaveTrendingScoresFn: func(ctx context.Context, scores map[string]float64) error {
+			saved = scores
+			return nil
+		},
+	}
+	uc := usecase.NewTrendingUsecase(repo, testTrendingConfig)
+	uc.Now = func() time.Time { return now }
+
+	n, err := uc.RecomputeScores(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, now.Add(-7*24*time.Hour), since)
+	require.InDelta(t, 10+2*5, saved["fresh"], 1e-9)
+	// 40 views two half-lives ago and 2 reactions one half-life ago
+	require.InDelta(t, 40*0.25+2*3*0.5, saved["old"], 1e-9)
+	require.Greater(t, saved["fresh"], saved["old"])
+}
+
+func TestRecomputeScores_ZeroWeightDropsArticle(t *testing.T) {
+	cfg := testTrendingConfig
+	cfg.ViewWeight = 0
+	var saved map[string]float64
+	repo := &mocks.TrendingRepositoryMock{
+		EngagementSinceFn: func(ctx context.Context, s time.Time) ([]domain.EngagementBucket, error) {
+			return []domain.EngagementBucket{{ArticleID: "a1", Kind: domain.EngagementView, Hour: time.Now(), Count: 3}}, nil
+		},
+		SaveTrendingScoresFn: func(ctx context.Context, scores map[string]float64) error { saved = scores; return nil },
+	}
+	n, err := usecase.NewTrendingUsecase(repo, cfg).RecomputeScores(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Empty(t, saved)
+}
+
+func TestRecomputeScores_RepoError(t *testing.T) {
+	repo := &mocks.TrendingRepositoryMock{
+		EngagementSinceFn: func(ctx context.Context, s time.Time) ([]domain.EngagementBucket, error) {
+			return nil, errors.New("db down")
+		},
+		SaveTrendingScoresFn: func(ctx context.Context, scores map[string]float64) error {
+			t.Fatal("scores must not be saved when aggregation fails")
+			return nil
+		},
+	}
+	_, err := usecase.NewTrendingUsecase(repo, testTrendingConfig).RecomputeScores(context.Background())
+	require.Error(t, err)
+}
@@ -15,10 +15,12 @@ type UserUsercase struct {
 	passwordService domain.IPasswordService
 	tokenService    domain.ITokenService
 	emailService    domain.IEmailService
+	moderation      domain.IModerationService
+	tagFollows      domain.ITagFollowRepository
 }
 
-func NewUserUsecase(repo domain.IUserRepository,pass domain.IPasswordService, tk domain.ITokenService , em domain.IEmailService) domain.IUserUsecase{
-	return &UserUsercase{userRepo: repo, passwordService: pass, tokenService: tk, emailService: em}
+func NewUserUsecase(repo domain.IUserRepository,pass domain.IPasswordService, tk domain.ITokenService , em domain.IEmailService, moderation domain.IModerationService, tagFollows domain.ITagFollowRepository) domain.IUserUsecase{
+	return &UserUsercase{userRepo: repo, passwordService: pass, tokenService: tk, emailService: em, moderation: moderation, tagFollows: tagFollows}
 }
 
 func (uu *UserUsercase) Register(ctx context.Context, req *domain.RegisterInput) error {
@@ -307,6 +309,17 @@ func(uu *UserUsercase) GetProfile(ctx context.Context, userID string) (*domain.U
 	if err != nil{
 		return nil, domain.ErrUserNotFound
 	}
+	if uu.tagFollows != nil {
+		// The profile still loads if followed tags cannot be read
+		if follows, err := uu.tagFollows.ListByUser(ctx, userID); err == nil {
+			user.FollowedTags = make([]string, len(follows))
+			for i, f := range follows {
+				user.FollowedTags[i] = f.TagName
+			}
+		} else {
+			log.Printf("loading followed tags of user %s failed: %v", userID, err)
+		}
+	}
 	return user, nil
 }
 
@@ -315,6 +328,16 @@ func(uu *UserUsercase) UpdateProfile(ctx context.Context, updateProfileInput *do
     if err != nil {
         return domain.ErrUserNotFound
     }
+	verdict := &domain.ModerationVerdict{Action: domain.ModerationAllow}
+	if uu.moderation != nil && updateProfileInput.Bio != user.Bio {
+		verdict, err = uu.moderation.Moderate(ctx, domain.ContentKindProfileBio, updateProfileInput.Bio)
+		if err != nil {
+			return domain.ErrUserUpdateFailed
+		}
+		if verdict.Action == domain.ModerationBlock {
+			return domain.ErrContentBlocked
+		}
+	}
 	user.Bio = updateProfileInput.Bio
 	user.ProfileImage = updateProfileInput.ProfileImage
 	user.UpdatedAt = time.Now()
@@ -322,6 +345,9 @@ func(uu *UserUsercase) UpdateProfile(ctx context.Context, updateProfileInput *do
     if err != nil {
         return domain.ErrUserUpdateFailed
     }
+	if verdict.Action == domain.ModerationFlag {
+		_ = uu.moderation.FlagForReview(ctx, domain.ContentKindProfileBio, user.ID, verdict)
+	}
     return nil
 }
 
@@ -2,24 +2,48 @@ package usecase
 
 import (
 	"context"
+	"time"
 	"write_base/internal/domain"
 )
 
 type ViewUsecaseImpl struct {
 	viewRepo domain.ViewRepository
-	utils domain.IUtils
+	articles domain.IArticleRepository
+	utils    domain.IUtils
+	window   time.Duration
 }
 
-func NewViewUsecase(viewRepo domain.ViewRepository, u domain.IUtils) domain.ViewUsecase {
-	return &ViewUsecaseImpl{viewRepo: viewRepo, utils: u}
+// NewViewUsecase counts a reader's views of an article at most once per window.
+func NewViewUsecase(viewRepo domain.ViewRepository, articles domain.IArticleRepository, u domain.IUtils, window time.Duration) domain.ViewUsecase {
+	return &ViewUsecaseImpl{viewRepo: viewRepo, articles: articles, utils: u, window: window}
 }
 
-func (uc *ViewUsecaseImpl) RecordView(ctx context.Context, userID, articleID, clientIP string) error {
+func (uc *ViewUsecaseImpl) RecordView(ctx context.Context, article *domain.Article, reader domain.ViewReader) error {
+	if reader.UserID != "" && reader.UserID == article.AuthorID {
+		return nil
+	}
+	if reader.IsBot() {
+		return nil
+	}
+	key := reader.Key()
+	counted, first, err := uc.viewRepo.TrackRead(ctx, article.ID, key, uc.window)
+	if err != nil || !counted {
+		return err
+	}
+
+	if _, err := uc.articles.IncrementStat(ctx, article.ID, domain.StatViews, 1); err != nil {
+		return err
+	}
+	if first {
+		if _, err := uc.articles.IncrementStat(ctx, article.ID, domain.StatUniqueViews, 1); err != nil {
+			return err
+		}
+	}
 	view := &domain.View{
-		ID: uc.utils.GenerateUUID(),
-		UserID:    userID,
-		ArticleID: articleID,
-		ClientIP:  clientIP,
+		ID:        uc.utils.GenerateUUID(),
+		UserID:    reader.UserID,
+		ArticleID: article.ID,
+		ReaderKey: key,
 	}
 	return uc.viewRepo.Create(ctx, view)
-}
\ No newline at end of file
+}
@@ -0,0 +1,73 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"write_base/internal/domain"
+	"write_base/internal/mocks"
+	"write_base/internal/usecase"
+
+	"github.com/stretchr/testify/require"
+)
+
+// windowViewRepo mirrors the reader records: one last-counted time per reader.
+type windowViewRepo struct {
+	now     time.Time
+	readers map[string]time.Time
+	logged  []domain.View
+}
+
+func (r *windowViewRepo) TrackRead(ctx context.Context, articleID, readerKey string, window time.Duration) (bool, bool, error) {
+	id := articleID + "|" + readerKey
+	last, seen := r.readers[id]
+	if seen && r.now.Sub(last) < window {
+		return false, false, nil
+	}
+	r.readers[id] = r.now
+	return true, !seen, nil
+}
+func (r *windowViewRepo) Create(ctx context.Context, view *domain.View) error {
+	r.logged = append(r.logged, *view)
+	return nil
+}
+
+func TestViewUsecase_CountsOncePerWindow(t *testing.T) {
+	repo := &windowViewRepo{now: time.Now(), readers: map[string]time.Time{}}
+	stats := map[domain.StatCounter]int{}
+	articles := &mocks.ArticleRepositoryMock{IncrementStatFn: func(ctx context.Context, id string, counter domain.StatCounter, delta int) (*domain.ArticleStats, error) {
+		stats[counter] += delta
+		return &domain.ArticleStats{}, nil
+	}}
+	uc := usecase.NewViewUsecase(repo, articles, &mocks.UtilsMock{}, 30*time.Minute)
+	article := &domain.Article{ID: "a1", AuthorID: "writer", Status: domain.StatusPublished}
+	ctx := context.Background()
+	browser := "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0"
+
+	read := func(reader domain.ViewReader) {
+		require.NoError(t, uc.RecordView(ctx, article, reader))
+	}
+	// Refreshes within the window count once
+	read(domain.ViewReader{UserID: "u1"})
+	read(domain.ViewReader{UserID: "u1"})
+	read(domain.ViewReader{ClientIP: "203.0.113.7", UserAgent: browser})
+	read(domain.ViewReader{ClientIP: "203.0.113.7", UserAgent: browser})
+	// The author, crawlers and scripts are not counted
+	read(domain.ViewReader{UserID: "writer"})
+	read(domain.ViewReader{ClientIP: "66.249.66.1", UserAgent: "Mozilla/5.0 (compatible; Googlebot/2.1)"})
+	read(domain.ViewReader{ClientIP: "198.51.100.2"})
+	require.Equal(t, 2, stats[domain.StatViews])
+	require.Equal(t, 2, stats[domain.StatUniqueViews])
+
+	// A returning reader counts as a view again, but not as a new reader
+	repo.now = repo.now.Add(31 * time.Minute)
+	read(domain.ViewReader{UserID: "u1"})
+	require.Equal(t, 3, stats[domain.StatViews])
+	require.Equal(t, 2, stats[domain.StatUniqueViews])
+
+	// Only counted views are logged, without the raw IP
+	require.Len(t, repo.logged, 3)
+	for _, v := range repo.logged {
+		require.NotContains(t, v.ReaderKey, "203.0.113.7")
+	}
+}
@@ -19,6 +19,9 @@ import (
 	"write_base/internal/domain"
 	"write_base/internal/infrastructure"
 	"write_base/internal/infrastructure/ai"
+	"write_base/internal/infrastructure/moderation"
+	"write_base/internal/infrastructure/realtime"
+	"write_base/internal/infrastructure/search"
 	"write_base/internal/infrastructure/utils"
 	"write_base/internal/policy"
 	"write_base/internal/repository"
@@ -27,6 +30,8 @@ import (
 	usecaseai "write_base/internal/usecase/ai"
 	usecasecomment "write_base/internal/usecase/comment"
 	usecasefollow "write_base/internal/usecase/follow"
+	usecasemention "write_base/internal/usecase/mention"
+	usecasenotification "write_base/internal/usecase/notification"
 	usecasereaction "write_base/internal/usecase/reaction"
 	usecasereport "write_base/internal/usecase/report"
 
@@ -108,6 +113,93 @@ func startCleanupJob(userRepo domain.IUserRepository, interval, expiration time.
 		}
 	}()
 }
+func startTrendingJob(trending domain.ITrendingUsecase, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx := context.Background()
+			if _, err := trending.RecomputeScores(ctx); err != nil {
+				fmt.Println("Trending score job error:", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+// startAICacheTrimJob keeps the shared AI cache within its entry cap.
+func startAICacheTrimJob(cache *repository.AICacheRepositoryImpl, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			<-ticker.C
+			if _, err := cache.Trim(context.Background()); err != nil {
+				fmt.Println("AI cache trim job error:", err)
+			}
+		}
+	}()
+}
+// startStatsReconcileJob repairs article counters that drifted from their
+// source collections, logging what it corrected.
+func startStatsReconcileJob(stats domain.IStatsReconcileUsecase, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx := context.Background()
+			report, err := stats.Reconcile(ctx, false)
+			if err != nil {
+				fmt.Println("Stats reconcile job error:", err)
+			} else if len(report.Drifted) > 0 {
+				fmt.Printf("Stats reconcile job: %d of %d articles drifted, %d fixed\n", len(report.Drifted), report.Checked, report.Fixed)
+			}
+			<-ticker.C
+		}
+	}()
+}
+// startSuggestRefreshJob keeps the autocomplete tries in step with new
+// articles, tags and users.
+func startSuggestRefreshJob(suggest domain.ISuggestUsecase, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx := context.Background()
+			if _, err := suggest.Refresh(ctx); err != nil {
+				fmt.Println("Suggest refresh job error:", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+// startNotificationBroker feeds the stream hub from the broker, restarting it
+// after errors such as a dropped change stream.
+func startNotificationBroker(broker domain.INotificationBroker, hub domain.INotificationHub) {
+	go func() {
+		for {
+			err := broker.Run(context.Background(), hub.Deliver)
+			log.Printf("notification broker stopped: %v; restarting", err)
+			time.Sleep(5 * time.Second)
+		}
+	}()
+}
+
+// startSearchIndexBuild fills a freshly created search index from MongoDB so
+// search works without waiting for an admin to trigger a rebuild.
+func startSearchIndexBuild(index *search.BleveIndex, articles domain.IArticleRepository) {
+	if n, err := index.DocCount(); err != nil || n > 0 {
+		return
+	}
+	go func() {
+		ctx := context.Background()
+		err := articles.ForEachPublished(ctx, 500, func(batch []domain.Article) error {
+			return index.Index(ctx, batch...)
+		})
+		if err != nil {
+			fmt.Println("Search index build error:", err)
+		}
+	}()
+}
 func startRevokedTokenCleanupJob(userRepo domain.IUserRepository, interval, olderThan time.Duration) {
 	go func() {
 		ticker := time.NewTicker(interval)
@@ -156,8 +248,9 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	// Repositories
 	articleRepo := repository.NewArticleRepository(db, "articles")
 	tagRepo := repository.NewTagRepository(db)
-	viewRepo := repository.NewViewRepository(db)
-	clapRepo := repository.NewClapRepository(db)
+	// Counted views feed trending, so they are kept for its whole window
+	viewRepo := repository.NewViewRepository(db, cfg.TrendingWindow)
+	clapRepo := repository.NewClapRepository(db, cfg.TrendingWindow)
 
 	userRepository := repository.NewUserRepository(db)
 
@@ -165,6 +258,11 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	reactionRepo := repository.NewMongoReactionRepository(db.Collection("reactions"))
 	followRepo := repository.NewMongoFollowRepository(db.Collection("follows"))
 	reportRepo := repository.NewMongoReportRepository(db.Collection("reports"))
+	promptRepo := repository.NewPromptTemplateRepository(db)
+	trendingRepo := repository.NewTrendingRepository(db)
+	articleStatsRepo := repository.NewArticleStatsRepository(db)
+	tagFollowRepo := repository.NewTagFollowRepository(db)
+	notificationRepo := repository.NewMongoNotificationRepository(db)
 
 	// Utils
 	utils := utils.NewUtils()
@@ -187,23 +285,92 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	}
 
 	// AI
-	aiClient := ai.NewGeminiClient(cfg.GeminiAPIKey)
+	var aiCache domain.IAICache
+	if cfg.AICacheBackend == "mongo" {
+		mongoCache := repository.NewAICacheRepository(db, cfg.AICacheTTL, cfg.AICacheMaxEntries)
+		startAICacheTrimJob(mongoCache, cfg.AICacheTrimInterval)
+		aiCache = mongoCache
+	} else {
+		aiCache = ai.NewMemoryCache(cfg.AICacheTTL, int(cfg.AICacheMaxEntries))
+	}
+	aiClient := ai.NewCachedClient(ai.NewGeminiClient(cfg.GeminiAPIKey), aiCache, "gemini", ai.GeminiModel)
 	// Policy
 	policy := policy.NewArticlePolicy(utils)
 
 	// Usecases
-	tagUsecase := usecase.NewTagUsecase(tagRepo, utils)
-	viewUsecase := usecase.NewViewUsecase(viewRepo, utils)
+	promptUsecase := usecase.NewPromptTemplateUsecase(promptRepo, utils)
+
+	// Moderation
+	wordList := moderation.DefaultWordList
+	if cfg.ModerationWordListPath != "" {
+		if wordList, err = moderation.LoadWordList(cfg.ModerationWordListPath); err != nil {
+			return nil, fmt.Errorf("failed to load moderation word list: %w", err)
+		}
+	}
+	moderationChecks := []domain.IModerationCheck{
+		moderation.NewWordListCheck(wordList),
+		moderation.NewSpamCheck(cfg.ModerationMaxLinks),
+	}
+	if cfg.ModerationAIClassifier {
+		moderationChecks = append(moderationChecks, moderation.NewAIClassifierCheck(aiClient, promptUsecase))
+	}
+	moderationService := moderation.NewService(reportRepo, moderationChecks...)
+
+	viewUsecase := usecase.NewViewUsecase(viewRepo, articleRepo, utils, cfg.ViewWindow)
 	clapUsecase := usecase.NewClapUsecase(clapRepo, utils)
-	articleUsecase := usecase.NewArticleUsecase(articleRepo, policy, utils, tagUsecase, viewUsecase, clapUsecase, aiClient)
+	// Search
+	var searchIndex domain.ISearchIndex = search.NewMongoIndex(articleRepo)
+	if cfg.SearchBackend == "bleve" {
+		bleveIndex, err := search.NewBleveIndex(cfg.SearchIndexPath, articleRepo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open search index: %w", err)
+		}
+		searchIndex = bleveIndex
+	}
+	tagUsecase := usecase.NewTagUsecase(tagRepo, utils, articleRepo, tagFollowRepo, searchIndex)
+	// Streams are served from this instance's hub; the broker brings it
+	// notifications stored by any instance
+	notificationHub := realtime.NewHub()
+	var notificationBroker domain.INotificationBroker = realtime.NewLocalBroker()
+	if cfg.NotificationBroker == "changestream" {
+		notificationBroker = repository.NewNotificationChangeStream(db)
+	}
+	startNotificationBroker(notificationBroker, notificationHub)
+	notificationUsecase := usecasenotification.NewNotificationUsecase(notificationRepo, userRepository, notificationHub, notificationBroker)
+	// Mention events reach other features through listeners subscribed here
+	mentionService := usecasemention.NewMentionService(userRepository, notificationUsecase)
+	articleUsecase := usecase.NewArticleUsecase(articleRepo, policy, utils, tagUsecase, viewUsecase, clapUsecase, aiClient, promptUsecase, moderationService, searchIndex, mentionService, notificationUsecase).(*usecase.ArticleUsecase)
+	articleUsecase.TrendingWindow = cfg.TrendingWindow
+	if bleveIndex, ok := searchIndex.(*search.BleveIndex); ok {
+		startSearchIndexBuild(bleveIndex, articleRepo)
+	}
 
-	userUsecase := usecase.NewUserUsecase(userRepository, passwordService, tokenService, emailService)
+	userUsecase := usecase.NewUserUsecase(userRepository, passwordService, tokenService, emailService, moderationService, tagFollowRepo)
 
-	commentUsecase := usecasecomment.NewCommentUsecase(commentRepo)
-	reactionUsecase := usecasereaction.NewReactionService(reactionRepo)
-	followUsecase := usecasefollow.NewFollowService(followRepo)
-	reportUsecase := usecasereport.NewReportService(reportRepo)
-	aiGemini := usecaseai.NewGeminiClient(cfg.GeminiAPIKey)
+	commentUsecase := usecasecomment.NewCommentUsecase(commentRepo, articleRepo, moderationService, mentionService, notificationUsecase)
+	reactionSet, err := domain.ParseReactionSet(cfg.ReactionTypes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REACTION_TYPES: %w", err)
+	}
+	reactionUsecase := usecasereaction.NewReactionService(reactionRepo, articleRepo, commentRepo, reactionSet)
+	followUsecase := usecasefollow.NewFollowService(followRepo, notificationUsecase)
+	reportUsecase := usecasereport.NewReportService(reportRepo, articleRepo, notificationUsecase)
+	trendingUsecase := usecase.NewTrendingUsecase(trendingRepo, domain.TrendingConfig{
+		Window:         cfg.TrendingWindow,
+		HalfLife:       cfg.TrendingHalfLife,
+		ViewWeight:     cfg.TrendingViewWeight,
+		ClapWeight:     cfg.TrendingClapWeight,
+		CommentWeight:  cfg.TrendingCommentWeight,
+		ReactionWeight: cfg.TrendingReactionWeight,
+	})
+	startTrendingJob(trendingUsecase, cfg.TrendingInterval)
+	startStatsReconcileJob(usecase.NewStatsReconcileUsecase(articleStatsRepo), cfg.StatsReconcileInterval)
+	tagFollowUsecase := usecase.NewTagFollowUsecase(tagRepo, tagFollowRepo)
+	feedUsecase := usecase.NewFeedUsecase(repository.NewFeedRepository(db), followRepo, tagFollowRepo)
+	relatedUsecase := usecase.NewRelatedUsecase(articleRepo, repository.NewRelatedRepository(db))
+	suggestUsecase := usecase.NewSuggestUsecase(repository.NewSuggestRepository(db))
+	startSuggestRefreshJob(suggestUsecase, cfg.SuggestRefreshInterval)
+	aiGemini := usecaseai.NewGeminiClient(cfg.GeminiAPIKey, promptUsecase, aiCache)
 
 	// Handlers
 	tagHandler := controller.NewTagHandler(tagUsecase)
@@ -215,19 +382,39 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	reactionController := controller.NewReactionController(reactionUsecase)
 	followController := controller.NewFollowController(followUsecase)
 	reportController := controller.NewReportController(reportUsecase)
+	notificationController := controller.NewNotificationController(notificationUsecase, cfg.NotificationHeartbeat)
 	aiController := controller.NewAIController(aiGemini)
-
-	r := gin.Default()
+	promptController := controller.NewPromptController(promptUsecase)
+	moderationController := controller.NewModerationController(moderationService)
+	suggestController := controller.NewSuggestController(suggestUsecase)
+	relatedController := controller.NewRelatedController(relatedUsecase)
+	feedController := controller.NewFeedController(feedUsecase)
+	tagFollowController := controller.NewTagFollowController(tagFollowUsecase)
+
+	r, err := router.NewEngine(cfg.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
 	r.Use(enableCORS())
-	router.RegisterArticleRouter(r, articleHandler)
+	router.RegisterArticleRouter(r, articleHandler, authMiddleware)
 	router.RegisterTagRouter(r, tagHandler)
 
 	router.UserRouter(r, userController, authMiddleware)
-	router.RegisterCommentRoutes(r, commentController)
-	router.RegisterReactionRoutes(r, reactionController)
-	router.RegisterFollowRoutes(r, followController)
-	router.RegisterReportRoutes(r, reportController)
+	router.RegisterCommentRoutes(r, commentController, authMiddleware)
+	router.RegisterReactionRoutes(r, reactionController, authMiddleware)
+	router.RegisterFollowRoutes(r, followController, authMiddleware)
+	router.RegisterReportRoutes(r, reportController, authMiddleware)
+	router.RegisterNotificationRoutes(r, notificationController, authMiddleware)
+	router.RegisterSuggestRoutes(r, suggestController)
+	router.RegisterRelatedRoutes(r, relatedController, authMiddleware)
+	router.RegisterFeedRoutes(r, feedController, authMiddleware)
+	router.RegisterTagFollowRoutes(r, tagFollowController, authMiddleware)
+	router.RegisterTagAdminRoutes(r, tagHandler, authMiddleware)
 	router.RegisterAIRoutes(r, aiController)
+	router.RegisterAIAdminRoutes(r, aiController, authMiddleware)
+	router.RegisterSearchAdminRoutes(r, articleHandler, authMiddleware)
+	router.RegisterPromptRoutes(r, promptController, authMiddleware)
+	router.RegisterModerationRoutes(r, moderationController, authMiddleware)
 
 	return &Container{
 		Router:      r,
@@ -255,11 +442,21 @@ func ensureArticleIndexes(ctx context.Context, db *mongo.Database) error {
 			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "timestamps.published_at", Value: -1}},
 			Options: options.Index().SetName("status_publishedat"),
 		},
+		// Feed: Equality(status), Equality(author_id), Sort/Range(published_at)
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "author_id", Value: 1}, {Key: "timestamps.published_at", Value: -1}},
+			Options: options.Index().SetName("status_author_publishedat"),
+		},
 		// Popular sort by view_count within published
 		{
 			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "stats.view_count", Value: -1}},
 			Options: options.Index().SetName("status_viewcount"),
 		},
+		// Trending: Equality(status), Sort(trending_score) within the publish window
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "stats.trending_score", Value: -1}, {Key: "timestamps.published_at", Value: -1}},
+			Options: options.Index().SetName("status_trendingscore_publishedat"),
+		},
 		// Trending: Equality(status), Sort(view_count), Range(published_at)
 		{
 			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "stats.view_count", Value: -1}, {Key: "timestamps.published_at", Value: -1}},
@@ -282,15 +479,31 @@ func ensureArticleIndexes(ctx context.Context, db *mongo.Database) error {
 		return err
 	}
 
-	// Optional text index for future text search on title/excerpt
+	// Text index for search over title, excerpt and content block text.
 	// Use default_language and language_override "none" to avoid unsupported language override errors
 	textModel := mongo.IndexModel{
-		Keys: bson.D{{Key: "status", Value: 1}, {Key: "title", Value: "text"}, {Key: "excerpt", Value: "text"}},
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "title", Value: "text"},
+			{Key: "excerpt", Value: "text"},
+			{Key: "content_blocks.content.heading.text", Value: "text"},
+			{Key: "content_blocks.content.paragraph.text", Value: "text"},
+			{Key: "content_blocks.content.list.items", Value: "text"},
+			{Key: "content_blocks.content.code.code", Value: "text"},
+			{Key: "content_blocks.content.image.caption", Value: "text"},
+		},
 		Options: options.Index().
 			SetDefaultLanguage("none").
 			SetLanguageOverride("none").
-			SetName("text_title_excerpt"),
+			SetWeights(bson.D{
+				{Key: "title", Value: 10},
+				{Key: "excerpt", Value: 5},
+				{Key: "content_blocks.content.heading.text", Value: 3},
+			}).
+			SetName("text_article_content"),
 	}
+	// A collection can only have one text index; replace the old title/excerpt one
+	_, _ = coll.Indexes().DropOne(ctx, "text_title_excerpt")
 	// Ignore error if text index cannot be created due to old server; it's optional
 	_, _ = coll.Indexes().CreateOne(ctx, textModel)
 